@@ -14,12 +14,15 @@
 package api
 
 import (
-	"log"
 	"strings"
 
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 	"golang.org/x/exp/slices"
 )
 
+// The values allowed for the type of an Async
+var AsyncTypes = []string{"OpAsync", "PollAsync"}
+
 // Base class from which other Async classes can inherit.
 type Async struct {
 	// Describes an operation
@@ -129,14 +132,21 @@ func (a *Async) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (a *Async) Validate() {
+func (a *Async) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
+
+	if !slices.Contains(AsyncTypes, a.Type) {
+		errs.Add("type", "Value on `type` should be one of %#v", AsyncTypes)
+	}
+
 	if a.Type == "OpAsync" {
 		if a.Operation == nil {
-			log.Fatalf("Missing `Operation` for OpAsync")
+			errs.Add("operation", "Missing `Operation` for OpAsync")
 		} else {
 			if a.Operation.BaseUrl != "" && a.Operation.FullUrl != "" {
-				log.Fatalf("`base_url` and `full_url` cannot be set at the same time in OpAsync operation.")
+				errs.Add("operation", "`base_url` and `full_url` cannot be set at the same time in OpAsync operation.")
 			}
 		}
 	}
+	return errs
 }
@@ -15,16 +15,18 @@ package api
 
 import (
 	"bytes"
-	"log"
+	"fmt"
 	"os"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 )
 
-func Compile(yamlPath string, obj interface{}, overrideDir string) {
+// Reads the YAML file into obj, returning any problems found while decoding
+// it. Semantic validation of obj is left to the caller.
+func Compile(yamlPath string, obj interface{}, overrideDir string) google.ValidationErrors {
 	objYaml, err := os.ReadFile(yamlPath)
 	if err != nil {
-		log.Fatalf("Cannot open the file: %v", objYaml)
+		return google.ValidationErrors{{File: yamlPath, Message: fmt.Sprintf("Cannot open the file: %v", err)}}
 	}
 
 	if overrideDir != "" {
@@ -32,5 +34,5 @@ func Compile(yamlPath string, obj interface{}, overrideDir string) {
 	}
 
 	yamlValidator := google.YamlValidator{}
-	yamlValidator.Parse(objYaml, obj, yamlPath)
+	return yamlValidator.Parse(objYaml, obj, yamlPath)
 }
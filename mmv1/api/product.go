@@ -68,6 +68,9 @@ type Product struct {
 	LegacyName string `yaml:"legacy_name,omitempty"`
 
 	ClientName string `yaml:"client_name,omitempty"`
+
+	// The directory the product was loaded from, e.g. products/compute
+	SourceDirectory string `yaml:"-"`
 }
 
 func (p *Product) UnmarshalYAML(unmarshal func(any) error) error {
@@ -84,15 +87,19 @@ func (p *Product) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (p *Product) Validate() {
+// Validates the product definition, returning every problem found. Problems
+// with the product's resources are reported by Resource.Validate.
+func (p *Product) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
+
 	if len(p.Name) == 0 {
-		log.Fatalf("Missing `name` for product")
+		errs.Add("name", "Missing `name` for product")
 	}
 
 	// product names must start with a capital
 	for i, ch := range p.Name {
 		if !unicode.IsUpper(ch) {
-			log.Fatalf("product name `%s` must start with a capital letter.", p.Name)
+			errs.Add("name", "product name `%s` must start with a capital letter.", p.Name)
 		}
 		if i == 0 {
 			break
@@ -100,20 +107,22 @@ func (p *Product) Validate() {
 	}
 
 	if len(p.Scopes) == 0 {
-		log.Fatalf("Missing `scopes` for product %s", p.Name)
+		errs.Add("scopes", "Missing `scopes` for product %s", p.Name)
 	}
 
 	if p.Versions == nil {
-		log.Fatalf("Missing `versions` for product %s", p.Name)
+		errs.Add("versions", "Missing `versions` for product %s", p.Name)
 	}
 
 	for _, v := range p.Versions {
-		v.Validate(p.Name)
+		errs.Append(google.JoinValidationPath("versions", v.Name), v.Validate(p.Name))
 	}
 
 	if p.Async != nil {
-		p.Async.Validate()
+		errs.Append("async", p.Async.Validate())
 	}
+
+	return errs
 }
 
 // ====================
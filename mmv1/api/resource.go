@@ -29,6 +29,14 @@ import (
 const RELATIVE_MAGICIAN_LOCATION = "mmv1/"
 const GITHUB_BASE_URL = "https://github.com/GoogleCloudPlatform/magic-modules/tree/main/" + RELATIVE_MAGICIAN_LOCATION
 
+// The HTTP verbs allowed for each method of a resource
+var (
+	CreateVerbs = []string{"POST", "PUT", "PATCH"}
+	ReadVerbs   = []string{"GET", "POST"}
+	UpdateVerbs = []string{"POST", "PUT", "PATCH"}
+	DeleteVerbs = []string{"POST", "PUT", "PATCH", "DELETE"}
+)
+
 type Resource struct {
 	Name string
 
@@ -210,6 +218,12 @@ type Resource struct {
 	// resource.
 	Mutex string `yaml:"mutex,omitempty"`
 
+	// [Optional] (Api::Resource::Batching) If set, create and delete requests
+	// for the resource are combined into batch requests by the provider's
+	// request batcher. If `mutex` is also set, the lock is held while each
+	// batch is sent rather than around each request.
+	Batching *resource.Batching `yaml:"batching,omitempty"`
+
 	// Examples in documentation. Backed by generated tests, and have
 	// corresponding OiCS walkthroughs.
 	Examples []resource.Examples
@@ -230,6 +244,22 @@ type Resource struct {
 	// Override sweeper settings
 	Sweeper resource.Sweeper `yaml:"sweeper,omitempty"`
 
+	// If set, generates a singular data source that reads an existing resource
+	// through the read of the resource, eg: google_secret_manager_secret
+	Datasource *resource.Datasource `yaml:"datasource,omitempty"`
+
+	// If set, generates a plural data source that lists the resources of the
+	// collection, with each item flattened like the resource itself.
+	ListDatasource *resource.ListDatasource `yaml:"list_datasource,omitempty"`
+
+	// If true, skip generating the list resource that `terraform query` uses to
+	// find existing resources. See HasListResource for which resources have one.
+	ExcludeListResource bool `yaml:"exclude_list_resource,omitempty"`
+
+	// Custom (non-CRUD) API methods of the resource, such as start or
+	// promote, called when the resource is created, updated or deleted.
+	CustomMethods []resource.CustomMethod `yaml:"custom_methods,omitempty"`
+
 	Timeouts *Timeouts `yaml:"timeouts,omitempty"`
 
 	// An array of function names that determine whether an error is retryable.
@@ -269,6 +299,19 @@ type Resource struct {
 	// public ca external account keys
 	ExcludeRead bool `yaml:"exclude_read,omitempty"`
 
+	// If true, the resource is generated as a Terraform Plugin Framework
+	// resource instead of an SDKv2 resource. Only a subset of MMv1 features
+	// is supported for these resources, and the others are rejected when the
+	// resource is validated.
+	FrameworkResource bool `yaml:"framework_resource,omitempty"`
+
+	// If true, generates typed Go structs for the API object of the resource,
+	// with typed expanders from its Terraform configuration and flatteners to
+	// its state, in resource_<name>_api_types.go. Only a subset of MMv1
+	// features is supported, and the others are rejected when the resource is
+	// validated.
+	GenerateApiTypes bool `yaml:"generate_api_types,omitempty"`
+
 	// Set to true for resources that wish to disable automatic generation of default provider
 	// value customdiff functions
 	// TODO rewrite: 1 instance used
@@ -340,6 +383,11 @@ type Resource struct {
 	// fine-grained resources and legacy resources.
 	ApiResourceTypeKind string `yaml:"api_resource_type_kind,omitempty"`
 
+	// [Optional] The IDs of lint rules that shouldn't report findings for
+	// this resource, e.g. `missing-examples` for a resource that can't be
+	// tested.
+	LintIgnore []string `yaml:"lint_ignore,omitempty"`
+
 	ImportPath     string `yaml:"-"`
 	SourceYamlFile string `yaml:"-"`
 }
@@ -379,6 +427,15 @@ func (r *Resource) SetDefault(product *Product) {
 	if r.IdFormat == "" {
 		r.IdFormat = r.SelfLinkUri()
 	}
+	for i := range r.CustomMethods {
+		r.CustomMethods[i].SetDefault(r.SelfLinkUri())
+	}
+	if r.NestedQuery != nil {
+		r.NestedQuery.SetDefault()
+	}
+	if r.Batching != nil {
+		r.Batching.SetDefault()
+	}
 
 	if len(r.VirtualFields) > 0 {
 		for _, f := range r.VirtualFields {
@@ -402,13 +459,17 @@ func (r *Resource) SetDefault(product *Product) {
 
 }
 
-func (r *Resource) Validate() {
+// Validates the resource definition, returning every problem found. The
+// paths of the returned problems are relative to the resource YAML file.
+func (r *Resource) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
+
 	if r.Name == "" {
-		log.Fatalf("Missing `name` for resource")
+		errs.Add("name", "Missing `name` for resource")
 	}
 
 	if r.NestedQuery != nil && r.NestedQuery.IsListOfIds && len(r.Identity) != 1 {
-		log.Fatalf("`is_list_of_ids: true` implies resource has exactly one `identity` property")
+		errs.Add("identity", "`is_list_of_ids: true` implies resource has exactly one `identity` property")
 	}
 
 	// Ensures we have all properties defined
@@ -417,59 +478,294 @@ func (r *Resource) Validate() {
 			return p.Name == i
 		})
 		if !hasIdentify {
-			log.Fatalf("Missing property/parameter for identity %s", i)
+			errs.Add("identity", "Missing property/parameter for identity %s", i)
 		}
 	}
 
 	if r.Description == "" {
-		log.Fatalf("Missing `description` for resource %s", r.Name)
+		errs.Add("description", "Missing `description` for resource %s", r.Name)
 	}
 
 	if !r.Exclude {
 		if len(r.Properties) == 0 {
-			log.Fatalf("Missing `properties` for resource %s", r.Name)
+			errs.Add("properties", "Missing `properties` for resource %s", r.Name)
 		}
 	}
 
-	allowed := []string{"POST", "PUT", "PATCH"}
-	if !slices.Contains(allowed, r.CreateVerb) {
-		log.Fatalf("Value on `create_verb` should be one of %#v", allowed)
+	if !slices.Contains(CreateVerbs, r.CreateVerb) {
+		errs.Add("create_verb", "Value on `create_verb` should be one of %#v", CreateVerbs)
 	}
 
-	allowed = []string{"GET", "POST"}
-	if !slices.Contains(allowed, r.ReadVerb) {
-		log.Fatalf("Value on `read_verb` should be one of %#v", allowed)
+	if !slices.Contains(ReadVerbs, r.ReadVerb) {
+		errs.Add("read_verb", "Value on `read_verb` should be one of %#v", ReadVerbs)
 	}
 
-	allowed = []string{"POST", "PUT", "PATCH", "DELETE"}
-	if !slices.Contains(allowed, r.DeleteVerb) {
-		log.Fatalf("Value on `delete_verb` should be one of %#v", allowed)
+	if !slices.Contains(DeleteVerbs, r.DeleteVerb) {
+		errs.Add("delete_verb", "Value on `delete_verb` should be one of %#v", DeleteVerbs)
 	}
 
-	allowed = []string{"POST", "PUT", "PATCH"}
-	if !slices.Contains(allowed, r.UpdateVerb) {
-		log.Fatalf("Value on `update_verb` should be one of %#v", allowed)
+	if !slices.Contains(UpdateVerbs, r.UpdateVerb) {
+		errs.Add("update_verb", "Value on `update_verb` should be one of %#v", UpdateVerbs)
 	}
 
-	for _, property := range r.AllProperties() {
-		property.Validate(r.Name)
+	for _, property := range r.Properties {
+		errs.Append(google.JoinValidationPath("properties", property.Name), property.Validate(r.Name))
+	}
+
+	for _, parameter := range r.Parameters {
+		errs.Append(google.JoinValidationPath("parameters", parameter.Name), parameter.Validate(r.Name))
 	}
 
 	if r.IamPolicy != nil {
-		r.IamPolicy.Validate(r.Name)
+		errs.Append("iam_policy", r.IamPolicy.Validate(r.Name))
 	}
 
 	if r.NestedQuery != nil {
-		r.NestedQuery.Validate(r.Name)
+		errs.Append("nested_query", r.NestedQuery.Validate(r.Name))
+	}
+
+	if r.HasNestedQueryEtag() {
+		errs.Append("", r.validateNestedQueryEtag())
 	}
 
+	if r.Batching != nil {
+		errs.Append("batching", r.Batching.Validate(r.Name))
+		errs.Append("", r.validateBatching())
+	}
+
+	errs.Append("", r.validateStateLineage())
+
 	for _, example := range r.Examples {
-		example.Validate(r.Name)
+		errs.Append(google.JoinValidationPath("examples", example.Name), example.Validate(r.Name))
+		if example.OfflineTest && r.NestedQuery != nil {
+			errs.Add(google.JoinValidationPath("examples", example.Name, "offline_test"), "`offline_test` is not supported for nested queries in resource %s", r.Name)
+		}
 	}
 
 	if r.Async != nil {
-		r.Async.Validate()
+		errs.Append("async", r.Async.Validate())
+	}
+
+	if r.Datasource != nil && (r.FrameworkResource || r.ExcludeRead) {
+		errs.Add("datasource", "`datasource` is not supported for framework resources or resources excluding read in resource %s", r.Name)
+	}
+
+	if r.ListDatasource != nil {
+		errs.Append("list_datasource", r.ListDatasource.Validate(r.Name))
+		if r.FrameworkResource || r.NestedQuery != nil || r.ExcludeRead {
+			errs.Add("list_datasource", "`list_datasource` is not supported for framework resources, nested queries or resources excluding read in resource %s", r.Name)
+		}
+	}
+
+	for _, m := range r.CustomMethods {
+		errs.Append(google.JoinValidationPath("custom_methods", m.Name), r.validateCustomMethod(m))
+	}
+
+	if r.FrameworkResource {
+		errs.Append("", r.validateFrameworkResource())
+	}
+
+	if r.GenerateApiTypes {
+		errs.Append("", r.validateApiTypes())
+	}
+
+	return errs
+}
+
+// Reports custom code that conflicts with the etag of a nested query. The
+// request body is built again when it's retried, so custom code can't change
+// it before it's sent, and custom CRUD code would skip the retries.
+func (r *Resource) validateNestedQueryEtag() google.ValidationErrors {
+	var errs google.ValidationErrors
+
+	for _, c := range []struct {
+		name string
+		set  bool
+	}{
+		{"pre_create", r.CustomCode.PreCreate != ""},
+		{"pre_update", r.CustomCode.PreUpdate != ""},
+		{"pre_delete", r.CustomCode.PreDelete != ""},
+		{"post_read", r.CustomCode.PostRead != ""},
+		{"custom_create", r.CustomCode.CustomCreate != ""},
+		{"custom_update", r.CustomCode.CustomUpdate != ""},
+		{"custom_delete", r.CustomCode.CustomDelete != ""},
+	} {
+		if c.set {
+			errs.Add(google.JoinValidationPath("custom_code", c.name), "`%s` is not supported with `nested_query.etag` in resource %s", c.name, r.Name)
+		}
+	}
+
+	return errs
+}
+
+// Reports features that conflict with batching. Batched requests are sent
+// with the body built by the generated create and delete code, so custom
+// CRUD code and nested queries that patch the parent can't be batched.
+func (r *Resource) validateBatching() google.ValidationErrors {
+	var errs google.ValidationErrors
+
+	if r.NestedQuery != nil && r.NestedQuery.ModifyByPatch {
+		errs.Add("batching", "`batching` is not supported with `nested_query.modify_by_patch` in resource %s", r.Name)
+	}
+	if r.Batching.BatchesAction("create") && r.CustomCode.CustomCreate != "" {
+		errs.Add(google.JoinValidationPath("custom_code", "custom_create"), "`custom_create` is not supported when `batching` includes create in resource %s", r.Name)
+	}
+	if r.Batching.BatchesAction("delete") && r.CustomCode.CustomDelete != "" {
+		errs.Add(google.JoinValidationPath("custom_code", "custom_delete"), "`custom_delete` is not supported when `batching` includes delete in resource %s", r.Name)
+	}
+	// The operation of a batch creates every resource in it, so the resource
+	// inside its response isn't the created one
+	if r.BatchWaitsForOperation("create") && r.GetAsync().Result.ResourceInsideResponse {
+		errs.Add(google.JoinValidationPath("async", "result", "resource_inside_response"), "`resource_inside_response` is not supported when `batching` includes create in resource %s", r.Name)
+	}
+
+	return errs
+}
+
+// Reports the features used by the resource that typed API structs don't
+// support. The generated create, read and update code sends and reads the
+// typed API object, so custom code that changes the untyped request or
+// response can't be used.
+func (r *Resource) validateApiTypes() google.ValidationErrors {
+	var errs google.ValidationErrors
+
+	unsupported := []struct {
+		field string
+		used  bool
+	}{
+		{"framework_resource", r.FrameworkResource},
+		{"nested_query", r.NestedQuery != nil},
+		{"batching", r.Batching != nil},
+		{"has_self_link", r.HasSelfLink},
+		{google.JoinValidationPath("custom_code", "encoder"), r.CustomCode.Encoder != ""},
+		{google.JoinValidationPath("custom_code", "update_encoder"), r.CustomCode.UpdateEncoder != ""},
+		{google.JoinValidationPath("custom_code", "decoder"), r.CustomCode.Decoder != ""},
+		{google.JoinValidationPath("custom_code", "pre_create"), r.CustomCode.PreCreate != ""},
+		{google.JoinValidationPath("custom_code", "pre_update"), r.CustomCode.PreUpdate != ""},
+		{google.JoinValidationPath("custom_code", "post_read"), r.CustomCode.PostRead != ""},
+	}
+	for _, u := range unsupported {
+		if u.used {
+			errs.Add(u.field, "`%s` is not supported with `generate_api_types`", u.field)
+		}
+	}
+
+	errs.Append("", validateApiStructFields("properties", r.ApiStructProperties()))
+
+	var walk func(path string, props []*Type)
+	walk = func(path string, props []*Type) {
+		for _, p := range props {
+			pPath := google.JoinValidationPath(path, p.Name)
+			if field := p.ApiTypesUnsupportedField(); field != "" {
+				errs.Add(google.JoinValidationPath(pPath, field), "`%s` of property %s is not supported with `generate_api_types`", field, p.Name)
+			}
+			if object := p.ApiObject(); object != nil {
+				errs.Append(pPath, validateApiStructFields("properties", object.ApiStructProperties()))
+				walk(google.JoinValidationPath(pPath, "properties"), object.UserProperties())
+			}
+		}
 	}
+	walk("properties", r.AllUserProperties())
+
+	return errs
+}
+
+// Reports properties whose API names collide in a typed API struct
+func validateApiStructFields(path string, props []*Type) google.ValidationErrors {
+	var errs google.ValidationErrors
+	fields := make(map[string]string)
+	for _, p := range props {
+		if other, ok := fields[p.ApiStructField()]; ok {
+			errs.Add(google.JoinValidationPath(path, p.Name), "Properties %s and %s have the same field %s in the typed API struct", other, p.Name, p.ApiStructField())
+			continue
+		}
+		fields[p.ApiStructField()] = p.Name
+	}
+	return errs
+}
+
+// Validates a custom method against the rest of the resource
+func (r *Resource) validateCustomMethod(m resource.CustomMethod) google.ValidationErrors {
+	errs := m.Validate(r.Name)
+
+	if m.Trigger != nil && m.Trigger.Field != "" {
+		found := slices.ContainsFunc(google.Concat(r.AllUserProperties(), r.VirtualFields), func(p *Type) bool {
+			return google.Underscore(p.Name) == m.TriggerField()
+		})
+		if !found {
+			errs.Add("trigger.field", "Missing property or virtual field %s for trigger of custom method %s in resource %s", m.Trigger.Field, m.Name, r.Name)
+		}
+	}
+
+	if m.Async {
+		if async := r.GetAsync(); async == nil || !async.IsA("OpAsync") {
+			errs.Add("async", "`async` on custom method %s requires an OpAsync `async` on resource %s", m.Name, r.Name)
+		}
+	}
+
+	customCode := map[string]string{
+		"create": r.CustomCode.CustomCreate,
+		"update": r.CustomCode.CustomUpdate,
+		"delete": r.CustomCode.CustomDelete,
+	}
+	if customCode[m.Phase] != "" {
+		errs.Add("phase", "Custom method %s can't be called on %s because resource %s uses `custom_code.custom_%s`", m.Name, m.Phase, r.Name, m.Phase)
+	}
+
+	return errs
+}
+
+// The custom diffs added to resources with labels or annotations at the root
+// level, which framework resources implement in their plan instead.
+var frameworkLabelsCustomDiffs = []string{
+	"tpgresource.SetLabelsDiff",
+	"tpgresource.SetLabelsDiffWithoutAttributionLabel",
+	"tpgresource.SetAnnotationsDiff",
+}
+
+// Reports the features used by the resource that plugin-framework resources
+// don't support yet.
+func (r *Resource) validateFrameworkResource() google.ValidationErrors {
+	var errs google.ValidationErrors
+
+	unsupported := []struct {
+		field string
+		used  bool
+	}{
+		{"custom_code", r.CustomCode != resource.CustomCode{}},
+		{"custom_diff", slices.ContainsFunc(r.CustomDiff, func(f string) bool { return !slices.Contains(frameworkLabelsCustomDiffs, f) })},
+		{"nested_query", r.NestedQuery != nil},
+		{"virtual_fields", len(r.VirtualFields) > 0},
+		{"mutex", r.Mutex != ""},
+		{"batching", r.Batching != nil},
+		{"exclude_read", r.ExcludeRead},
+		{"schema_version", r.SchemaVersion > 0},
+		{"migrate_state", r.MigrateState != ""},
+		{"supports_indirect_user_project_override", r.SupportsIndirectUserProjectOverride},
+		{"legacy_long_form_project", r.LegacyLongFormProject},
+		{"has_self_link", r.HasSelfLink},
+		{"read_error_transform", r.ReadErrorTransform != ""},
+		{"custom_methods", len(r.CustomMethods) > 0},
+	}
+	for _, u := range unsupported {
+		if u.used {
+			errs.Add(u.field, "`%s` is not supported for framework resources", u.field)
+		}
+	}
+
+	if async := r.GetAsync(); async != nil && async.IsA("PollAsync") {
+		errs.Add("async", "PollAsync is not supported for framework resources")
+	}
+
+	for _, property := range r.Properties {
+		errs.Append(google.JoinValidationPath("properties", property.Name), property.validateFrameworkProperty(false))
+	}
+
+	for _, parameter := range r.Parameters {
+		errs.Append(google.JoinValidationPath("parameters", parameter.Name), parameter.validateFrameworkProperty(false))
+	}
+
+	return errs
 }
 
 // ====================
@@ -588,6 +884,21 @@ func (r Resource) WriteOnlyPropsToString() string {
 	return strings.Join(props, ", ")
 }
 
+// Returns the sorted API names of the sensitive and write-only properties,
+// whose values are redacted from the provider's HTTP log.
+func (r Resource) HttpLogRedactedFields() []string {
+	var fields []string
+
+	for _, prop := range append(r.SensitiveProps(), r.WriteOnlyProps()...) {
+		if !slices.Contains(fields, prop.ApiName) {
+			fields = append(fields, prop.ApiName)
+		}
+	}
+
+	sort.Strings(fields)
+	return fields
+}
+
 // All settable properties in the resource.
 // Fingerprints aren't *really" settable properties, but they behave like one.
 // At Create, they have no value but they can just be read in anyways, and after a Read
@@ -696,6 +1007,25 @@ func (r Resource) GetIdentity() []*Type {
 	})
 }
 
+// Return the properties that are placeholders of the self link or id format
+// of the resource and are sent to or returned by the API, in the order they
+// appear, eg: name in projects/{{project}}/topics/{{name}}.
+func (r Resource) FakeGcpIdentity() []*Type {
+	props := r.AllUserProperties()
+
+	var identity []*Type
+	for _, param := range r.ExtractIdentifiers(r.SelfLinkUri() + " " + r.GetIdFormat()) {
+		i := slices.IndexFunc(props, func(p *Type) bool {
+			return google.Underscore(p.Name) == param && !p.UrlParamOnly
+		})
+		if i == -1 || slices.Contains(identity, props[i]) {
+			continue
+		}
+		identity = append(identity, props[i])
+	}
+	return identity
+}
+
 func (r *Resource) AddLabelsRelatedFields(props []*Type, parent *Type) []*Type {
 	for _, p := range props {
 		if p.IsA("KeyValueLabels") {
@@ -815,6 +1145,15 @@ func (r Resource) RootLabels() bool {
 	return false
 }
 
+func (r Resource) RootAnnotations() bool {
+	for _, p := range r.RootProperties() {
+		if p.IsA("KeyValueAnnotations") {
+			return true
+		}
+	}
+	return false
+}
+
 // Return labels fields that should be added to ImportStateVerifyIgnore
 func (r Resource) IgnoreReadLabelsFields(props []*Type) []string {
 	fields := make([]string, 0)
@@ -964,6 +1303,15 @@ func (r Resource) UpdateBodyProperties() []*Type {
 	return updateProp
 }
 
+// Returns the update body properties whose changes are added to the update mask
+// of framework resources. Write-only properties are never planned, so like in
+// SDK resources they are only sent along with changes to other properties.
+func (r Resource) FrameworkUpdateMaskProperties() []*Type {
+	return google.Reject(r.UpdateBodyProperties(), func(p *Type) bool {
+		return p.WriteOnly
+	})
+}
+
 // Handwritten TF Operation objects will be shaped like accessContextManager
 // while the Google Go Client will have a name like accesscontextmanager
 func (r Resource) ClientNamePascal() string {
@@ -1030,7 +1378,7 @@ func (r Resource) HasZone() bool {
 // but due to how files are being inherited here it was easier to put in here
 // taken wholesale from tpgtools
 func (r Resource) Updatable() bool {
-	if !r.Immutable {
+	if !r.Immutable || len(r.CustomMethodsForPhase("update")) > 0 {
 		return true
 	}
 	for _, p := range r.AllPropertiesInVersion() {
@@ -1041,6 +1389,14 @@ func (r Resource) Updatable() bool {
 	return false
 }
 
+// Returns the custom methods called in the phase: "create", "update" or
+// "delete"
+func (r Resource) CustomMethodsForPhase(phase string) []resource.CustomMethod {
+	return google.Select(r.CustomMethods, func(m resource.CustomMethod) bool {
+		return m.Phase == phase
+	})
+}
+
 // ====================
 // Debugging Methods
 // ====================
@@ -1059,6 +1415,186 @@ func (r Resource) TerraformName() string {
 	return fmt.Sprintf("google_%s_%s", r.ProductMetadata.TerraformName(), google.Underscore(r.Name))
 }
 
+// Returns the fields of the id format of the resource, which identify the
+// resource read by its singular data source. The project, region and zone
+// are optional as they default to the provider's.
+func (r Resource) DatasourceIdentityFields() (required, optional []string) {
+	return formatFields(r.GetIdFormat())
+}
+
+// Returns the fields of a format like `projects/{{project}}/topics/{{name}}`
+// in order, split into the required ones and the project, region and zone,
+// which are optional as they default to the provider's.
+func formatFields(format string) (required, optional []string) {
+	re := regexp.MustCompile(`{{%?([[:word:]]+)}}`)
+	for _, m := range re.FindAllStringSubmatch(format, -1) {
+		field := m[1]
+		if slices.Contains(required, field) || slices.Contains(optional, field) {
+			continue
+		}
+		if field == "project" || field == "region" || field == "zone" {
+			optional = append(optional, field)
+		} else {
+			required = append(required, field)
+		}
+	}
+	return required, optional
+}
+
+// Returns the import id format of a resource imported by its Terraform
+// resource identity. It's the first import id format, which contains every
+// field of the id.
+func (r Resource) ResourceIdentityImportFormat() string {
+	formats := r.ImportIdFormatsFromResource()
+	if len(formats) == 0 {
+		return ""
+	}
+	return formats[0]
+}
+
+// Returns the attributes of the Terraform resource identity of the resource,
+// which are the fields of its identity import format.
+func (r Resource) ResourceIdentityFields() (required, optional []string) {
+	return formatFields(r.ResourceIdentityImportFormat())
+}
+
+func (r Resource) ResourceIdentityRequiredFields() []string {
+	required, _ := r.ResourceIdentityFields()
+	return required
+}
+
+func (r Resource) ResourceIdentityOptionalFields() []string {
+	_, optional := r.ResourceIdentityFields()
+	return optional
+}
+
+func (r Resource) ResourceIdentityAllFields() []string {
+	return google.Concat(r.ResourceIdentityFields())
+}
+
+func (r Resource) DatasourceRequiredFields() []string {
+	required, _ := r.DatasourceIdentityFields()
+	return required
+}
+
+func (r Resource) DatasourceOptionalFields() []string {
+	_, optional := r.DatasourceIdentityFields()
+	return optional
+}
+
+// Returns the fields of the resource whose value in the state of the resource
+// can't be read back by the data source, eg: ignore_read, virtual fields and
+// url parameters that aren't part of the identity.
+func (r Resource) DatasourceIgnoreFields() []string {
+	required, optional := r.DatasourceIdentityFields()
+	identity := google.Concat(required, optional)
+
+	fields := ignoreReadFields(r.AllUserProperties())
+	for _, p := range r.AllUserProperties() {
+		name := google.Underscore(p.Name)
+		if p.WriteOnly || (p.UrlParamOnly && !slices.Contains(identity, name)) {
+			fields = append(fields, name)
+		}
+	}
+	for _, v := range r.VirtualFields {
+		fields = append(fields, v.Name)
+	}
+	slices.Sort(fields)
+	return fields
+}
+
+// Returns the name of the example whose test also reads the created resource
+// through the singular data source, or an empty string if there is none.
+func (r Resource) DatasourceTestExampleName() string {
+	if r.Datasource == nil || r.Datasource.ExcludeTest {
+		return ""
+	}
+	for _, e := range r.TestExamples() {
+		if e.ResourceType(r.TerraformName()) == r.TerraformName() {
+			return e.Name
+		}
+	}
+	return ""
+}
+
+// Returns the Terraform name of the plural data source of the resource, eg:
+// google_artifact_registry_repositories
+func (r Resource) ListDatasourceName() string {
+	if r.ListDatasource != nil && r.ListDatasource.Name != "" {
+		return r.ListDatasource.Name
+	}
+	return fmt.Sprintf("google_%s_%s", r.ProductMetadata.TerraformName(), google.Underscore(google.Plural(r.Name)))
+}
+
+// Returns the name of the Go function that builds the plural data source of the
+// resource, eg: DataSourceArtifactRegistryRepositories
+func (r Resource) ListDatasourceFuncName() string {
+	return fmt.Sprintf("DataSource%s", google.Camelize(strings.TrimPrefix(r.ListDatasourceName(), "google_"), "upper"))
+}
+
+// Returns the parameters of the collection url of the resource, such as project
+// or location. They are the arguments of its plural data source and list
+// resource.
+func (r Resource) ListParentParams() []string {
+	var params []string
+	re := regexp.MustCompile(`{{%?([[:word:]]+)}}`)
+	for _, m := range re.FindAllStringSubmatch(r.collectionUri(), -1) {
+		if !slices.Contains(params, m[1]) {
+			params = append(params, m[1])
+		}
+	}
+	return params
+}
+
+// Returns the parameters of the collection url that have no provider default,
+// unlike project, region and zone.
+func (r Resource) ListRequiredParentParams() []string {
+	return google.Reject(r.ListParentParams(), func(p string) bool {
+		return p == "project" || p == "region" || p == "zone"
+	})
+}
+
+// Returns true if a list resource is generated for the resource, which
+// `terraform query` uses to find its existing objects and import them by
+// identity. Every field of the identity must be found in the collection url
+// or in the self link of each listed object.
+func (r Resource) HasListResource() bool {
+	if r.ExcludeListResource || r.IsExcluded() || r.FrameworkResource || r.ExcludeRead || r.ExcludeImport {
+		return false
+	}
+	// Nested objects and objects read with custom code can't be flattened from
+	// the list response like the read response.
+	if r.NestedQuery != nil || r.CustomCode.PostRead != "" || r.ResourceListKey() == "" {
+		return false
+	}
+	// Singletons, such as settings, are read at a fixed url within or at the
+	// collection url, and some objects are read by a query, such as the rules
+	// of a policy. Neither is listed at the collection url.
+	item, found := strings.CutPrefix(r.SelfLinkUri(), r.BaseUrl+"/")
+	if !found || !strings.Contains(item, "{{") || strings.Contains(item, "?") {
+		return false
+	}
+
+	identity := r.ResourceIdentityAllFields()
+	if len(identity) == 0 {
+		return false
+	}
+	params := google.Concat(formatFields(r.SelfLinkUri()))
+	params = append(params, r.ListParentParams()...)
+	for _, f := range identity {
+		if !slices.Contains(params, f) {
+			return false
+		}
+	}
+	return true
+}
+
+// Returns the name of the Go function that builds the list resource of the
+// resource, eg: NewPubsubTopicListResource
+func (r Resource) ListResourceFuncName() string {
+	return fmt.Sprintf("New%sListResource", r.ResourceName())
+}
+
 func (r Resource) ImportIdFormatsFromResource() []string {
 	return ImportIdFormats(r.ImportFormat, r.Identity, r.BaseUrl)
 }
@@ -1212,6 +1748,86 @@ func (r Resource) ReadProperties() []*Type {
 	})
 }
 
+// Returns the nested objects of a plugin-framework resource, including the
+// items of arrays of nested objects. Each of them has its own model.
+func (r Resource) FrameworkNestedObjects() []*Type {
+	var objects []*Type
+	var walk func(props []*Type)
+	walk = func(props []*Type) {
+		for _, p := range props {
+			switch {
+			case p.IsA("NestedObject"):
+				objects = append(objects, p)
+				walk(p.UserProperties())
+			case p.IsA("Array") && p.ItemType.IsA("NestedObject"):
+				objects = append(objects, p.ItemType)
+				walk(p.ItemType.UserProperties())
+			}
+		}
+	}
+	walk(r.AllUserProperties())
+	return objects
+}
+
+// Returns the name of the typed API struct of the resource, eg:
+// PubsubTopicApiObject
+func (r Resource) ApiStructName() string {
+	return fmt.Sprintf("%sApiObject", r.ResourceName())
+}
+
+// Returns the top-level properties that are fields of the typed API struct
+// of the resource.
+func (r Resource) ApiStructProperties() []*Type {
+	return google.Reject(r.AllUserProperties(), func(p *Type) bool {
+		return p.ProviderOnly()
+	})
+}
+
+// Returns the nested objects that have a typed API struct, including the
+// items of arrays and the values of maps.
+func (r Resource) ApiStructObjects() []*Type {
+	var objects []*Type
+	var walk func(props []*Type)
+	walk = func(props []*Type) {
+		for _, p := range props {
+			if object := p.ApiObject(); object != nil {
+				objects = append(objects, object)
+				walk(object.apiObjectProperties())
+			}
+		}
+	}
+	walk(r.GettableProperties())
+	return objects
+}
+
+// Returns the properties that have typed expanders: the settable top-level
+// properties and the properties of their nested objects.
+func (r Resource) ApiExpandedProperties() []*Type {
+	return apiTypedProperties(r.SettableProperties(), Type.ApiExpandProperties)
+}
+
+// Returns the properties that have typed flatteners: the read top-level
+// properties and the properties of their nested objects.
+func (r Resource) ApiFlattenedProperties() []*Type {
+	return apiTypedProperties(r.ReadProperties(), Type.ApiFlattenProperties)
+}
+
+// Walks the properties and the children of their nested objects.
+func apiTypedProperties(props []*Type, children func(Type) []*Type) []*Type {
+	var typed []*Type
+	var walk func(props []*Type)
+	walk = func(props []*Type) {
+		for _, p := range props {
+			typed = append(typed, p)
+			if object := p.ApiObject(); object != nil {
+				walk(children(*object))
+			}
+		}
+	}
+	walk(props)
+	return typed
+}
+
 func (r Resource) FlattenedProperties() []*Type {
 	return google.Select(r.ReadProperties(), func(p *Type) bool {
 		return p.FlattenObject
@@ -1595,6 +2211,40 @@ func (r Resource) LastNestedQueryKey() string {
 	return r.NestedQuery.Keys[len-1]
 }
 
+// Returns true if requests that patch the parent of a nested query resource
+// send the etag of the parent and are retried when it's stale
+func (r Resource) HasNestedQueryEtag() bool {
+	return r.NestedQuery != nil && r.NestedQuery.ModifyByPatch && r.NestedQuery.Etag != nil
+}
+
+// Returns true if requests for the action ("create" or "delete") are batched
+func (r Resource) BatchesAction(action string) bool {
+	return r.Batching.BatchesAction(action)
+}
+
+// Returns the batched actions that return an operation, which is waited for
+// before the batch returns so that the resource's mutex is held until it is
+// done
+func (r Resource) BatchOperationActions() []string {
+	async := r.GetAsync()
+	if r.Batching == nil || async == nil || !async.IsA("OpAsync") {
+		return nil
+	}
+	var actions []string
+	for _, action := range r.Batching.Actions {
+		if async.Allow(action) {
+			actions = append(actions, action)
+		}
+	}
+	return actions
+}
+
+// Returns true if the operation of batched requests for the action is waited
+// for by the batch, so the generated CRUD code doesn't wait for it again
+func (r Resource) BatchWaitsForOperation(action string) bool {
+	return slices.Contains(r.BatchOperationActions(), action)
+}
+
 func (r Resource) FirstIdentityProp() *Type {
 	idProps := r.GetIdentity()
 	if len(idProps) == 0 {
@@ -1711,6 +2361,78 @@ func (r Resource) StateUpgradersCount() []int {
 	return nums
 }
 
+// Returns the schema version of the resource, which the state lineage of its
+// properties bumps past `schema_version`
+func (r Resource) CurrentSchemaVersion() int {
+	version := r.SchemaVersion
+	for _, u := range r.StateLineageUpgrades() {
+		version = max(version, u.NextVersion())
+	}
+	return version
+}
+
+// Returns the state upgrades generated from the state lineage of the
+// properties, in order of version
+func (r Resource) StateLineageUpgrades() []resource.StateUpgrade {
+	moves := make(map[int][]resource.StateMove)
+	r.walkStateLineage(google.Concat(r.AllUserProperties(), r.UserVirtualFields()), func(p *Type) {
+		for _, l := range p.StateLineage {
+			moves[l.SchemaVersion] = append(moves[l.SchemaVersion], resource.StateMove{
+				From: l.PreviousPath(p.parentStatePathAt(l.SchemaVersion)),
+				To:   p.StatePathAt(l.SchemaVersion),
+			})
+		}
+	})
+
+	var versions []int
+	for version := range moves {
+		versions = append(versions, version)
+	}
+	sort.Ints(versions)
+
+	var upgrades []resource.StateUpgrade
+	for _, version := range versions {
+		upgrades = append(upgrades, resource.StateUpgrade{
+			Version: version - 1,
+			Moves:   moves[version],
+		})
+	}
+	return upgrades
+}
+
+// Calls f for the properties and the properties nested in them, parents
+// first, so that the moves of parents are applied before the moves of their
+// properties
+func (r Resource) walkStateLineage(props []*Type, f func(p *Type)) {
+	for _, p := range props {
+		if p.Exclude {
+			continue
+		}
+		f(p)
+		switch {
+		case p.IsA("NestedObject"):
+			r.walkStateLineage(p.Properties, f)
+		case p.IsA("Array") && p.ItemType != nil && p.ItemType.IsA("NestedObject"):
+			r.walkStateLineage(p.ItemType.Properties, f)
+		}
+	}
+}
+
+// Reports state lineage whose schema versions don't follow the handwritten
+// state upgraders of the resource
+func (r *Resource) validateStateLineage() google.ValidationErrors {
+	var errs google.ValidationErrors
+
+	upgrades := r.StateLineageUpgrades()
+	for i, u := range upgrades {
+		if u.Version != r.SchemaVersion+i {
+			errs.Add("", "The `schema_version` of the `state_lineage` of properties must count up from %d without gaps in resource %s, got %d", r.SchemaVersion+1, r.Name, u.NextVersion())
+			break
+		}
+	}
+	return errs
+}
+
 func (r Resource) CaiProductBaseUrl() string {
 	version := r.ProductMetadata.VersionObjOrClosest(r.TargetVersionName)
 	baseUrl := version.CaiBaseUrl
@@ -0,0 +1,77 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+var BatchCombines = []string{"append_list", "merge_map"}
+
+var BatchActions = []string{"create", "delete"}
+
+// Metadata for resources whose create and delete requests are combined into
+// batches with transport.RequestBatcher, eg: resources that are added to or
+// removed from a list in a shared parent by a single API method.
+type Batching struct {
+	// Template for the key requests are batched under, with the same
+	// variables as the resource urls. Only requests with the same key and
+	// action are combined.
+	// i.e. "networkEndpoints/{{project}}/{{zone}}/{{network_endpoint_group}}"
+	BatchKey string `yaml:"batch_key"`
+
+	// How the encoded request bodies are combined into one batch request.
+	// append_list: the lists in top-level fields are appended, eg:
+	//   {"items": [a]} and {"items": [b]} are sent as {"items": [a, b]}.
+	// merge_map: the bodies are merged recursively, eg:
+	//   {"labels": {"a": "1"}} and {"labels": {"b": "2"}} are sent as
+	//   {"labels": {"a": "1", "b": "2"}}.
+	Combine string
+
+	// The actions whose requests are batched. Defaults to create and delete.
+	Actions []string `yaml:"actions,omitempty"`
+
+	// The endpoint batch requests are sent to, relative to the product base
+	// url. Defaults to the create_url or delete_url of the action.
+	Url string `yaml:"url,omitempty"`
+}
+
+func (b *Batching) SetDefault() {
+	if len(b.Actions) == 0 {
+		b.Actions = slices.Clone(BatchActions)
+	}
+}
+
+func (b *Batching) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
+	if b.BatchKey == "" {
+		errs.Add("batch_key", "Missing `batch_key` for `batching` in resource %s", rName)
+	}
+	if !slices.Contains(BatchCombines, b.Combine) {
+		errs.Add("combine", "Value on `combine` should be one of %#v for `batching` in resource %s", BatchCombines, rName)
+	}
+	for _, a := range b.Actions {
+		if !slices.Contains(BatchActions, a) {
+			errs.Add("actions", "Unsupported action %q for `batching` in resource %s, should be one of %#v", a, rName, BatchActions)
+		}
+	}
+	return errs
+}
+
+// Returns true if requests for the action are batched
+func (b *Batching) BatchesAction(action string) bool {
+	return b != nil && slices.Contains(b.Actions, action)
+}
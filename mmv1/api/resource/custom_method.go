@@ -0,0 +1,208 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+var CustomMethodVerbs = []string{"POST", "PATCH", "PUT"}
+
+var CustomMethodPhases = []string{"create", "update", "delete"}
+
+// A custom (non-CRUD) API method of a resource, such as
+// instances.start or clusters.promote, that the provider calls as part
+// of creating, updating or deleting the resource.
+type CustomMethod struct {
+	// The name of the method, e.g. "start". Used in the name of the
+	// generated function and, by default, in the url.
+	Name string
+
+	// A description of the method, used in the docs
+	Description string
+
+	// The url of the method relative to the product base url. Defaults to
+	// the self link of the resource followed by ":" and the name of the
+	// method, e.g. "projects/{{project}}/instances/{{name}}:start".
+	Url string
+
+	// The HTTP verb of the method. Defaults to POST.
+	Verb string
+
+	// A request body sent to the method. Values must be scalars; string
+	// values may reference fields of the resource like urls do, e.g.
+	// "{{name}}".
+	Body map[string]interface{}
+
+	// If true, the method returns an operation that is waited on using
+	// the async configuration of the resource.
+	Async bool
+
+	// When the method is called: "create" calls it after the resource is
+	// created, "update" when the trigger field changes and "delete" before
+	// the resource is deleted. Defaults to "update".
+	Phase string
+
+	// The field that invokes the method. Required for the update phase,
+	// optional otherwise.
+	Trigger *CustomMethodTrigger
+}
+
+// The field that invokes a custom method
+type CustomMethodTrigger struct {
+	// The name of a property or virtual field of the resource
+	Field string
+
+	// If set, the method is only called when the field has this value,
+	// compared with the string form of the field
+	Value string
+}
+
+// A field of the request body of a custom method
+type CustomMethodBodyField struct {
+	Key string
+
+	// The value as a Go literal
+	Literal string
+
+	// If true, the value references fields of the resource and needs to
+	// be resolved with ReplaceVars
+	Templated bool
+}
+
+func (m *CustomMethod) SetDefault(selfLinkUri string) {
+	if m.Verb == "" {
+		m.Verb = "POST"
+	}
+	if m.Phase == "" {
+		m.Phase = "update"
+	}
+	if m.Url == "" {
+		m.Url = fmt.Sprintf("%s:%s", selfLinkUri, m.Name)
+	}
+}
+
+func (m CustomMethod) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
+
+	if m.Name == "" {
+		errs.Add("name", "Missing `name` for custom method in resource %s", rName)
+	}
+
+	if !slices.Contains(CustomMethodVerbs, m.Verb) {
+		errs.Add("verb", "Value on `verb` should be one of %#v", CustomMethodVerbs)
+	}
+
+	if !slices.Contains(CustomMethodPhases, m.Phase) {
+		errs.Add("phase", "Value on `phase` should be one of %#v", CustomMethodPhases)
+	}
+
+	if m.Trigger == nil {
+		if m.Phase == "update" {
+			errs.Add("trigger", "Missing `trigger` for custom method %s called on update in resource %s", m.Name, rName)
+		}
+	} else if m.Trigger.Field == "" {
+		errs.Add("trigger.field", "Missing `field` for trigger of custom method %s in resource %s", m.Name, rName)
+	}
+
+	for k, v := range m.Body {
+		switch v.(type) {
+		case string, bool, int, float64:
+		default:
+			errs.Add(google.JoinValidationPath("body", k), "Value of custom method %s body field %s in resource %s must be a string, number or boolean", m.Name, k, rName)
+		}
+	}
+
+	return errs
+}
+
+// Returns the name of the field that triggers the method in the Terraform
+// schema
+func (m CustomMethod) TriggerField() string {
+	if m.Trigger == nil {
+		return ""
+	}
+	return google.Underscore(m.Trigger.Field)
+}
+
+// Returns the Go condition under which the generated code calls the method,
+// or an empty string if it is always called.
+func (m CustomMethod) Condition() string {
+	if m.Trigger == nil {
+		return ""
+	}
+	field := m.TriggerField()
+
+	var conditions []string
+	if m.Phase == "update" {
+		conditions = append(conditions, fmt.Sprintf("d.HasChange(%q)", field))
+	}
+	if m.Trigger.Value != "" {
+		conditions = append(conditions, fmt.Sprintf("fmt.Sprintf(\"%%v\", d.Get(%q)) == %q", field, m.Trigger.Value))
+	} else if m.Phase != "update" {
+		conditions = append(conditions, fmt.Sprintf("!tpgresource.IsEmptyValue(reflect.ValueOf(d.Get(%q)))", field))
+	}
+	return strings.Join(conditions, " && ")
+}
+
+// Returns a sentence describing when the method is called, for the docs
+func (m CustomMethod) When() string {
+	var when string
+	switch m.Phase {
+	case "create":
+		when = "Called after the resource is created"
+	case "delete":
+		when = "Called before the resource is deleted"
+	default:
+		when = fmt.Sprintf("Called when `%s` changes", m.TriggerField())
+		if m.Trigger != nil && m.Trigger.Value != "" {
+			when += fmt.Sprintf(" to `%s`", m.Trigger.Value)
+		}
+		return when + "."
+	}
+
+	if m.Trigger != nil {
+		if m.Trigger.Value != "" {
+			when += fmt.Sprintf(" if `%s` is `%s`", m.TriggerField(), m.Trigger.Value)
+		} else {
+			when += fmt.Sprintf(" if `%s` is set", m.TriggerField())
+		}
+	}
+	return when + "."
+}
+
+// Returns the fields of the request body sorted by key
+func (m CustomMethod) BodyFields() []CustomMethodBodyField {
+	var fields []CustomMethodBodyField
+	for k, v := range m.Body {
+		field := CustomMethodBodyField{Key: k}
+		switch val := v.(type) {
+		case string:
+			field.Literal = fmt.Sprintf("%q", val)
+			field.Templated = strings.Contains(val, "{{")
+		default:
+			field.Literal = fmt.Sprintf("%v", val)
+		}
+		fields = append(fields, field)
+	}
+	sort.Slice(fields, func(i, j int) bool {
+		return fields[i].Key < fields[j].Key
+	})
+	return fields
+}
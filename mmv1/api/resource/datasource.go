@@ -0,0 +1,66 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"regexp"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// Datasource provides configuration for the singular data source of a
+// resource, which reads an existing resource by its identity.
+type Datasource struct {
+	// [Optional] If true, the generated tests don't read the resources created
+	// by the examples through the data source.
+	ExcludeTest bool `yaml:"exclude_test,omitempty"`
+}
+
+// ListDatasource provides configuration for the plural data source of a
+// resource, which lists all of the resources in its collection, eg:
+// google_secret_manager_secrets
+type ListDatasource struct {
+	// [Optional] The Terraform name of the data source. Defaults to the
+	// plural of the Terraform name of the resource, eg:
+	// google_artifact_registry_repositories
+	Name string `yaml:"name,omitempty"`
+
+	// [Optional] The query parameter of the list method used to filter the
+	// listed resources. If set, the data source has a `filter` argument.
+	FilterParam string `yaml:"filter_param,omitempty"`
+
+	// [Optional] A link to the documentation of the filter syntax, used in
+	// the description of the `filter` argument.
+	FilterDocs string `yaml:"filter_docs,omitempty"`
+
+	// [Optional] The maximum number of resources requested per page. The
+	// default page size of the API is used if unset.
+	PageSize int `yaml:"page_size,omitempty"`
+}
+
+var listDatasourceNameRegex = regexp.MustCompile(`^google_[a-z0-9_]+$`)
+
+func (l *ListDatasource) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
+	if l.Name != "" && !listDatasourceNameRegex.MatchString(l.Name) {
+		errs.Add("name", "Invalid `name` %q for `list_datasource` in resource %s", l.Name, rName)
+	}
+	if l.FilterDocs != "" && l.FilterParam == "" {
+		errs.Add("filter_docs", "`filter_docs` requires `filter_param` for `list_datasource` in resource %s", rName)
+	}
+	if l.PageSize < 0 {
+		errs.Add("page_size", "`page_size` must be positive for `list_datasource` in resource %s", rName)
+	}
+	return errs
+}
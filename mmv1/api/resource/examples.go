@@ -16,7 +16,6 @@ package resource
 import (
 	"bytes"
 	"fmt"
-	"log"
 	"net/url"
 	"path/filepath"
 	"regexp"
@@ -171,6 +170,11 @@ type Examples struct {
 	// your test so avoid if you can.
 	ExternalProviders []string `yaml:"external_providers,omitempty"`
 
+	// Whether to generate a variant of the example's test that runs against
+	// the fakegcp server instead of GCP, without credentials. Every resource
+	// in the config must be a generated resource of the same product.
+	OfflineTest bool `yaml:"offline_test,omitempty"`
+
 	DocumentationHCLText string `yaml:"-"`
 	TestHCLText          string `yaml:"-"`
 	OicsHCLText          string `yaml:"-"`
@@ -194,14 +198,19 @@ func (e *Examples) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (e *Examples) Validate(rName string) {
+func (e *Examples) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
 	if e.Name == "" {
-		log.Fatalf("Missing `name` for one example in resource %s", rName)
+		errs.Add("name", "Missing `name` for one example in resource %s", rName)
+	}
+	errs.Append("", e.ValidateExternalProviders())
+	if e.OfflineTest && (len(e.TestEnvVars) > 0 || len(e.ExternalProviders) > 0 || len(e.BootstrapIam) > 0) {
+		errs.Add("offline_test", "`offline_test` is not supported for examples using `test_env_vars`, `external_providers` or `bootstrap_iam` in resource %s", rName)
 	}
-	e.ValidateExternalProviders()
+	return errs
 }
 
-func (e *Examples) ValidateExternalProviders() {
+func (e *Examples) ValidateExternalProviders() google.ValidationErrors {
 	// Official providers supported by HashiCorp
 	// https://registry.terraform.io/search/providers?namespace=hashicorp&tier=official
 	HASHICORP_PROVIDERS := []string{"aws", "random", "null", "template", "azurerm", "kubernetes", "local",
@@ -216,9 +225,11 @@ func (e *Examples) ValidateExternalProviders() {
 		}
 	}
 
+	var errs google.ValidationErrors
 	if len(unallowedProviders) > 0 {
-		log.Fatalf("Providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders)
+		errs.Add("external_providers", "Providers %#v are not allowed. Only providers published by HashiCorp are allowed.", unallowedProviders)
 	}
+	return errs
 }
 
 // Executes example templates for documentation and tests
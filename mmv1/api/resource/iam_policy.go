@@ -14,8 +14,16 @@
 package resource
 
 import (
-	"log"
 	"slices"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// The values allowed for the verbs and request types of an IamPolicy
+var (
+	FetchIamPolicyVerbs       = []string{"GET", "POST"}
+	SetIamPolicyVerbs         = []string{"POST", "PUT"}
+	IamConditionsRequestTypes = []string{"REQUEST_BODY", "QUERY_PARAM", "QUERY_PARAM_NESTED"}
 )
 
 // Information about the IAM policy for this resource
@@ -139,19 +147,19 @@ func (p *IamPolicy) UnmarshalYAML(unmarshal func(any) error) error {
 	return nil
 }
 
-func (p *IamPolicy) Validate(rName string) {
-	allowed := []string{"GET", "POST"}
-	if !slices.Contains(allowed, p.FetchIamPolicyVerb) {
-		log.Fatalf("Value on `fetch_iam_policy_verb` should be one of %#v in resource %s", allowed, rName)
+func (p *IamPolicy) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
+
+	if !slices.Contains(FetchIamPolicyVerbs, p.FetchIamPolicyVerb) {
+		errs.Add("fetch_iam_policy_verb", "Value on `fetch_iam_policy_verb` should be one of %#v in resource %s", FetchIamPolicyVerbs, rName)
 	}
 
-	allowed = []string{"POST", "PUT"}
-	if !slices.Contains(allowed, p.SetIamPolicyVerb) {
-		log.Fatalf("Value on `set_iam_policy_verb` should be one of %#v in resource %s", allowed, rName)
+	if !slices.Contains(SetIamPolicyVerbs, p.SetIamPolicyVerb) {
+		errs.Add("set_iam_policy_verb", "Value on `set_iam_policy_verb` should be one of %#v in resource %s", SetIamPolicyVerbs, rName)
 	}
 
-	allowed = []string{"REQUEST_BODY", "QUERY_PARAM", "QUERY_PARAM_NESTED"}
-	if p.IamConditionsRequestType != "" && !slices.Contains(allowed, p.IamConditionsRequestType) {
-		log.Fatalf("Value on `iam_conditions_request_type` should be one of %#v in resource %s", allowed, rName)
+	if p.IamConditionsRequestType != "" && !slices.Contains(IamConditionsRequestTypes, p.IamConditionsRequestType) {
+		errs.Add("iam_conditions_request_type", "Value on `iam_conditions_request_type` should be one of %#v in resource %s", IamConditionsRequestTypes, rName)
 	}
+	return errs
 }
@@ -13,7 +13,7 @@
 
 package resource
 
-import "log"
+import "github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
 
 // Metadata for resources that are nested within a parent resource, as
 // a list of resources or single object within the parent.
@@ -41,10 +41,40 @@ type NestedQuery struct {
 	//  keys[-1] : list_of_objects
 	// }
 	ModifyByPatch bool `yaml:"modify_by_patch"`
+
+	// If set, requests that patch the parent resource send the etag or
+	// fingerprint it was read with, and are retried with a fresh read of the
+	// parent when the API rejects them because the parent changed since.
+	// Unlike `mutex`, this protects changes made from several Terraform
+	// processes at once. Requires `modify_by_patch`.
+	Etag *NestedQueryEtag `yaml:"etag,omitempty"`
+}
+
+// The etag or fingerprint of the parent resource of a nested query, used for
+// optimistic concurrency
+type NestedQueryEtag struct {
+	// The API name of the top-level field of the parent resource holding its
+	// etag or fingerprint. Defaults to "etag".
+	Field string
+
+	// If true, the etag is sent in an If-Match header instead of in the
+	// field of the request body.
+	IfMatch bool `yaml:"if_match,omitempty"`
 }
 
-func (q *NestedQuery) Validate(rName string) {
+func (q *NestedQuery) SetDefault() {
+	if q.Etag != nil && q.Etag.Field == "" {
+		q.Etag.Field = "etag"
+	}
+}
+
+func (q *NestedQuery) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
 	if len(q.Keys) == 0 {
-		log.Fatalf("Missing `keys` for `nested_query` in resource %s", rName)
+		errs.Add("keys", "Missing `keys` for `nested_query` in resource %s", rName)
+	}
+	if q.Etag != nil && !q.ModifyByPatch {
+		errs.Add("etag", "`etag` requires `modify_by_patch` for `nested_query` in resource %s", rName)
 	}
+	return errs
 }
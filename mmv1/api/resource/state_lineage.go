@@ -0,0 +1,141 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// A previous location of a property in the Terraform state of its resource.
+// Renaming or moving a property records where it was, so that a state
+// upgrader that moves the values of existing resources is generated.
+type StateLineage struct {
+	// The schema version of the resource that renamed or moved the property.
+	// States of earlier versions are upgraded to it.
+	SchemaVersion int `yaml:"schema_version"`
+
+	// The name the property had in the same object, eg: when `tier` is
+	// renamed to `machineTier`, `renamed_from: 'tier'`
+	RenamedFrom string `yaml:"renamed_from,omitempty"`
+
+	// The path of the property from the top level of the resource, with the
+	// names of the nested objects it was in separated by dots. The objects on
+	// the path are assumed to have been nested objects, eg: when
+	// `settings.tier` is flattened to `tier`, `moved_from: 'settings.tier'`
+	MovedFrom string `yaml:"moved_from,omitempty"`
+}
+
+func (l *StateLineage) Validate() google.ValidationErrors {
+	var errs google.ValidationErrors
+
+	if l.SchemaVersion < 1 {
+		errs.Add("schema_version", "`schema_version` must be at least 1")
+	}
+	if (l.RenamedFrom == "") == (l.MovedFrom == "") {
+		errs.Add("", "exactly one of `renamed_from` or `moved_from` must be set")
+	}
+	if strings.Contains(l.RenamedFrom, ".") {
+		errs.Add("renamed_from", "`renamed_from` is a name, use `moved_from` for a path")
+	}
+
+	return errs
+}
+
+// The path of the property in the state before the move, given its parent's
+// path in the same version of the state, as `renamed_from` is relative to it
+func (l StateLineage) PreviousPath(parentPath []string) []string {
+	if l.RenamedFrom != "" {
+		return append(append([]string{}, parentPath...), google.Underscore(l.RenamedFrom))
+	}
+
+	var path []string
+	for _, name := range strings.Split(l.MovedFrom, ".") {
+		path = append(path, google.Underscore(name))
+	}
+	return path
+}
+
+// A move of the value of a property in the Terraform state, between paths
+// of field names from the top level of the resource
+type StateMove struct {
+	From []string
+	To   []string
+}
+
+// The state upgrade generated for the properties renamed or moved by a
+// schema version
+type StateUpgrade struct {
+	// The schema version upgraded from, which is the version of the upgrader
+	Version int
+
+	// In the order they're applied to the state, with the moves of parents
+	// before the moves of the properties nested in them
+	Moves []StateMove
+}
+
+// The version the state is upgraded to
+func (u StateUpgrade) NextVersion() int {
+	return u.Version + 1
+}
+
+// Returns the moves that derive the state type of the version from the type
+// of the next version, which are the moves in reverse
+func (u StateUpgrade) ReverseMoves() []StateMove {
+	var moves []StateMove
+	for i := len(u.Moves) - 1; i >= 0; i-- {
+		moves = append(moves, StateMove{From: u.Moves[i].To, To: u.Moves[i].From})
+	}
+	return moves
+}
+
+func (m StateMove) String() string {
+	return fmt.Sprintf("%s to %s", strings.Join(m.From, "."), strings.Join(m.To, "."))
+}
+
+// Returns the path the value is moved from as a Go literal
+func (m StateMove) FromLiteral() string {
+	return fmt.Sprintf("%#v", m.From)
+}
+
+// Returns the path the value is moved to as a Go literal
+func (m StateMove) ToLiteral() string {
+	return fmt.Sprintf("%#v", m.To)
+}
+
+// Returns a Go literal of a state with a value at the path the value is
+// moved from, for the tests of the generated upgraders
+func (m StateMove) FromStateLiteral() string {
+	return stateLiteral(m.From)
+}
+
+// Returns a Go literal of a state with a value at the path the value is
+// moved to, for the tests of the generated upgraders
+func (m StateMove) ToStateLiteral() string {
+	return stateLiteral(m.To)
+}
+
+// Fields on the way to the value are nested objects
+func stateLiteral(path []string) string {
+	if len(path) == 0 {
+		return `"value"`
+	}
+	nested := stateLiteral(path[1:])
+	if len(path) > 1 {
+		nested = fmt.Sprintf("[]interface{}{%s}", nested)
+	}
+	return fmt.Sprintf("map[string]interface{}{%q: %s}", path[0], nested)
+}
@@ -4,10 +4,12 @@ import (
 	"os"
 	"path/filepath"
 	"reflect"
+	"slices"
 	"strings"
 	"testing"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
 )
 
 func TestResourceMinVersionObj(t *testing.T) {
@@ -319,6 +321,149 @@ func TestLeafProperties(t *testing.T) {
 	}
 }
 
+func TestResourceHttpLogRedactedFields(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Resource
+		expected    []string
+	}{
+		{
+			description: "no sensitive fields",
+			obj: Resource{
+				BaseUrl: "test",
+				Properties: []*Type{
+					{
+						Name: "basic",
+						Type: "String",
+					},
+				},
+			},
+			expected: nil,
+		},
+		{
+			description: "nested sensitive and write-only fields",
+			obj: Resource{
+				BaseUrl: "test",
+				Properties: []*Type{
+					{
+						Name:      "password",
+						Type:      "String",
+						Sensitive: true,
+					},
+					{
+						Name: "settings",
+						Type: "NestedObject",
+						Properties: []*Type{
+							{
+								Name:      "apiKeyWo",
+								ApiName:   "apiKey",
+								Type:      "String",
+								WriteOnly: true,
+							},
+						},
+					},
+				},
+			},
+			expected: []string{"apiKey", "password"},
+		},
+		{
+			description: "sensitive and write-only fields with the same api name",
+			obj: Resource{
+				BaseUrl: "test",
+				Properties: []*Type{
+					{
+						Name:      "secretData",
+						ApiName:   "data",
+						Type:      "String",
+						Sensitive: true,
+					},
+					{
+						Name:      "secretDataWo",
+						ApiName:   "data",
+						Type:      "String",
+						WriteOnly: true,
+					},
+				},
+			},
+			expected: []string{"data"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			tc.obj.SetDefault(nil)
+			if got := tc.obj.HttpLogRedactedFields(); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v to be %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestResourceBatchOperationActions(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Resource
+		expected    []string
+	}{
+		{
+			description: "no batching",
+			obj: Resource{
+				Async: &Async{Type: "OpAsync", Actions: []string{"create", "delete"}},
+			},
+			expected: nil,
+		},
+		{
+			description: "batched operations",
+			obj: Resource{
+				Async:    &Async{Type: "OpAsync", Actions: []string{"create", "delete", "update"}},
+				Batching: &resource.Batching{Actions: []string{"create", "delete"}},
+			},
+			expected: []string{"create", "delete"},
+		},
+		{
+			description: "only some batched actions are async",
+			obj: Resource{
+				Async:    &Async{Type: "OpAsync", Actions: []string{"delete"}},
+				Batching: &resource.Batching{Actions: []string{"create", "delete"}},
+			},
+			expected: []string{"delete"},
+		},
+		{
+			description: "polled resource",
+			obj: Resource{
+				Async:    &Async{Type: "PollAsync", Actions: []string{"create", "delete"}},
+				Batching: &resource.Batching{Actions: []string{"create", "delete"}},
+			},
+			expected: nil,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.BatchOperationActions(); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v to be %v", got, tc.expected)
+			}
+			for _, action := range []string{"create", "delete"} {
+				if got, want := tc.obj.BatchWaitsForOperation(action), slices.Contains(tc.expected, action); got != want {
+					t.Errorf("expected the batch to wait for the %s operation to be %t, got %t", action, want, got)
+				}
+			}
+		})
+	}
+}
+
 // TestMagicianLocation verifies that the current package is being executed from within
 // the RELATIVE_MAGICIAN_LOCATION ("mmv1/") directory structure. This ensures that references
 // to files relative to this location will remain valid even if the repository structure
@@ -357,3 +502,987 @@ func TestMagicianLocation(t *testing.T) {
 		t.Errorf("Current package is not under %s. Path from magician dir to current dir: %s", RELATIVE_MAGICIAN_LOCATION, relPath)
 	}
 }
+
+func TestListDatasource(t *testing.T) {
+	t.Parallel()
+
+	p := Product{Name: "ArtifactRegistry"}
+
+	cases := []struct {
+		description    string
+		obj            Resource
+		expectedName   string
+		expectedFunc   string
+		expectedParams []string
+	}{
+		{
+			description: "default name",
+			obj: Resource{
+				Name:           "Repository",
+				BaseUrl:        "projects/{{project}}/locations/{{location}}/repositories",
+				ListDatasource: &resource.ListDatasource{},
+			},
+			expectedName:   "google_artifact_registry_repositories",
+			expectedFunc:   "DataSourceArtifactRegistryRepositories",
+			expectedParams: []string{"project", "location"},
+		},
+		{
+			description: "name override",
+			obj: Resource{
+				Name:    "Repository",
+				BaseUrl: "projects/{{project}}/repositories/{{%parent}}",
+				ListDatasource: &resource.ListDatasource{
+					Name: "google_artifact_registry_repos",
+				},
+			},
+			expectedName:   "google_artifact_registry_repos",
+			expectedFunc:   "DataSourceArtifactRegistryRepos",
+			expectedParams: []string{"project", "parent"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			tc.obj.ProductMetadata = &p
+
+			if got := tc.obj.ListDatasourceName(); got != tc.expectedName {
+				t.Errorf("expected name %q to be %q", got, tc.expectedName)
+			}
+			if got := tc.obj.ListDatasourceFuncName(); got != tc.expectedFunc {
+				t.Errorf("expected func name %q to be %q", got, tc.expectedFunc)
+			}
+			if got := tc.obj.ListParentParams(); !reflect.DeepEqual(got, tc.expectedParams) {
+				t.Errorf("expected params %v to be %v", got, tc.expectedParams)
+			}
+		})
+	}
+}
+
+func TestDatasourceIdentityFields(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description      string
+		obj              Resource
+		expectedRequired []string
+		expectedOptional []string
+	}{
+		{
+			description: "project and location",
+			obj: Resource{
+				Name:     "Repository",
+				IdFormat: "projects/{{project}}/locations/{{location}}/repositories/{{repository_id}}",
+			},
+			expectedRequired: []string{"location", "repository_id"},
+			expectedOptional: []string{"project"},
+		},
+		{
+			description: "region",
+			obj: Resource{
+				Name:     "Router",
+				IdFormat: "projects/{{project}}/regions/{{region}}/routers/{{name}}",
+			},
+			expectedRequired: []string{"name"},
+			expectedOptional: []string{"project", "region"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			required, optional := tc.obj.DatasourceIdentityFields()
+			if !reflect.DeepEqual(required, tc.expectedRequired) {
+				t.Errorf("expected required fields %v to be %v", required, tc.expectedRequired)
+			}
+			if !reflect.DeepEqual(optional, tc.expectedOptional) {
+				t.Errorf("expected optional fields %v to be %v", optional, tc.expectedOptional)
+			}
+		})
+	}
+}
+
+func TestResourceIdentityFields(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description      string
+		obj              Resource
+		expectedFormat   string
+		expectedRequired []string
+		expectedOptional []string
+	}{
+		{
+			description: "base url and name",
+			obj: Resource{
+				Name:    "Topic",
+				BaseUrl: "projects/{{project}}/topics",
+			},
+			expectedFormat:   "projects/{{project}}/topics/{{name}}",
+			expectedRequired: []string{"name"},
+			expectedOptional: []string{"project"},
+		},
+		{
+			description: "identity",
+			obj: Resource{
+				Name:     "Repository",
+				BaseUrl:  "projects/{{project}}/locations/{{location}}/repositories",
+				Identity: []string{"repositoryId"},
+			},
+			expectedFormat:   "projects/{{project}}/locations/{{location}}/repositories/{{repository_id}}",
+			expectedRequired: []string{"location", "repository_id"},
+			expectedOptional: []string{"project"},
+		},
+		{
+			description: "import format",
+			obj: Resource{
+				Name:         "Rule",
+				BaseUrl:      "{{parent}}/rules",
+				ImportFormat: []string{"{{%parent}}/rules/{{name}}"},
+			},
+			expectedFormat:   "{{%parent}}/rules/{{name}}",
+			expectedRequired: []string{"parent", "name"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.ResourceIdentityImportFormat(); got != tc.expectedFormat {
+				t.Errorf("expected import format %q to be %q", got, tc.expectedFormat)
+			}
+			required, optional := tc.obj.ResourceIdentityFields()
+			if !reflect.DeepEqual(required, tc.expectedRequired) {
+				t.Errorf("expected required fields %v to be %v", required, tc.expectedRequired)
+			}
+			if !reflect.DeepEqual(optional, tc.expectedOptional) {
+				t.Errorf("expected optional fields %v to be %v", optional, tc.expectedOptional)
+			}
+		})
+	}
+}
+
+func TestFakeGcpIdentity(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Resource
+		expected    []string
+	}{
+		{
+			description: "name in base url",
+			obj: Resource{
+				BaseUrl: "projects/{{project}}/topics",
+				Properties: []*Type{
+					{Name: "name", ApiName: "name"},
+				},
+			},
+			expected: []string{"name"},
+		},
+		{
+			description: "self link",
+			obj: Resource{
+				BaseUrl:  "projects/{{project}}/locations/{{location}}/instances",
+				SelfLink: "projects/{{project}}/locations/{{location}}/instances/{{instance_id}}",
+				Parameters: []*Type{
+					{Name: "location", ApiName: "location", UrlParamOnly: true},
+					{Name: "instanceId", ApiName: "instanceId"},
+				},
+				Properties: []*Type{
+					{Name: "name", ApiName: "name", Output: true},
+				},
+			},
+			expected: []string{"instanceId"},
+		},
+		{
+			description: "id format",
+			obj: Resource{
+				BaseUrl:  "projects/{{project}}/rules",
+				SelfLink: "projects/{{project}}/rules/{{name}}",
+				IdFormat: "{{rule_set}}/rules/{{name}}",
+				Properties: []*Type{
+					{Name: "name", ApiName: "name"},
+					{Name: "ruleSet", ApiName: "parentRuleSet"},
+				},
+			},
+			expected: []string{"name", "parentRuleSet"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, p := range tc.obj.FakeGcpIdentity() {
+				got = append(got, p.ApiName)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected identity %v to be %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestHasListResource(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Resource
+		expected    bool
+	}{
+		{
+			description: "listed by collection",
+			obj: Resource{
+				Name:             "Topic",
+				BaseUrl:          "projects/{{project}}/topics",
+				CollectionUrlKey: "topics",
+			},
+			expected: true,
+		},
+		{
+			description: "excluded",
+			obj: Resource{
+				Name:                "Topic",
+				BaseUrl:             "projects/{{project}}/topics",
+				CollectionUrlKey:    "topics",
+				ExcludeListResource: true,
+			},
+		},
+		{
+			description: "nested query",
+			obj: Resource{
+				Name:             "Member",
+				BaseUrl:          "projects/{{project}}/groups/{{group}}",
+				CollectionUrlKey: "members",
+				NestedQuery:      &resource.NestedQuery{Keys: []string{"members"}},
+			},
+		},
+		{
+			description: "singleton",
+			obj: Resource{
+				Name:     "FolderSettings",
+				BaseUrl:  "folders/{{folder_id}}/accessApprovalSettings",
+				SelfLink: "folders/{{folder_id}}/accessApprovalSettings",
+			},
+		},
+		{
+			description: "singleton within collection",
+			obj: Resource{
+				Name:     "EnvironmentAddonsConfig",
+				BaseUrl:  "{{env_id}}",
+				SelfLink: "{{env_id}}/addonsConfig",
+			},
+		},
+		{
+			description: "identity field not in self link",
+			obj: Resource{
+				Name:             "Rule",
+				BaseUrl:          "projects/{{project}}/rules",
+				CollectionUrlKey: "rules",
+				ImportFormat:     []string{"projects/{{project}}/policies/{{policy}}/rules/{{name}}"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.HasListResource(); got != tc.expected {
+				t.Errorf("expected HasListResource to be %v, got %v", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestResourceValidate(t *testing.T) {
+	t.Parallel()
+
+	p := Product{
+		Name: "Test",
+		Versions: []*product.Version{
+			{
+				Name:    "ga",
+				BaseUrl: "ga_url",
+			},
+		},
+	}
+
+	cases := []struct {
+		description string
+		obj         Resource
+		expected    []string
+	}{
+		{
+			description: "valid resource",
+			obj: Resource{
+				Name:        "Widget",
+				Description: "A widget.",
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+					},
+				},
+			},
+		},
+		{
+			description: "all problems are reported",
+			obj: Resource{
+				Name:       "Widget",
+				CreateVerb: "GET",
+				Identity:   []string{"missing"},
+				Properties: []*Type{
+					{
+						Name:     "name",
+						Type:     "String",
+						Output:   true,
+						Required: true,
+					},
+					{
+						Name: "parent",
+						Type: "NestedObject",
+						Properties: []*Type{
+							{
+								Name:           "child",
+								Type:           "String",
+								DefaultFromApi: true,
+								DefaultValue:   "foo",
+							},
+						},
+					},
+					{
+						Name: "items",
+						Type: "Array",
+					},
+				},
+			},
+			expected: []string{
+				"identity",
+				"description",
+				"create_verb",
+				"properties.name",
+				"properties.parent.properties.child.default_value",
+				"properties.items.item_type",
+			},
+		},
+		{
+			description: "unsupported features of framework resources",
+			obj: Resource{
+				Name:              "Widget",
+				Description:       "A widget.",
+				FrameworkResource: true,
+				Mutex:             "widgets/{{name}}",
+				Properties: []*Type{
+					{
+						Name:         "name",
+						Type:         "String",
+						CustomExpand: "templates/terraform/custom_expand/name.go.tmpl",
+					},
+					{
+						Name: "labels",
+						Type: "KeyValueLabels",
+					},
+					{
+						Name:         "password",
+						Type:         "String",
+						WriteOnly:    true,
+						DefaultValue: "secret",
+					},
+					{
+						Name: "matrix",
+						Type: "Array",
+						ItemType: &Type{
+							Type:     "Array",
+							ItemType: &Type{Type: "String"},
+						},
+					},
+				},
+			},
+			expected: []string{
+				"mutex",
+				"properties.name.custom_expand",
+				"properties.password.default_value",
+				"properties.matrix.item_type",
+			},
+		},
+		{
+			description: "invalid list datasource",
+			obj: Resource{
+				Name:        "Widget",
+				Description: "A widget.",
+				ExcludeRead: true,
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+					},
+				},
+				ListDatasource: &resource.ListDatasource{
+					Name:       "widgets",
+					FilterDocs: "https://cloud.google.com/filtering",
+				},
+			},
+			expected: []string{
+				"list_datasource.name",
+				"list_datasource.filter_docs",
+				"list_datasource",
+			},
+		},
+		{
+			description: "datasource excluding read",
+			obj: Resource{
+				Name:        "Widget",
+				Description: "A widget.",
+				ExcludeRead: true,
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+					},
+				},
+				Datasource: &resource.Datasource{},
+			},
+			expected: []string{
+				"datasource",
+			},
+		},
+		{
+			description: "invalid custom methods",
+			obj: Resource{
+				Name:        "Widget",
+				Description: "A widget.",
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+					},
+				},
+				CustomMethods: []resource.CustomMethod{
+					{
+						Name:  "start",
+						Verb:  "GET",
+						Async: true,
+						Trigger: &resource.CustomMethodTrigger{
+							Field: "desiredState",
+						},
+					},
+					{
+						Name: "stop",
+					},
+					{
+						Name:  "resize",
+						Phase: "create",
+						Body: map[string]interface{}{
+							"sizes": []interface{}{1, 2},
+						},
+					},
+				},
+			},
+			expected: []string{
+				"custom_methods.start.verb",
+				"custom_methods.start.trigger.field",
+				"custom_methods.start.async",
+				"custom_methods.stop.trigger",
+				"custom_methods.resize.body.sizes",
+			},
+		},
+		{
+			description: "nested query etag without modify by patch",
+			obj: Resource{
+				Name:        "Widget",
+				Description: "A widget.",
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+					},
+				},
+				NestedQuery: &resource.NestedQuery{
+					Keys: []string{"widgets"},
+					Etag: &resource.NestedQueryEtag{},
+				},
+			},
+			expected: []string{"nested_query.etag"},
+		},
+		{
+			description: "nested query etag with custom code",
+			obj: Resource{
+				Name:        "Widget",
+				Description: "A widget.",
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+					},
+				},
+				NestedQuery: &resource.NestedQuery{
+					Keys:          []string{"widgets"},
+					ModifyByPatch: true,
+					Etag:          &resource.NestedQueryEtag{},
+				},
+				CustomCode: resource.CustomCode{
+					PreCreate:    "templates/terraform/pre_create/widget.go.tmpl",
+					CustomDelete: "templates/terraform/custom_delete/widget.go.tmpl",
+				},
+			},
+			expected: []string{
+				"custom_code.pre_create",
+				"custom_code.custom_delete",
+			},
+		},
+		{
+			description: "invalid batching",
+			obj: Resource{
+				Name:        "Widget",
+				Description: "A widget.",
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+					},
+				},
+				Batching: &resource.Batching{
+					Combine: "concat",
+					Actions: []string{"create", "update"},
+				},
+			},
+			expected: []string{
+				"batching.batch_key",
+				"batching.combine",
+				"batching.actions",
+			},
+		},
+		{
+			description: "batching with custom code",
+			obj: Resource{
+				Name:        "Widget",
+				Description: "A widget.",
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+					},
+				},
+				Batching: &resource.Batching{
+					BatchKey: "widgets/{{project}}",
+					Combine:  "append_list",
+				},
+				CustomCode: resource.CustomCode{
+					CustomCreate: "templates/terraform/custom_create/widget.go.tmpl",
+				},
+			},
+			expected: []string{"custom_code.custom_create"},
+		},
+		{
+			description: "batching only creates with custom delete",
+			obj: Resource{
+				Name:        "Widget",
+				Description: "A widget.",
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+					},
+				},
+				Batching: &resource.Batching{
+					BatchKey: "widgets/{{project}}",
+					Combine:  "merge_map",
+					Actions:  []string{"create"},
+				},
+				CustomCode: resource.CustomCode{
+					CustomDelete: "templates/terraform/custom_delete/widget.go.tmpl",
+				},
+			},
+		},
+		{
+			description: "batching creates with the resource inside the operation",
+			obj: Resource{
+				Name:        "Widget",
+				Description: "A widget.",
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+					},
+				},
+				Batching: &resource.Batching{
+					BatchKey: "widgets/{{project}}",
+					Combine:  "append_list",
+					Actions:  []string{"create"},
+				},
+				Async: &Async{
+					Type:      "OpAsync",
+					Actions:   []string{"create"},
+					Operation: &Operation{OpAsyncOperation: OpAsyncOperation{BaseUrl: "{{op_id}}"}},
+					OpAsync:   OpAsync{Result: OpAsyncResult{ResourceInsideResponse: true}},
+				},
+			},
+			expected: []string{"async.result.resource_inside_response"},
+		},
+		{
+			description: "unsupported api types",
+			obj: Resource{
+				Name:             "Widget",
+				Description:      "A widget.",
+				GenerateApiTypes: true,
+				CustomCode: resource.CustomCode{
+					Decoder: "templates/terraform/decoders/widget.go.tmpl",
+				},
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+					},
+					{
+						Name:      "password",
+						Type:      "String",
+						WriteOnly: true,
+					},
+					{
+						Name: "settings",
+						Type: "NestedObject",
+						Properties: []*Type{
+							{
+								Name:          "fooBar",
+								Type:          "String",
+								ApiName:       "fooBar",
+								CustomFlatten: "templates/terraform/custom_flatten/foo_bar.go.tmpl",
+							},
+							{
+								Name:    "legacyFooBar",
+								Type:    "String",
+								ApiName: "fooBar",
+							},
+						},
+					},
+				},
+			},
+			expected: []string{
+				"custom_code.decoder",
+				"properties.password.write_only",
+				"properties.settings.properties.legacyFooBar",
+				"properties.settings.properties.fooBar.custom_flatten",
+			},
+		},
+		{
+			description: "invalid state lineage",
+			obj: Resource{
+				Name:        "Widget",
+				Description: "A widget.",
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+						StateLineage: []resource.StateLineage{
+							{SchemaVersion: 0, RenamedFrom: "title", MovedFrom: "settings.title"},
+						},
+					},
+					{
+						Name: "tier",
+						Type: "String",
+						StateLineage: []resource.StateLineage{
+							{SchemaVersion: 2, RenamedFrom: "settings.tier"},
+							{SchemaVersion: 1, RenamedFrom: "level"},
+						},
+					},
+				},
+			},
+			expected: []string{
+				"properties.name.state_lineage.schema_version",
+				"properties.name.state_lineage",
+				"properties.tier.state_lineage.renamed_from",
+				"properties.tier.state_lineage",
+				"",
+			},
+		},
+		{
+			description: "offline test of an example that needs GCP",
+			obj: Resource{
+				Name:        "Widget",
+				Description: "A widget.",
+				NestedQuery: &resource.NestedQuery{Keys: []string{"widgets"}},
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+					},
+				},
+				Examples: []resource.Examples{
+					{
+						Name:        "widget_basic",
+						OfflineTest: true,
+					},
+					{
+						Name:        "widget_org",
+						OfflineTest: true,
+						TestEnvVars: map[string]string{"org_id": "ORG_ID"},
+					},
+				},
+			},
+			expected: []string{
+				"examples.widget_basic.offline_test",
+				"examples.widget_org.offline_test",
+				"examples.widget_org.offline_test",
+			},
+		},
+		{
+			description: "state lineage with a gap in schema versions",
+			obj: Resource{
+				Name:          "Widget",
+				Description:   "A widget.",
+				SchemaVersion: 1,
+				Properties: []*Type{
+					{
+						Name: "tier",
+						Type: "String",
+						StateLineage: []resource.StateLineage{
+							{SchemaVersion: 3, RenamedFrom: "level"},
+						},
+					},
+				},
+			},
+			expected: []string{""},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			tc.obj.SetDefault(&p)
+
+			var got []string
+			for _, e := range tc.obj.Validate() {
+				got = append(got, e.Path)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v to be %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCustomMethodCondition(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         resource.CustomMethod
+		expected    string
+	}{
+		{
+			description: "update on change",
+			obj: resource.CustomMethod{
+				Phase:   "update",
+				Trigger: &resource.CustomMethodTrigger{Field: "desiredState"},
+			},
+			expected: `d.HasChange("desired_state")`,
+		},
+		{
+			description: "update on change to value",
+			obj: resource.CustomMethod{
+				Phase:   "update",
+				Trigger: &resource.CustomMethodTrigger{Field: "desiredState", Value: "STOPPED"},
+			},
+			expected: `d.HasChange("desired_state") && fmt.Sprintf("%v", d.Get("desired_state")) == "STOPPED"`,
+		},
+		{
+			description: "create when set",
+			obj: resource.CustomMethod{
+				Phase:   "create",
+				Trigger: &resource.CustomMethodTrigger{Field: "promote"},
+			},
+			expected: `!tpgresource.IsEmptyValue(reflect.ValueOf(d.Get("promote")))`,
+		},
+		{
+			description: "delete without trigger",
+			obj: resource.CustomMethod{
+				Phase: "delete",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if got := tc.obj.Condition(); got != tc.expected {
+				t.Errorf("expected condition %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestResourceStateLineageUpgrades(t *testing.T) {
+	t.Parallel()
+
+	p := Product{
+		Name: "Test",
+		Versions: []*product.Version{
+			{
+				Name:    "ga",
+				BaseUrl: "ga_url",
+			},
+		},
+	}
+
+	cases := []struct {
+		description   string
+		obj           Resource
+		expected      []resource.StateUpgrade
+		schemaVersion int
+	}{
+		{
+			description: "no state lineage",
+			obj: Resource{
+				Name:          "Widget",
+				SchemaVersion: 2,
+				Properties: []*Type{
+					{
+						Name: "name",
+						Type: "String",
+					},
+				},
+			},
+			schemaVersion: 2,
+		},
+		{
+			description: "renamed and flattened fields",
+			obj: Resource{
+				Name:          "Widget",
+				SchemaVersion: 1,
+				Properties: []*Type{
+					{
+						Name: "machineTier",
+						Type: "String",
+						StateLineage: []resource.StateLineage{
+							{SchemaVersion: 2, MovedFrom: "settings.tier"},
+							{SchemaVersion: 3, RenamedFrom: "tier"},
+						},
+					},
+				},
+			},
+			expected: []resource.StateUpgrade{
+				{
+					Version: 1,
+					Moves: []resource.StateMove{
+						{From: []string{"settings", "tier"}, To: []string{"tier"}},
+					},
+				},
+				{
+					Version: 2,
+					Moves: []resource.StateMove{
+						{From: []string{"tier"}, To: []string{"machine_tier"}},
+					},
+				},
+			},
+			schemaVersion: 3,
+		},
+		{
+			description: "renamed field in a renamed object",
+			obj: Resource{
+				Name: "Widget",
+				Properties: []*Type{
+					{
+						Name: "rules",
+						Type: "Array",
+						StateLineage: []resource.StateLineage{
+							{SchemaVersion: 1, RenamedFrom: "policies"},
+						},
+						ItemType: &Type{
+							Type: "NestedObject",
+							Properties: []*Type{
+								{
+									Name: "verdict",
+									Type: "String",
+									StateLineage: []resource.StateLineage{
+										{SchemaVersion: 1, RenamedFrom: "action"},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []resource.StateUpgrade{
+				{
+					Version: 0,
+					Moves: []resource.StateMove{
+						{From: []string{"policies"}, To: []string{"rules"}},
+						{From: []string{"rules", "action"}, To: []string{"rules", "verdict"}},
+					},
+				},
+			},
+			schemaVersion: 1,
+		},
+		{
+			description: "field of a flattened object",
+			obj: Resource{
+				Name: "Widget",
+				Properties: []*Type{
+					{
+						Name:          "settings",
+						Type:          "NestedObject",
+						FlattenObject: true,
+						Properties: []*Type{
+							{
+								Name: "tier",
+								Type: "String",
+								StateLineage: []resource.StateLineage{
+									{SchemaVersion: 1, MovedFrom: "settings.tier"},
+								},
+							},
+						},
+					},
+				},
+			},
+			expected: []resource.StateUpgrade{
+				{
+					Version: 0,
+					Moves: []resource.StateMove{
+						{From: []string{"settings", "tier"}, To: []string{"tier"}},
+					},
+				},
+			},
+			schemaVersion: 1,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			tc.obj.SetDefault(&p)
+
+			if got := tc.obj.StateLineageUpgrades(); !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected upgrades %#v, got %#v", tc.expected, got)
+			}
+			if got := tc.obj.CurrentSchemaVersion(); got != tc.schemaVersion {
+				t.Errorf("expected schema version %d, got %d", tc.schemaVersion, got)
+			}
+		})
+	}
+}
@@ -24,6 +24,26 @@ import (
 	"golang.org/x/exp/slices"
 )
 
+// The values allowed for the type of a property
+var PropertyTypes = []string{
+	"Boolean",
+	"Double",
+	"Integer",
+	"String",
+	"Time",
+	"Enum",
+	"ResourceRef",
+	"NestedObject",
+	"Array",
+	"KeyValuePairs",
+	"KeyValueLabels",
+	"KeyValueTerraformLabels",
+	"KeyValueEffectiveLabels",
+	"KeyValueAnnotations",
+	"Map",
+	"Fingerprint",
+}
+
 // Represents a property type
 type Type struct {
 	Name string `yaml:"name,omitempty"`
@@ -263,6 +283,12 @@ type Type struct {
 	// all of it's parents such as `one`
 	FlattenObject bool `yaml:"flatten_object,omitempty"`
 
+	// The previous names or locations of the field in the Terraform state,
+	// each at the schema version that renamed or moved it. State upgraders
+	// that move the values of existing resources are generated, and the
+	// schema version of the resource is bumped to the latest of them.
+	StateLineage []resource.StateLineage `yaml:"state_lineage,omitempty"`
+
 	// ===========
 	// Custom code
 	// ===========
@@ -304,12 +330,12 @@ func (t *Type) SetDefault(r *Resource) {
 	}
 
 	switch {
-	case t.IsA("Array"):
+	case t.IsA("Array") && t.ItemType != nil:
 		t.ItemType.Name = t.Name
 		t.ItemType.ParentName = t.Name
 		t.ItemType.ParentMetadata = t
 		t.ItemType.SetDefault(r)
-	case t.IsA("Map"):
+	case t.IsA("Map") && t.ValueType != nil:
 		if t.KeyExpander == "" {
 			t.KeyExpander = "tpgresource.ExpandString"
 		}
@@ -350,40 +376,182 @@ func (t *Type) SetDefault(r *Resource) {
 	}
 }
 
-func (t *Type) Validate(rName string) {
+// Validates the property and its nested properties, returning every problem
+// found. The paths of the returned problems are relative to the property.
+func (t *Type) Validate(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
+
 	if t.Name == "" {
-		log.Fatalf("Missing `name` for proprty with type %s in resource %s", t.Type, rName)
+		errs.Add("name", "Missing `name` for proprty with type %s in resource %s", t.Type, rName)
 	}
 
 	if t.Output && t.Required {
-		log.Fatalf("Property %s cannot be output and required at the same time in resource %s.", t.Name, rName)
+		errs.Add("", "Property %s cannot be output and required at the same time in resource %s.", t.Name, rName)
 	}
 
 	if t.DefaultFromApi && t.DefaultValue != nil {
-		log.Fatalf("'default_value' and 'default_from_api' cannot be both set in resource %s", rName)
+		errs.Add("default_value", "'default_value' and 'default_from_api' cannot be both set in resource %s", rName)
 	}
 
 	if t.WriteOnly && (t.DefaultFromApi || t.Output) {
-		log.Fatalf("Property %s cannot be write_only and default_from_api or output at the same time in resource %s", t.Name, rName)
+		errs.Add("write_only", "Property %s cannot be write_only and default_from_api or output at the same time in resource %s", t.Name, rName)
 	}
 
 	if t.WriteOnly && t.Sensitive {
-		log.Fatalf("Property %s cannot be write_only and sensitive at the same time in resource %s", t.Name, rName)
+		errs.Add("write_only", "Property %s cannot be write_only and sensitive at the same time in resource %s", t.Name, rName)
 	}
 
-	t.validateLabelsField()
+	errs.Append("", t.validateLabelsField())
+
+	if len(t.StateLineage) > 0 {
+		errs.Append("", t.validateStateLineage(rName))
+	}
 
 	switch {
 	case t.IsA("Array"):
-		t.ItemType.Validate(rName)
+		if t.ItemType == nil {
+			errs.Add("item_type", "Missing `item_type` for Array property %s in resource %s", t.Name, rName)
+			break
+		}
+		errs.Append("item_type", t.ItemType.Validate(rName))
 	case t.IsA("Map"):
-		t.ValueType.Validate(rName)
+		if t.ValueType == nil {
+			errs.Add("value_type", "Missing `value_type` for Map property %s in resource %s", t.Name, rName)
+			break
+		}
+		errs.Append("value_type", t.ValueType.Validate(rName))
 	case t.IsA("NestedObject"):
 		for _, p := range t.Properties {
-			p.Validate(rName)
+			errs.Append(google.JoinValidationPath("properties", p.Name), p.Validate(rName))
 		}
 	default:
 	}
+
+	return errs
+}
+
+func (t *Type) validateStateLineage(rName string) google.ValidationErrors {
+	var errs google.ValidationErrors
+
+	for i, l := range t.StateLineage {
+		errs.Append("state_lineage", l.Validate())
+		if i > 0 && l.SchemaVersion <= t.StateLineage[i-1].SchemaVersion {
+			errs.Add("state_lineage", "`state_lineage` of property %s must be in increasing order of `schema_version` in resource %s", t.Name, rName)
+		}
+	}
+
+	if t.FlattenObject {
+		errs.Add("state_lineage", "`state_lineage` is not supported on flattened object %s in resource %s, set `moved_from` on its properties instead", t.Name, rName)
+	}
+	for p := t.ParentMetadata; p != nil; p = p.ParentMetadata {
+		if p.IsA("Map") {
+			errs.Add("state_lineage", "`state_lineage` is not supported on property %s of a map in resource %s", t.Name, rName)
+			break
+		}
+	}
+
+	return errs
+}
+
+// Returns the path of the field in the Terraform state at a schema version
+// of the resource, following the state lineage of the field and its parents
+func (t Type) StatePathAt(version int) []string {
+	if t.ParentMetadata != nil && t.ParentMetadata.IsA("Array") {
+		// The item type of an array is at the path of the array
+		return t.ParentMetadata.StatePathAt(version)
+	}
+
+	parentPath := t.parentStatePathAt(version)
+	for _, l := range t.StateLineage {
+		if l.SchemaVersion > version {
+			return l.PreviousPath(parentPath)
+		}
+	}
+	return append(parentPath, google.Underscore(t.Name))
+}
+
+// Returns the path of the object the field is in at a schema version, where
+// the fields of flattened objects are in the object of the flattened one
+func (t Type) parentStatePathAt(version int) []string {
+	p := t.ParentMetadata
+	for p != nil && p.FlattenObject {
+		p = p.ParentMetadata
+	}
+	if p == nil {
+		return nil
+	}
+	return p.StatePathAt(version)
+}
+
+// Reports the features used by the property or its nested properties that
+// plugin-framework resources don't support yet.
+func (t *Type) validateFrameworkProperty(nested bool) google.ValidationErrors {
+	var errs google.ValidationErrors
+
+	switch {
+	case t.IsA("Array"):
+		if t.ItemType != nil && t.ItemType.IsA("Array") {
+			errs.Add("item_type", "Nested arrays are not supported for framework resources")
+		}
+	case t.IsA("NestedObject"), t.isFrameworkMap():
+	case t.frameworkPrimitive() == "":
+		errs.Add("type", "Type %s is not supported for framework resources", t.Type)
+	}
+
+	unsupported := []struct {
+		field string
+		used  bool
+	}{
+		{"flatten_object", t.FlattenObject},
+		{"custom_expand", t.CustomExpand != ""},
+		{"custom_flatten", t.CustomFlatten != ""},
+		{"diff_suppress_func", t.DiffSuppressFunc != ""},
+		{"state_func", t.StateFunc != ""},
+		{"set_hash_func", t.SetHashFunc != ""},
+		{"is_set", t.IsSet},
+		{"unordered_list", t.UnorderedList},
+		{"client_side", t.ClientSide},
+		{"ignore_read", t.IgnoreRead && nested},
+		{"url_param_only", t.UrlParamOnly && nested},
+		{"write_only", t.WriteOnly && nested},
+		{"state_lineage", len(t.StateLineage) > 0},
+		{"update_url", t.UpdateUrl != ""},
+		{"send_empty_value", t.SendEmptyValue},
+		{"allow_empty_object", t.AllowEmptyObject},
+		{"conflicts", len(t.Conflicts) > 0},
+		{"at_least_one_of", len(t.AtLeastOneOf) > 0},
+		{"exactly_one_of", len(t.ExactlyOneOf) > 0},
+		{"required_with", len(t.RequiredWith) > 0},
+		{"validation.function", t.Validation.Function != ""},
+		{"item_validation.function", t.ItemValidation.Function != ""},
+		{"validation.regex", t.Validation.Regex != "" && t.frameworkPrimitive() != "String"},
+		{"item_validation.regex", t.ItemValidation.Regex != "" && (t.ItemType == nil || t.ItemType.frameworkPrimitive() != "String")},
+	}
+	for _, u := range unsupported {
+		if u.used {
+			errs.Add(u.field, "`%s` is not supported for framework resources", u.field)
+		}
+	}
+
+	if t.DefaultValue != nil && t.frameworkPrimitive() == "" {
+		errs.Add("default_value", "`default_value` is only supported on primitive properties of framework resources")
+	}
+
+	// Write-only attributes are never planned, so they can't have a default
+	if t.WriteOnly && t.DefaultValue != nil {
+		errs.Add("default_value", "`default_value` is not supported on write-only properties of framework resources")
+	}
+
+	switch {
+	case t.IsA("Array") && t.ItemType != nil:
+		errs.Append("item_type", t.ItemType.validateFrameworkProperty(true))
+	case t.IsA("NestedObject"):
+		for _, p := range t.Properties {
+			errs.Append(google.JoinValidationPath("properties", p.Name), p.validateFrameworkProperty(true))
+		}
+	}
+
+	return errs
 }
 
 // TODO rewrite: add validations
@@ -968,7 +1136,9 @@ func propertyWithIgnoreWrite(ignoreWrite bool) func(*Type) {
 	}
 }
 
-func (t *Type) validateLabelsField() {
+func (t *Type) validateLabelsField() google.ValidationErrors {
+	var errs google.ValidationErrors
+
 	productName := t.ResourceMetadata.ProductMetadata.Name
 	resourceName := t.ResourceMetadata.Name
 	lineage := t.Lineage()
@@ -991,21 +1161,22 @@ func (t *Type) validateLabelsField() {
 
 			// The "labels" field has type Array, so skip this resource
 			!(productName == "Monitoring" && resourceName == "MetricDescriptor") {
-			log.Fatalf("Please use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+			errs.Add("type", "Please use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
 		}
 	} else if t.IsA("KeyValueLabels") {
-		log.Fatalf("Please don't use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
+		errs.Add("type", "Please don't use type KeyValueLabels for field %s in resource %s/%s", lineage, productName, resourceName)
 	}
 
 	if lineage == "annotations" || lineage == "metadata.annotations" {
 		if !t.IsA("KeyValueAnnotations") &&
 			// The "annotations" field has "ouput: true", so skip this eap resource
 			!(productName == "Gkeonprem" && resourceName == "BareMetalAdminClusterEnrollment") {
-			log.Fatalf("Please use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+			errs.Add("type", "Please use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
 		}
 	} else if t.IsA("KeyValueAnnotations") {
-		log.Fatalf("Please don't use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
+		errs.Add("type", "Please don't use type KeyValueAnnotations for field %s in resource %s/%s", lineage, productName, resourceName)
 	}
+	return errs
 }
 
 func (t Type) fieldMinVersion() string {
@@ -1126,12 +1297,23 @@ func (t *Type) IsForceNew() bool {
 	parent := t.Parent()
 	return !t.WriteOnly && (!t.Output || t.IsA("KeyValueEffectiveLabels")) &&
 		(t.Immutable ||
-			(t.ResourceMetadata.Immutable && t.UpdateUrl == "" &&
+			(t.ResourceMetadata.Immutable && t.UpdateUrl == "" && !t.TriggersCustomMethod() &&
 				(parent == nil ||
 					(parent.IsForceNew() &&
 						!(parent.FlattenObject && t.IsA("KeyValueLabels"))))))
 }
 
+// Returns true if changing the top-level field calls a custom method of
+// the resource
+func (t *Type) TriggersCustomMethod() bool {
+	if t.ResourceMetadata == nil || t.Parent() != nil {
+		return false
+	}
+	return slices.ContainsFunc(t.ResourceMetadata.CustomMethodsForPhase("update"), func(m resource.CustomMethod) bool {
+		return m.TriggerField() == google.Underscore(t.Name)
+	})
+}
+
 // Returns true if the type does not correspond to an API type
 func (t *Type) ProviderOnly() bool {
 	// These are special case fields created by the generator which have no API counterpart
@@ -1205,3 +1387,287 @@ func (t Type) GetPropertySchemaPathList(propertyList []string) []string {
 	}
 	return list
 }
+
+// ====================
+// Plugin Framework Methods
+// ====================
+// Functions used to generate plugin-framework resources, see
+// Resource.FrameworkResource.
+
+// Returns the plugin-framework kind of a primitive property, eg: Int64, or an
+// empty string if the property isn't a primitive.
+func (t Type) frameworkPrimitive() string {
+	switch t.Type {
+	case "Boolean":
+		return "Bool"
+	case "Double":
+		return "Float64"
+	case "Integer":
+		return "Int64"
+	case "String", "Time", "Enum", "ResourceRef":
+		return "String"
+	}
+	return ""
+}
+
+// Returns the plugin-framework kind of the property, which names its value
+// type, plan modifiers and validators, eg: String for types.String and
+// planmodifier.String
+func (t Type) FrameworkKind() string {
+	switch {
+	case t.IsA("NestedObject"):
+		return "Object"
+	case t.IsA("Array"):
+		return "List"
+	case t.isFrameworkMap():
+		return "Map"
+	}
+	return t.frameworkPrimitive()
+}
+
+// Returns true if the property is a map of strings, including labels and
+// annotations.
+func (t Type) isFrameworkMap() bool {
+	return strings.HasPrefix(t.Type, "KeyValue")
+}
+
+// Returns true if the property is a primitive, as opposed to an object or a
+// collection.
+func (t Type) IsFrameworkPrimitive() bool {
+	return t.frameworkPrimitive() != ""
+}
+
+// Returns the plugin-framework schema attribute of the property, eg:
+// ListNestedAttribute
+func (t Type) FrameworkAttribute() string {
+	switch {
+	case t.IsA("NestedObject"):
+		return "SingleNestedAttribute"
+	case t.IsA("Array") && t.ItemType.IsA("NestedObject"):
+		return "ListNestedAttribute"
+	}
+	return fmt.Sprintf("%sAttribute", t.FrameworkKind())
+}
+
+// Returns the attr.Type of the property, eg: types.StringType. Nested objects
+// refer to the attribute types of their model in fwmodels.
+func (t Type) FrameworkAttrType() string {
+	switch {
+	case t.IsA("NestedObject"):
+		return fmt.Sprintf("types.ObjectType{AttrTypes: %sAttrTypes()}", t.FrameworkModelName())
+	case t.IsA("Array"):
+		return fmt.Sprintf("types.ListType{ElemType: %s}", t.ItemType.FrameworkAttrType())
+	case t.isFrameworkMap():
+		return "types.MapType{ElemType: types.StringType}"
+	}
+	return fmt.Sprintf("types.%sType", t.frameworkPrimitive())
+}
+
+// Returns the name of the model of a nested object, which is prefixed with the
+// names of the resource and of its parent properties, eg:
+// PubsubTopicMessageStoragePolicyModel
+func (t Type) FrameworkModelName() string {
+	name := ""
+	for p := &t; p != nil; p = p.Parent() {
+		// Array items share their name with the array
+		if parent := p.Parent(); parent != nil && parent.IsA("Array") {
+			continue
+		}
+		name = google.Camelize(p.Name, "upper") + name
+	}
+	return fmt.Sprintf("%s%sModel", t.ResourceMetadata.ResourceName(), name)
+}
+
+// Returns true if the property is only set by the API. Properties nested in an
+// output property are also output properties.
+func (t Type) FrameworkComputedOnly() bool {
+	for p := &t; p != nil; p = p.Parent() {
+		if p.Output {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the plan modifiers of the property: immutable properties require
+// the resource to be replaced, and properties defaulted by the API keep their
+// value from the state when they are not configured.
+func (t *Type) FrameworkPlanModifiers() []string {
+	var modifiers []string
+	pkg := fmt.Sprintf("%splanmodifier", strings.ToLower(t.FrameworkKind()))
+
+	if t.IsForceNew() && !t.FrameworkComputedOnly() {
+		modifiers = append(modifiers, fmt.Sprintf("%s.RequiresReplace()", pkg))
+	}
+	if t.DefaultFromApi {
+		modifiers = append(modifiers, fmt.Sprintf("%s.UseStateForUnknown()", pkg))
+	}
+	return modifiers
+}
+
+// Returns the validators of the property, mapped from its validation, its enum
+// values and the size of arrays.
+func (t Type) FrameworkValidators() []string {
+	var validators []string
+
+	switch {
+	case t.IsA("Enum"):
+		validators = append(validators, fmt.Sprintf("stringvalidator.OneOf(%s)", t.EnumValuesToString("\"", false)))
+	case t.IsA("Array"):
+		if t.MinSize != "" {
+			validators = append(validators, fmt.Sprintf("listvalidator.SizeAtLeast(%s)", t.MinSize))
+		}
+		if t.MaxSize != "" {
+			validators = append(validators, fmt.Sprintf("listvalidator.SizeAtMost(%s)", t.MaxSize))
+		}
+		if t.ItemValidation.Regex != "" {
+			validators = append(validators, fmt.Sprintf("listvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(`%s`), \"\"))", t.ItemValidation.Regex))
+		}
+	}
+
+	if t.Validation.Regex != "" {
+		validators = append(validators, fmt.Sprintf("stringvalidator.RegexMatches(regexp.MustCompile(`%s`), \"\")", t.Validation.Regex))
+	}
+	return validators
+}
+
+// Returns the static default of a primitive property, eg:
+// stringdefault.StaticString("foo")
+func (t *Type) FrameworkDefault() string {
+	if t.DefaultValue == nil || !t.IsFrameworkPrimitive() {
+		return ""
+	}
+	kind := t.frameworkPrimitive()
+	return fmt.Sprintf("%sdefault.Static%s(%s)", strings.ToLower(kind), kind, t.GoLiteral(t.DefaultValue))
+}
+
+// Returns the name of the typed API struct of a nested object, which is
+// prefixed with the names of the resource and of its parent properties, eg:
+// PubsubTopicMessageStoragePolicyApiObject
+func (t Type) ApiStructName() string {
+	name := ""
+	for p := &t; p != nil; p = p.Parent() {
+		// Array items and map values share their name with the array or map
+		if parent := p.Parent(); parent != nil && (parent.IsA("Array") || parent.IsA("Map")) {
+			continue
+		}
+		name = google.Camelize(p.Name, "upper") + name
+	}
+	return fmt.Sprintf("%s%sApiObject", t.ResourceMetadata.ResourceName(), name)
+}
+
+// Returns the name of the field of the property in its typed API struct.
+// Fields are named after the API, so labels and effective_labels share one.
+func (t Type) ApiStructField() string {
+	return google.Camelize(t.ApiName, "upper")
+}
+
+// Returns the Go type of the property in its typed API struct, eg: string,
+// *bool or []PubsubTopicSchemaSettingsApiObject. Scalars other than strings
+// are pointers so that unset values are told apart from zero values.
+func (t Type) ApiGoType() string {
+	switch {
+	case t.IsA("NestedObject"):
+		return "*" + t.ApiStructName()
+	case t.IsA("Array"):
+		return "[]" + t.ItemType.ApiGoValueType()
+	case t.IsA("Map"):
+		return "map[string]" + t.ValueType.ApiStructName()
+	case strings.HasPrefix(t.Type, "KeyValue"):
+		return "map[string]string"
+	case t.ApiGoValueType() == "string" && !t.SendEmptyValue:
+		return "string"
+	}
+	return "*" + t.ApiGoValueType()
+}
+
+// Returns the Go type of a value of the property, which is also the type of
+// the items of arrays.
+func (t Type) ApiGoValueType() string {
+	switch {
+	case t.IsA("NestedObject"):
+		return t.ApiStructName()
+	case t.IsA("Array"):
+		return "[]" + t.ItemType.ApiGoValueType()
+	case t.IsA("Boolean"):
+		return "bool"
+	case t.IsA("Integer"):
+		return "tpgresource.Int64"
+	case t.IsA("Double"):
+		return "float64"
+	}
+	return "string"
+}
+
+// Returns the nested object that has the typed API struct of the property:
+// the property itself, the items of an array or the values of a map.
+func (t *Type) ApiObject() *Type {
+	switch {
+	case t.IsA("NestedObject"):
+		return t
+	case t.IsA("Array") && t.ItemType.IsA("NestedObject"):
+		return t.ItemType
+	case t.IsA("Map"):
+		return t.ValueType
+	}
+	return nil
+}
+
+// Returns the field of the property that typed API structs don't support, or
+// "" if they support it. Typed expanders and flatteners don't run custom code
+// and only handle the shapes of the untyped ones that map onto Go types.
+func (t Type) ApiTypesUnsupportedField() string {
+	switch {
+	case t.CustomExpand != "":
+		return "custom_expand"
+	case t.CustomFlatten != "":
+		return "custom_flatten"
+	case t.FlattenObject:
+		return "flatten_object"
+	case t.WriteOnly:
+		return "write_only"
+	case t.IsA("Map") && t.KeyExpander != "tpgresource.ExpandString":
+		return "key_expander"
+	case t.IsA("Array") && t.ItemType.IsA("Array"):
+		return "item_type"
+	case t.IsA("Array") && t.IsSet && t.SetHashFunc == "":
+		if !slices.Contains([]string{"String", "Enum", "ResourceRef", "Integer", "NestedObject"}, t.ItemType.Type) {
+			return "item_type"
+		}
+	}
+	return ""
+}
+
+// Returns the properties of a nested object that are fields of its typed API
+// struct. The key of a map value is the key of the map instead.
+func (t Type) ApiStructProperties() []*Type {
+	return google.Reject(t.apiObjectProperties(), func(p *Type) bool {
+		return p.ProviderOnly()
+	})
+}
+
+// Returns the properties of a nested object that are expanded into its typed
+// API struct. Labels and annotations are expanded from their effective
+// fields.
+func (t Type) ApiExpandProperties() []*Type {
+	return google.Reject(t.apiObjectProperties(), func(p *Type) bool {
+		return strings.HasPrefix(p.Type, "KeyValue") && p.IgnoreWrite
+	})
+}
+
+// Returns the properties of a nested object that are flattened from its
+// typed API struct. Items of arrays and values of maps skip the properties
+// that ignore read, like their untyped flatteners.
+func (t Type) ApiFlattenProperties() []*Type {
+	parent := t.Parent()
+	return google.Reject(t.apiObjectProperties(), func(p *Type) bool {
+		return p.IgnoreRead && parent != nil && (parent.IsA("Array") || parent.IsA("Map"))
+	})
+}
+
+func (t Type) apiObjectProperties() []*Type {
+	parent := t.Parent()
+	return google.Reject(t.UserProperties(), func(p *Type) bool {
+		return parent != nil && parent.IsA("Map") && p.Name == parent.KeyName
+	})
+}
@@ -425,3 +425,318 @@ func TestProviderOnly(t *testing.T) {
 		})
 	}
 }
+
+func TestFrameworkModelName(t *testing.T) {
+	t.Parallel()
+
+	root := Type{
+		Name: "config",
+		Type: "NestedObject",
+		Properties: []*Type{
+			{
+				Name: "rules",
+				Type: "Array",
+				ItemType: &Type{
+					Type: "NestedObject",
+					Properties: []*Type{
+						{
+							Name: "matchDetails",
+							Type: "NestedObject",
+						},
+					},
+				},
+			},
+		},
+	}
+	root.SetDefault(&Resource{
+		Name:            "Widget",
+		ProductMetadata: &Product{Name: "Test"},
+	})
+
+	cases := []struct {
+		description string
+		obj         Type
+		expected    string
+	}{
+		{
+			description: "root type",
+			obj:         root,
+			expected:    "TestWidgetConfigModel",
+		},
+		{
+			description: "array of objects",
+			obj:         *root.Properties[0].ItemType,
+			expected:    "TestWidgetConfigRulesModel",
+		},
+		{
+			description: "object in array",
+			obj:         *root.Properties[0].ItemType.Properties[0],
+			expected:    "TestWidgetConfigRulesMatchDetailsModel",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			got := tc.obj.FrameworkModelName()
+			if got != tc.expected {
+				t.Errorf("expected %q to be %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestFrameworkPlanModifiers(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Type
+		expected    []string
+	}{
+		{
+			description: "updatable",
+			obj: Type{
+				Name: "foo",
+				Type: "String",
+			},
+		},
+		{
+			description: "immutable",
+			obj: Type{
+				Name:      "foo",
+				Type:      "Integer",
+				Immutable: true,
+			},
+			expected: []string{"int64planmodifier.RequiresReplace()"},
+		},
+		{
+			description: "output",
+			obj: Type{
+				Name:      "foo",
+				Type:      "String",
+				Output:    true,
+				Immutable: true,
+			},
+		},
+		{
+			description: "default from api",
+			obj: Type{
+				Name:           "foo",
+				Type:           "NestedObject",
+				DefaultFromApi: true,
+			},
+			expected: []string{"objectplanmodifier.UseStateForUnknown()"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			tc.obj.SetDefault(&Resource{})
+
+			got := tc.obj.FrameworkPlanModifiers()
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v to be %v", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestApiGoType(t *testing.T) {
+	t.Parallel()
+
+	root := Type{
+		Name: "config",
+		Type: "NestedObject",
+		Properties: []*Type{
+			{
+				Name: "rules",
+				Type: "Array",
+				ItemType: &Type{
+					Type: "NestedObject",
+					Properties: []*Type{
+						{
+							Name: "priority",
+							Type: "Integer",
+						},
+					},
+				},
+			},
+			{
+				Name:    "backends",
+				Type:    "Map",
+				KeyName: "name",
+				ValueType: &Type{
+					Type: "NestedObject",
+					Properties: []*Type{
+						{
+							Name: "weight",
+							Type: "Double",
+						},
+					},
+				},
+			},
+			{
+				Name: "tags",
+				Type: "Array",
+				ItemType: &Type{
+					Type: "String",
+				},
+			},
+			{
+				Name: "description",
+				Type: "String",
+			},
+			{
+				Name:           "enabled",
+				Type:           "Boolean",
+				SendEmptyValue: true,
+			},
+		},
+	}
+	root.SetDefault(&Resource{
+		Name:            "Widget",
+		ProductMetadata: &Product{Name: "Test"},
+	})
+
+	cases := []struct {
+		description string
+		obj         Type
+		expected    string
+	}{
+		{
+			description: "nested object",
+			obj:         root,
+			expected:    "*TestWidgetConfigApiObject",
+		},
+		{
+			description: "array of objects",
+			obj:         *root.Properties[0],
+			expected:    "[]TestWidgetConfigRulesApiObject",
+		},
+		{
+			description: "integer in array item",
+			obj:         *root.Properties[0].ItemType.Properties[0],
+			expected:    "*tpgresource.Int64",
+		},
+		{
+			description: "map of objects",
+			obj:         *root.Properties[1],
+			expected:    "map[string]TestWidgetConfigBackendsApiObject",
+		},
+		{
+			description: "array of strings",
+			obj:         *root.Properties[2],
+			expected:    "[]string",
+		},
+		{
+			description: "string",
+			obj:         *root.Properties[3],
+			expected:    "string",
+		},
+		{
+			description: "boolean",
+			obj:         *root.Properties[4],
+			expected:    "*bool",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			got := tc.obj.ApiGoType()
+			if got != tc.expected {
+				t.Errorf("expected %q to be %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestApiTypesUnsupportedField(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		obj         Type
+		expected    string
+	}{
+		{
+			description: "string",
+			obj: Type{
+				Name: "foo",
+				Type: "String",
+			},
+		},
+		{
+			description: "custom expand",
+			obj: Type{
+				Name:         "foo",
+				Type:         "String",
+				CustomExpand: "templates/terraform/custom_expand/foo.go.tmpl",
+			},
+			expected: "custom_expand",
+		},
+		{
+			description: "array of arrays",
+			obj: Type{
+				Name: "foo",
+				Type: "Array",
+				ItemType: &Type{
+					Type: "Array",
+					ItemType: &Type{
+						Type: "String",
+					},
+				},
+			},
+			expected: "item_type",
+		},
+		{
+			description: "set of strings",
+			obj: Type{
+				Name:  "foo",
+				Type:  "Array",
+				IsSet: true,
+				ItemType: &Type{
+					Type: "String",
+				},
+			},
+		},
+		{
+			description: "set of booleans",
+			obj: Type{
+				Name:  "foo",
+				Type:  "Array",
+				IsSet: true,
+				ItemType: &Type{
+					Type: "Boolean",
+				},
+			},
+			expected: "item_type",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			tc.obj.SetDefault(&Resource{})
+
+			got := tc.obj.ApiTypesUnsupportedField()
+			if got != tc.expected {
+				t.Errorf("expected %q to be %q", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,503 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Checks the Terraform configs of resource examples against the schemas of
+// the generated resources, so that typos in examples fail generation instead
+// of the acceptance tests or the docs.
+
+package example_check
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// Meta-arguments Terraform accepts in every resource block
+var resourceMetaArguments = []string{"provider", "count", "for_each", "depends_on"}
+var resourceMetaBlocks = []string{"lifecycle", "provisioner", "connection", "timeouts"}
+
+// Roots of references that don't point to something declared in the config
+var builtinReferenceRoots = []string{"each", "count", "self", "path", "terraform"}
+
+// Placeholders like %{random_suffix} that tests fill in with acctest.Nprintf.
+// They look like template directives to HCL, so they're replaced before the
+// config is parsed.
+var nprintfPlaceholder = regexp.MustCompile(`%\{(\w+)\}`)
+
+var templateKeywords = []string{"if", "else", "endif", "for", "endfor"}
+
+// Resources generated by tpgtools from the DCL, whose schemas MMv1 doesn't
+// load
+var dclResourceTypes = []string{
+	"google_apikeys_key",
+	"google_assured_workloads_workload",
+	"google_cloudbuild_worker_pool",
+	"google_clouddeploy_delivery_pipeline",
+	"google_clouddeploy_target",
+	"google_container_aws_cluster",
+	"google_container_aws_node_pool",
+	"google_container_azure_client",
+	"google_container_azure_cluster",
+	"google_container_azure_node_pool",
+	"google_dataplex_asset",
+	"google_dataplex_lake",
+	"google_dataplex_zone",
+	"google_dataproc_workflow_template",
+	"google_firebaserules_release",
+	"google_firebaserules_ruleset",
+	"google_gke_hub_feature_membership",
+	"google_recaptcha_enterprise_key",
+}
+
+// The maps of the provider's resource map template that register handwritten
+// resources
+var handwrittenResourceMaps = []string{"handwrittenResources", "handwrittenIAMResources"}
+
+var resourceMapStart = regexp.MustCompile(`^var (\w+) = map\[string\]\*schema\.Resource\{`)
+var resourceMapEntry = regexp.MustCompile(`^\s*"(google_\w+)":`)
+
+// Returns the Terraform names of the handwritten resources registered in the
+// provider's resource map template, eg:
+// third_party/terraform/provider/provider_mmv1_resources.go.tmpl
+func HandwrittenResourceTypes(path string) ([]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var types []string
+	inHandwrittenMap := false
+	for _, line := range strings.Split(string(content), "\n") {
+		if m := resourceMapStart.FindStringSubmatch(line); m != nil {
+			inHandwrittenMap = slices.Contains(handwrittenResourceMaps, m[1])
+			continue
+		}
+		if line == "}" {
+			inHandwrittenMap = false
+			continue
+		}
+		if m := resourceMapEntry.FindStringSubmatch(line); inHandwrittenMap && m != nil {
+			types = append(types, m[1])
+		}
+	}
+	if len(types) == 0 {
+		return nil, fmt.Errorf("no handwritten resources found in %s", path)
+	}
+	return types, nil
+}
+
+// Checks the examples of every resource of the products. Problems are
+// located in the resource YAML files, under the example they were found in.
+// Resource blocks of the handwritten types are accepted without checking
+// their arguments, and blocks of other unknown google_ types are reported.
+func Run(products []*api.Product, handwrittenTypes []string) google.ValidationErrors {
+	resources := make(map[string]*api.Resource)
+	for _, name := range google.Concat(handwrittenTypes, dclResourceTypes) {
+		resources[name] = nil
+	}
+	for _, p := range products {
+		for _, r := range p.Objects {
+			if r.IamPolicy != nil && !r.IamPolicy.Exclude {
+				for _, suffix := range []string{"_iam_binding", "_iam_member", "_iam_policy"} {
+					resources[r.TerraformName()+suffix] = nil
+				}
+			}
+			if !r.IsExcluded() {
+				resources[r.TerraformName()] = r
+			}
+		}
+	}
+
+	var errs google.ValidationErrors
+	for _, p := range products {
+		for _, r := range p.Objects {
+			if r.Exclude {
+				continue
+			}
+			// Resources and examples of later versions aren't generated, and
+			// may use resources of products that weren't loaded
+			version := p.VersionObjOrClosest(r.TargetVersionName)
+			if r.NotInVersion(version) {
+				continue
+			}
+			var resourceErrs google.ValidationErrors
+			for _, e := range r.Examples {
+				if version.CompareTo(p.VersionObjOrClosest(e.MinVersion)) < 0 {
+					continue
+				}
+				resourceErrs.Append(google.JoinValidationPath("examples", e.Name), CheckExample(e, resources))
+			}
+			if r.SourceYamlFile != "" {
+				resourceErrs.Locate(r.SourceYamlFile)
+			}
+			errs = append(errs, resourceErrs...)
+		}
+	}
+	return errs
+}
+
+// Parses the config of an example, rendered with its vars, and checks it.
+// Blocks of the given resources, keyed by Terraform name, are checked against
+// their properties at the version of the provider the block uses, and blocks
+// of google_ types missing from resources are reported. Resources without a
+// schema, like handwritten ones, are known but not checked. References must
+// point to something declared in the config.
+func CheckExample(e resource.Examples, resources map[string]*api.Resource) google.ValidationErrors {
+	var errs google.ValidationErrors
+
+	// Examples that are only used as tests are checked as they're tested
+	config := e.DocumentationHCLText
+	if e.ExcludeDocs {
+		config = e.TestHCLText
+	}
+	config = nprintfPlaceholder.ReplaceAllStringFunc(config, func(m string) string {
+		if slices.Contains(templateKeywords, nprintfPlaceholder.FindStringSubmatch(m)[1]) {
+			return m
+		}
+		return "placeholder"
+	})
+	file, diags := hclsyntax.ParseConfig([]byte(config), e.ConfigPath, hcl.InitialPos)
+	if diags.HasErrors() {
+		for _, d := range diags.Errs() {
+			errs.Add("", "Invalid config: %s", d)
+		}
+		return errs
+	}
+
+	c := &checker{
+		configPath: e.ConfigPath,
+		resources:  resources,
+		declared:   make(map[string]bool),
+		iterators:  make(map[string]bool),
+	}
+	body := file.Body.(*hclsyntax.Body)
+	c.collectDeclarations(body)
+
+	for _, block := range body.Blocks {
+		if block.Type != "resource" || len(block.Labels) != 2 {
+			continue
+		}
+		r, ok := resources[block.Labels[0]]
+		switch {
+		case !ok && strings.HasPrefix(block.Labels[0], "google_"):
+			c.addError(block.LabelRanges[0], "unknown resource type %q", block.Labels[0])
+		case r != nil:
+			c.checkResource(r, block)
+		}
+	}
+	c.checkReferences(body, "")
+
+	return c.errs
+}
+
+type checker struct {
+	configPath string
+	resources  map[string]*api.Resource
+
+	// The addresses declared in the config, eg: google_compute_network.default
+	// or data.google_project.project
+	declared map[string]bool
+
+	// The iterator names of dynamic blocks
+	iterators map[string]bool
+
+	errs google.ValidationErrors
+}
+
+func (c *checker) addError(rng hcl.Range, format string, args ...any) {
+	c.errs.Add("", "%s:%d: %s", c.configPath, rng.Start.Line, fmt.Sprintf(format, args...))
+}
+
+func (c *checker) collectDeclarations(body *hclsyntax.Body) {
+	for _, block := range body.Blocks {
+		switch {
+		case block.Type == "resource" && len(block.Labels) == 2:
+			c.declared[block.Labels[0]+"."+block.Labels[1]] = true
+		case (block.Type == "data" || block.Type == "ephemeral") && len(block.Labels) == 2:
+			c.declared[block.Type+"."+block.Labels[0]+"."+block.Labels[1]] = true
+		case block.Type == "variable" && len(block.Labels) == 1:
+			c.declared["var."+block.Labels[0]] = true
+		case block.Type == "module" && len(block.Labels) == 1:
+			c.declared["module."+block.Labels[0]] = true
+		case block.Type == "locals":
+			for name := range block.Body.Attributes {
+				c.declared["local."+name] = true
+			}
+		}
+		c.collectIterators(block.Body)
+	}
+}
+
+func (c *checker) collectIterators(body *hclsyntax.Body) {
+	for _, block := range body.Blocks {
+		if block.Type == "dynamic" && len(block.Labels) == 1 {
+			c.iterators[block.Labels[0]] = true
+			if attr, ok := block.Body.Attributes["iterator"]; ok {
+				if name := hcl.ExprAsKeyword(attr.Expr); name != "" {
+					c.iterators[name] = true
+				}
+			}
+		}
+		c.collectIterators(block.Body)
+	}
+}
+
+// Returns the provider version a resource block uses
+func blockVersion(body *hclsyntax.Body) *product.Version {
+	if attr, ok := body.Attributes["provider"]; ok {
+		if traversal, diags := hcl.AbsTraversalForExpr(attr.Expr); !diags.HasErrors() && traversal.RootName() == "google-beta" {
+			return &product.Version{Name: "beta"}
+		}
+	}
+	return &product.Version{Name: "ga"}
+}
+
+func (c *checker) checkResource(r *api.Resource, block *hclsyntax.Block) {
+	address := block.Labels[0] + "." + block.Labels[1]
+	version := blockVersion(block.Body)
+	// The resource may be handwritten or generated by DCL at versions MMv1
+	// doesn't generate it at, so its schema is unknown
+	if r.NotInVersion(version) {
+		return
+	}
+
+	props := google.Concat(r.AllUserProperties(), r.UserVirtualFields())
+	fields := schemaFields(props)
+	if r.HasProject() {
+		if _, ok := fields["project"]; !ok {
+			fields["project"] = nil
+		}
+	}
+	for _, name := range resourceMetaArguments {
+		fields[name] = nil
+	}
+
+	// Fields added by custom code are unknown, so any other argument is
+	// accepted at the top level
+	allowUnknown := r.CustomCode.ExtraSchemaEntry != ""
+	c.checkBody(address, block.Body, fields, version, allowUnknown, r.FrameworkResource, true)
+}
+
+// Returns the fields of a schema level by Terraform name, with the fields of
+// objects that are flattened into it
+func schemaFields(props []*api.Type) map[string]*api.Type {
+	fields := make(map[string]*api.Type)
+	for _, p := range props {
+		if p.Exclude {
+			continue
+		}
+		if p.FlattenObject {
+			for name, f := range schemaFields(p.Properties) {
+				fields[name] = f
+			}
+			continue
+		}
+		fields[google.Underscore(p.Name)] = p
+	}
+	return fields
+}
+
+// Returns the fields of the blocks of a property
+func nestedFields(p *api.Type) map[string]*api.Type {
+	switch {
+	case p.IsA("NestedObject"):
+		return schemaFields(p.Properties)
+	case p.IsA("Array") && p.ItemType != nil && p.ItemType.IsA("NestedObject"):
+		return schemaFields(p.ItemType.Properties)
+	case p.IsA("Map") && p.ValueType != nil:
+		fields := schemaFields(p.ValueType.Properties)
+		fields[p.KeyName] = nil
+		return fields
+	}
+	return nil
+}
+
+// Returns true if the property is set with nested blocks rather than as an
+// argument
+func isBlock(p *api.Type, framework bool) bool {
+	if framework {
+		return false
+	}
+	return p.IsA("NestedObject") || p.IsA("Map") || (p.IsA("Array") && p.ItemType != nil && p.ItemType.IsA("NestedObject"))
+}
+
+func inVersion(p *api.Type, version *product.Version) bool {
+	if p.ExactVersion != "" && p.ExactVersion != version.Name {
+		return false
+	}
+	return version.CompareTo(p.MinVersionObj()) >= 0
+}
+
+// Checks a field of a block, returning false if it's unknown or can't be set
+func (c *checker) checkField(path string, rng hcl.Range, name string, fields map[string]*api.Type, version *product.Version, allowUnknown bool) (*api.Type, bool) {
+	p, ok := fields[name]
+	if !ok {
+		if !allowUnknown {
+			c.addError(rng, "%s: unsupported argument or block %q", path, name)
+		}
+		return nil, false
+	}
+	if p == nil {
+		return nil, false
+	}
+	if !inVersion(p, version) {
+		c.addError(rng, "%s: %q is not available at version %s", path, name, version.Name)
+		return nil, false
+	}
+	if p.Output {
+		c.addError(rng, "%s: %q is output only and can't be set", path, name)
+		return nil, false
+	}
+	return p, true
+}
+
+func (c *checker) checkBody(path string, body *hclsyntax.Body, fields map[string]*api.Type, version *product.Version, allowUnknown, framework, topLevel bool) {
+	for _, attr := range sortedAttributes(body) {
+		p, ok := c.checkField(path, attr.NameRange, attr.Name, fields, version, allowUnknown)
+		if ok && isBlock(p, framework) && !p.SchemaConfigModeAttr {
+			c.addError(attr.NameRange, "%s: %q must be set with a block, not as an argument", path, attr.Name)
+		}
+	}
+
+	for _, block := range body.Blocks {
+		if topLevel && slices.Contains(resourceMetaBlocks, block.Type) {
+			continue
+		}
+		name, nested := block.Type, block.Body
+		if block.Type == "dynamic" && len(block.Labels) == 1 {
+			name = block.Labels[0]
+			nested = nil
+			for _, b := range block.Body.Blocks {
+				if b.Type == "content" {
+					nested = b.Body
+				}
+			}
+		}
+
+		p, ok := c.checkField(path, block.TypeRange, name, fields, version, allowUnknown)
+		if !ok {
+			continue
+		}
+		if !isBlock(p, framework) {
+			c.addError(block.TypeRange, "%s: %q must be set as an argument, not with a block", path, name)
+			continue
+		}
+		if nested != nil {
+			c.checkBody(path+"."+name, nested, nestedFields(p), version, false, framework, false)
+		}
+	}
+}
+
+// Checks that the references in a body point to something declared in the
+// config. The block type is used to skip arguments that aren't expressions.
+func (c *checker) checkReferences(body *hclsyntax.Body, blockType string) {
+	for _, attr := range sortedAttributes(body) {
+		if skipReferences(blockType, attr.Name) {
+			continue
+		}
+		for _, traversal := range attr.Expr.Variables() {
+			c.checkReference(traversal)
+		}
+	}
+
+	for _, block := range body.Blocks {
+		switch block.Type {
+		case "terraform", "moved", "removed":
+			continue
+		}
+		c.checkReferences(block.Body, block.Type)
+	}
+}
+
+func skipReferences(blockType, attrName string) bool {
+	switch {
+	case attrName == "provider" || attrName == "providers":
+		return true
+	case blockType == "lifecycle" && attrName == "ignore_changes":
+		return true
+	case blockType == "variable" && attrName == "type":
+		return true
+	}
+	return false
+}
+
+func (c *checker) checkReference(traversal hcl.Traversal) {
+	root := traversal.RootName()
+	if slices.Contains(builtinReferenceRoots, root) || c.iterators[root] {
+		return
+	}
+
+	names := []string{root}
+	for _, step := range traversal[1:] {
+		attr, ok := step.(hcl.TraverseAttr)
+		if !ok {
+			break
+		}
+		names = append(names, attr.Name)
+	}
+
+	// resource.<type>.<name> is an explicit form of <type>.<name>
+	if root == "resource" && len(names) > 1 {
+		names = names[1:]
+		root = names[0]
+	}
+
+	var parts int
+	switch root {
+	case "data", "ephemeral":
+		parts = 3
+	case "var", "local", "module":
+		parts = 2
+	default:
+		// A bare name, eg: an interpolation in a script, isn't a reference
+		// to a resource
+		parts = 2
+		if len(traversal) == 1 {
+			return
+		}
+	}
+	if len(names) < parts {
+		return
+	}
+
+	address := names[0]
+	for _, n := range names[1:parts] {
+		address += "." + n
+	}
+	if !c.declared[address] {
+		c.addError(traversal.SourceRange(), "reference to undeclared %s", address)
+	}
+}
+
+func sortedAttributes(body *hclsyntax.Body) []*hclsyntax.Attribute {
+	attrs := make([]*hclsyntax.Attribute, 0, len(body.Attributes))
+	for _, attr := range body.Attributes {
+		attrs = append(attrs, attr)
+	}
+	sort.Slice(attrs, func(i, j int) bool {
+		return attrs[i].SrcRange.Start.Byte < attrs[j].SrcRange.Start.Byte
+	})
+	return attrs
+}
@@ -0,0 +1,261 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package example_check
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+)
+
+func testResources() map[string]*api.Resource {
+	p := &api.Product{
+		Name: "Test",
+		Versions: []*product.Version{
+			{Name: "ga", BaseUrl: "ga_url"},
+			{Name: "beta", BaseUrl: "beta_url"},
+		},
+	}
+	r := &api.Resource{
+		Name:    "Widget",
+		BaseUrl: "projects/{{project}}/widgets",
+		Properties: []*api.Type{
+			{Name: "name", Type: "String", Required: true},
+			{Name: "selfLink", Type: "String", Output: true},
+			{Name: "betaField", Type: "String", MinVersion: "beta"},
+			{Name: "tags", Type: "Array", ItemType: &api.Type{Type: "String"}},
+			{
+				Name: "config",
+				Type: "NestedObject",
+				Properties: []*api.Type{
+					{Name: "size", Type: "Integer"},
+				},
+			},
+		},
+	}
+	p.Objects = []*api.Resource{r}
+	r.SetDefault(p)
+	return map[string]*api.Resource{
+		r.TerraformName(): r,
+		// A handwritten resource, whose schema is unknown
+		"google_compute_network": nil,
+	}
+}
+
+func TestCheckExample(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		config      string
+		expected    []string
+	}{
+		{
+			description: "valid config",
+			config: `
+resource "google_compute_network" "default" {
+  name = "net-%{random_suffix}"
+}
+
+resource "google_test_widget" "default" {
+  name    = google_compute_network.default.name
+  project = "my-project"
+  tags    = ["a"]
+  config {
+    size = 1
+  }
+  lifecycle {
+    prevent_destroy = true
+  }
+}
+`,
+		},
+		{
+			description: "unknown argument",
+			config: `
+resource "google_test_widget" "default" {
+  name  = "widget"
+  color = "blue"
+}
+`,
+			expected: []string{
+				`example.tf:4: google_test_widget.default: unsupported argument or block "color"`,
+			},
+		},
+		{
+			description: "unknown nested argument",
+			config: `
+resource "google_test_widget" "default" {
+  name = "widget"
+  config {
+    weight = 1
+  }
+}
+`,
+			expected: []string{
+				`example.tf:5: google_test_widget.default.config: unsupported argument or block "weight"`,
+			},
+		},
+		{
+			description: "output only field",
+			config: `
+resource "google_test_widget" "default" {
+  name      = "widget"
+  self_link = "link"
+}
+`,
+			expected: []string{
+				`example.tf:4: google_test_widget.default: "self_link" is output only and can't be set`,
+			},
+		},
+		{
+			description: "block set as an argument",
+			config: `
+resource "google_test_widget" "default" {
+  name   = "widget"
+  config = { size = 1 }
+}
+`,
+			expected: []string{
+				`example.tf:4: google_test_widget.default: "config" must be set with a block, not as an argument`,
+			},
+		},
+		{
+			description: "argument set as a block",
+			config: `
+resource "google_test_widget" "default" {
+  name = "widget"
+  tags {
+  }
+}
+`,
+			expected: []string{
+				`example.tf:4: google_test_widget.default: "tags" must be set as an argument, not with a block`,
+			},
+		},
+		{
+			description: "beta field at ga",
+			config: `
+resource "google_test_widget" "ga" {
+  name       = "widget"
+  beta_field = "value"
+}
+
+resource "google_test_widget" "beta" {
+  provider   = google-beta
+  name       = "widget"
+  beta_field = "value"
+}
+`,
+			expected: []string{
+				`example.tf:4: google_test_widget.ga: "beta_field" is not available at version ga`,
+			},
+		},
+		{
+			description: "undeclared references",
+			config: `
+data "google_project" "project" {}
+
+resource "google_test_widget" "default" {
+  name       = "${data.google_project.project.name}-${google_compute_network.missing.name}"
+  tags       = [data.google_client_config.current.project, resource.google_test_widget.other.name]
+  depends_on = [google_compute_network.missing]
+}
+`,
+			expected: []string{
+				"example.tf:5: reference to undeclared google_compute_network.missing",
+				"example.tf:6: reference to undeclared data.google_client_config.current",
+				"example.tf:6: reference to undeclared google_test_widget.other",
+				"example.tf:7: reference to undeclared google_compute_network.missing",
+			},
+		},
+		{
+			description: "misspelled resource type",
+			config: `
+resource "google_test_widgets" "default" {
+  name = "widget"
+}
+
+resource "random_id" "default" {
+  byte_length = 4
+}
+`,
+			expected: []string{
+				`example.tf:2: unknown resource type "google_test_widgets"`,
+			},
+		},
+		{
+			description: "invalid config",
+			config: `
+resource "google_test_widget" "default" {
+  name = "widget"
+`,
+			expected: []string{
+				"Invalid config",
+			},
+		},
+	}
+
+	resources := testResources()
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			e := resource.Examples{
+				Name:                 "example",
+				ConfigPath:           "example.tf",
+				DocumentationHCLText: tc.config,
+			}
+
+			var got []string
+			for _, err := range CheckExample(e, resources) {
+				msg := err.Message
+				if strings.HasPrefix(msg, "Invalid config") {
+					msg = "Invalid config"
+				}
+				got = append(got, msg)
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %q to be %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestHandwrittenResourceTypes(t *testing.T) {
+	t.Parallel()
+
+	types, err := HandwrittenResourceTypes("../third_party/terraform/provider/provider_mmv1_resources.go.tmpl")
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	for _, name := range []string{"google_bigquery_table", "google_bigtable_instance_iam_member"} {
+		if !slices.Contains(types, name) {
+			t.Errorf("expected handwritten resource %q in %v", name, types)
+		}
+	}
+	// Data sources and generated resources are registered in other maps
+	for _, name := range []string{"google_active_folder", "{{ $object.TerraformName }}"} {
+		if slices.Contains(types, name) {
+			t.Errorf("expected %q to not be a handwritten resource", name)
+		}
+	}
+}
@@ -0,0 +1,195 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package google
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// A single problem found while loading or validating a product or resource
+// definition.
+type ValidationError struct {
+	// The YAML file the problem was found in. May be empty if the problem is
+	// not tied to a file.
+	File string
+
+	// The line within File, or 0 if it could not be determined.
+	Line int
+
+	// A dot notation path to the offending field, using the YAML keys of the
+	// definition. Entries of named lists are addressed by their name, e.g.
+	// properties.network.item_type.properties.subnetwork
+	Path string
+
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	var location string
+	switch {
+	case e.File != "" && e.Line > 0:
+		location = fmt.Sprintf("%s:%d", e.File, e.Line)
+	case e.File != "":
+		location = e.File
+	}
+
+	parts := []string{}
+	for _, p := range []string{location, e.Path, e.Message} {
+		if p != "" {
+			parts = append(parts, p)
+		}
+	}
+	return strings.Join(parts, ": ")
+}
+
+// A list of problems found while validating definitions. Validation collects
+// every problem instead of stopping at the first one, so that all of them can
+// be reported together.
+type ValidationErrors []*ValidationError
+
+// Records a new problem found at the given path.
+func (errs *ValidationErrors) Add(path, format string, args ...any) {
+	*errs = append(*errs, &ValidationError{
+		Path:    path,
+		Message: fmt.Sprintf(format, args...),
+	})
+}
+
+// Appends the problems of a nested object, prefixing their paths with the
+// path of the nested object within its parent.
+func (errs *ValidationErrors) Append(prefix string, other ValidationErrors) {
+	for _, e := range other {
+		e.Path = JoinValidationPath(prefix, e.Path)
+		*errs = append(*errs, e)
+	}
+}
+
+// Joins the segments of a validation path, skipping empty ones.
+func JoinValidationPath(segments ...string) string {
+	var nonEmpty []string
+	for _, s := range segments {
+		if s != "" {
+			nonEmpty = append(nonEmpty, s)
+		}
+	}
+	return strings.Join(nonEmpty, ".")
+}
+
+// Sets the file of every problem that doesn't have one yet, and resolves the
+// line number of each of them from its path.
+func (errs ValidationErrors) Locate(yamlPath string) {
+	var root *yamlv3.Node
+	if content, err := os.ReadFile(yamlPath); err == nil {
+		var doc yamlv3.Node
+		if yamlv3.Unmarshal(content, &doc) == nil {
+			root = &doc
+		}
+	}
+
+	for _, e := range errs {
+		if e.File != "" {
+			continue
+		}
+		e.File = yamlPath
+		if e.Line == 0 && root != nil {
+			e.Line = lineOfPath(root, e.Path)
+		}
+	}
+}
+
+// Returns the line of the deepest node of the YAML document that matches the
+// given path.
+func lineOfPath(node *yamlv3.Node, path string) int {
+	if node.Kind == yamlv3.DocumentNode && len(node.Content) > 0 {
+		node = node.Content[0]
+	}
+	line := node.Line
+
+	if path == "" {
+		return line
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		next, keyLine := childNode(node, segment)
+		if next == nil {
+			break
+		}
+		node = next
+		line = keyLine
+	}
+	return line
+}
+
+// Finds the child of a mapping node by key, or the entry of a sequence node
+// whose `name` matches the segment. The returned line is the line of the key
+// for mappings and of the entry for sequences.
+func childNode(node *yamlv3.Node, segment string) (*yamlv3.Node, int) {
+	switch node.Kind {
+	case yamlv3.MappingNode:
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == segment {
+				return node.Content[i+1], node.Content[i].Line
+			}
+		}
+	case yamlv3.SequenceNode:
+		for _, entry := range node.Content {
+			if entry.Kind != yamlv3.MappingNode {
+				continue
+			}
+			for i := 0; i+1 < len(entry.Content); i += 2 {
+				if entry.Content[i].Value == "name" && entry.Content[i+1].Value == segment {
+					return entry, entry.Line
+				}
+			}
+		}
+	}
+	return nil, 0
+}
+
+// Sorts the problems by file, line and path.
+func (errs ValidationErrors) Sort() {
+	sort.SliceStable(errs, func(i, j int) bool {
+		a, b := errs[i], errs[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.Path < b.Path
+	})
+}
+
+func (errs ValidationErrors) Error() string {
+	var lines []string
+	for _, e := range errs {
+		lines = append(lines, e.Error())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// Returns a human readable report of all problems, one per line.
+func (errs ValidationErrors) Report() string {
+	files := map[string]bool{}
+	for _, e := range errs {
+		files[e.File] = true
+	}
+
+	return fmt.Sprintf("Found %d validation error(s) in %d file(s):\n%s", len(errs), len(files), errs.Error())
+}
@@ -0,0 +1,157 @@
+package google
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const validationTestYaml = `name: 'Topic'
+description: |
+  A named resource.
+properties:
+  - name: 'name'
+    type: String
+  - name: 'messageStoragePolicy'
+    type: NestedObject
+    properties:
+      - name: 'allowedPersistenceRegions'
+        type: Array
+        item_type:
+          type: String
+`
+
+func TestValidationErrorsLocate(t *testing.T) {
+	t.Parallel()
+
+	yamlPath := filepath.Join(t.TempDir(), "Topic.yaml")
+	if err := os.WriteFile(yamlPath, []byte(validationTestYaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		description string
+		path        string
+		expected    int
+	}{
+		{
+			description: "top level key",
+			path:        "description",
+			expected:    2,
+		},
+		{
+			description: "named list entry",
+			path:        "properties.messageStoragePolicy",
+			expected:    7,
+		},
+		{
+			description: "key within nested list entry",
+			path:        "properties.messageStoragePolicy.properties.allowedPersistenceRegions.item_type",
+			expected:    12,
+		},
+		{
+			description: "missing key falls back to the closest parent",
+			path:        "properties.name.default_value",
+			expected:    5,
+		},
+		{
+			description: "empty path is the document",
+			path:        "",
+			expected:    1,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var errs ValidationErrors
+			errs.Add(tc.path, "problem")
+			errs.Locate(yamlPath)
+
+			if got, want := errs[0].Line, tc.expected; got != want {
+				t.Errorf("expected line %d to be %d", got, want)
+			}
+			if got, want := errs[0].File, yamlPath; got != want {
+				t.Errorf("expected file %q to be %q", got, want)
+			}
+		})
+	}
+}
+
+func TestValidationErrorsAppend(t *testing.T) {
+	t.Parallel()
+
+	var nested ValidationErrors
+	nested.Add("name", "missing name")
+	nested.Add("", "output and required")
+
+	var errs ValidationErrors
+	errs.Add("description", "missing description")
+	errs.Append(JoinValidationPath("properties", "foo"), nested)
+
+	var got []string
+	for _, e := range errs {
+		got = append(got, e.Path)
+	}
+	want := []string{"description", "properties.foo.name", "properties.foo"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v to be %v", got, want)
+	}
+}
+
+func TestYamlValidatorParse(t *testing.T) {
+	t.Parallel()
+
+	type obj struct {
+		Name        string
+		Description string
+	}
+
+	cases := []struct {
+		description string
+		content     string
+		expected    []ValidationError
+	}{
+		{
+			description: "valid content",
+			content:     "name: foo\ndescription: bar\n",
+		},
+		{
+			description: "every unknown field is reported",
+			content:     "name: foo\ndescriptionx: bar\nfoo: baz\n",
+			expected: []ValidationError{
+				{File: "obj.yaml", Line: 2, Message: "field descriptionx not found in type google.obj"},
+				{File: "obj.yaml", Line: 3, Message: "field foo not found in type google.obj"},
+			},
+		},
+		{
+			description: "syntax error",
+			content:     "name: foo\n  description: bar\n",
+			expected: []ValidationError{
+				{File: "obj.yaml", Line: 2, Message: "mapping values are not allowed in this context"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			v := YamlValidator{}
+			var got []ValidationError
+			for _, e := range v.Parse([]byte(tc.content), &obj{}, "obj.yaml") {
+				got = append(got, *e)
+			}
+
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %v to be %v", got, tc.expected)
+			}
+		})
+	}
+}
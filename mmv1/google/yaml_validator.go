@@ -14,7 +14,8 @@
 package google
 
 import (
-	"log"
+	"regexp"
+	"strconv"
 
 	"gopkg.in/yaml.v2"
 )
@@ -22,8 +23,30 @@ import (
 // A helper class to validate contents coming from YAML files.
 type YamlValidator struct{}
 
-func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) {
-	if err := yaml.UnmarshalStrict(content, obj); err != nil {
-		log.Fatalf("Cannot unmarshal data from file %s: %v", yamlPath, err)
+var yamlErrorLineRegexp = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+
+// Unmarshals the content into obj, returning every problem reported by the
+// strict decoder. Unknown or mistyped fields don't stop decoding, so the
+// partially decoded obj can still be validated afterwards.
+func (v *YamlValidator) Parse(content []byte, obj interface{}, yamlPath string) ValidationErrors {
+	err := yaml.UnmarshalStrict(content, obj)
+	if err == nil {
+		return nil
+	}
+
+	messages := []string{err.Error()}
+	if typeErr, ok := err.(*yaml.TypeError); ok {
+		messages = typeErr.Errors
+	}
+
+	var errs ValidationErrors
+	for _, m := range messages {
+		e := &ValidationError{File: yamlPath, Message: m}
+		if match := yamlErrorLineRegexp.FindStringSubmatch(m); match != nil {
+			e.Line, _ = strconv.Atoi(match[1])
+			e.Message = match[2]
+		}
+		errs = append(errs, e)
 	}
+	return errs
 }
@@ -0,0 +1,340 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Generates JSON Schemas for product and resource YAML from the api structs,
+// so editors can validate and autocomplete YAML while it's being written.
+
+package json_schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path"
+	"path/filepath"
+	"reflect"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+)
+
+const draft = "http://json-schema.org/draft-07/schema#"
+
+const (
+	ProductSchemaFile  = "product.schema.json"
+	ResourceSchemaFile = "resource.schema.json"
+)
+
+// The values allowed for string fields, keyed by the field's struct and Go
+// name like "api.Type.Type"
+var enums = map[string][]string{
+	"api.Type.Type":                               api.PropertyTypes,
+	"api.Type.MinVersion":                         product.ORDER,
+	"api.Type.ExactVersion":                       product.ORDER,
+	"api.Async.Type":                              api.AsyncTypes,
+	"api.Resource.CreateVerb":                     api.CreateVerbs,
+	"api.Resource.ReadVerb":                       api.ReadVerbs,
+	"api.Resource.UpdateVerb":                     api.UpdateVerbs,
+	"api.Resource.DeleteVerb":                     api.DeleteVerbs,
+	"api.Resource.MinVersion":                     product.ORDER,
+	"product.Version.Name":                        product.ORDER,
+	"resource.Examples.MinVersion":                product.ORDER,
+	"resource.IamPolicy.FetchIamPolicyVerb":       resource.FetchIamPolicyVerbs,
+	"resource.IamPolicy.SetIamPolicyVerb":         resource.SetIamPolicyVerbs,
+	"resource.IamPolicy.IamConditionsRequestType": resource.IamConditionsRequestTypes,
+	"resource.IamPolicy.MinVersion":               product.ORDER,
+	"resource.CustomMethod.Verb":                  resource.CustomMethodVerbs,
+	"resource.CustomMethod.Phase":                 resource.CustomMethodPhases,
+	"resource.Batching.Combine":                   resource.BatchCombines,
+}
+
+// Fields that are set while loading the YAML and never written in it
+var skipped = map[string]bool{
+	"api.Type.ResourceMetadata": true,
+	"api.Type.ParentMetadata":   true,
+}
+
+// The fields a property requires, keyed by its type. These mirror the checks
+// in Type.Validate.
+var requiredByType = map[string][]string{
+	"Array": {"item_type"},
+	"Map":   {"value_type"},
+}
+
+// A JSON Schema, limited to the keywords used for the api structs
+type Schema struct {
+	Schema               string             `json:"$schema,omitempty"`
+	Ref                  string             `json:"$ref,omitempty"`
+	Title                string             `json:"title,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Const                string             `json:"const,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties any                `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+	If                   *Schema            `json:"if,omitempty"`
+	Then                 *Schema            `json:"then,omitempty"`
+	Definitions          map[string]*Schema `json:"definitions,omitempty"`
+}
+
+// Returns the schema of product.yaml files. The descriptions are read from
+// the doc comments of the api packages in apiDir.
+func ProductSchema(apiDir string) (*Schema, error) {
+	return rootSchema(apiDir, "MMv1 product", reflect.TypeOf(api.Product{}))
+}
+
+// Returns the schema of resource YAML files. The descriptions are read from
+// the doc comments of the api packages in apiDir.
+func ResourceSchema(apiDir string) (*Schema, error) {
+	return rootSchema(apiDir, "MMv1 resource", reflect.TypeOf(api.Resource{}))
+}
+
+// Writes the product and resource schemas to outputDir
+func Write(apiDir, outputDir string) error {
+	productSchema, err := ProductSchema(apiDir)
+	if err != nil {
+		return err
+	}
+	resourceSchema, err := ResourceSchema(apiDir)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return err
+	}
+	for file, s := range map[string]*Schema{
+		ProductSchemaFile:  productSchema,
+		ResourceSchemaFile: resourceSchema,
+	} {
+		content, err := Marshal(s)
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(outputDir, file), content, 0644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Returns the indented JSON of the schema, as written by Write
+func Marshal(s *Schema) ([]byte, error) {
+	content, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(content, '\n'), nil
+}
+
+func rootSchema(apiDir, title string, t reflect.Type) (*Schema, error) {
+	docs, err := readDocs(apiDir)
+	if err != nil {
+		return nil, err
+	}
+	g := &generator{
+		docs:        docs,
+		definitions: make(map[string]*Schema),
+	}
+	root := g.schema(t)
+	root.Schema = draft
+	root.Title = title
+	root.Definitions = g.definitions
+	return root, nil
+}
+
+type generator struct {
+	// Doc comments keyed like "api.Type" for structs and "api.Type.Name"
+	// for fields
+	docs map[string]string
+
+	definitions map[string]*Schema
+}
+
+// Returns the schema of a value of type t. Structs are added to the
+// definitions and referenced.
+func (g *generator) schema(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return g.schema(t.Elem())
+	case reflect.Struct:
+		g.define(t)
+		return &Schema{Ref: "#/definitions/" + definitionName(t)}
+	case reflect.Slice, reflect.Array:
+		return &Schema{Type: "array", Items: g.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: g.schema(t.Elem())}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return &Schema{Type: "integer"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	}
+	// interface{} fields accept any value
+	return &Schema{}
+}
+
+func (g *generator) define(t reflect.Type) {
+	name := definitionName(t)
+	if _, ok := g.definitions[name]; ok {
+		return
+	}
+	s := &Schema{
+		Description:          g.docs[name],
+		Type:                 "object",
+		Properties:           make(map[string]*Schema),
+		AdditionalProperties: false,
+	}
+	// Added before its fields, as structs like api.Type reference themselves
+	g.definitions[name] = s
+	g.addFields(s, t)
+
+	if t == reflect.TypeOf(api.Type{}) {
+		for _, propertyType := range api.PropertyTypes {
+			required, ok := requiredByType[propertyType]
+			if !ok {
+				continue
+			}
+			s.AllOf = append(s.AllOf, &Schema{
+				If: &Schema{
+					Properties: map[string]*Schema{"type": {Const: propertyType}},
+					Required:   []string{"type"},
+				},
+				Then: &Schema{Required: required},
+			})
+		}
+	}
+}
+
+// Adds the fields of t to s, following the field names used by yaml.v2
+func (g *generator) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		key := definitionName(t) + "." + field.Name
+		if skipped[key] {
+			continue
+		}
+
+		name, options, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if name == "-" {
+			continue
+		}
+		if strings.Contains(options, "inline") {
+			g.addFields(s, field.Type)
+			continue
+		}
+		if name == "" {
+			name = strings.ToLower(field.Name)
+		}
+
+		fieldSchema := g.schema(field.Type)
+		if values, ok := enums[key]; ok {
+			fieldSchema.Enum = values
+		}
+		if description := g.docs[key]; description != "" {
+			// Keywords next to $ref are ignored in draft-07
+			if fieldSchema.Ref != "" {
+				fieldSchema = &Schema{AllOf: []*Schema{fieldSchema}}
+			}
+			fieldSchema.Description = description
+		}
+		s.Properties[name] = fieldSchema
+	}
+}
+
+// Returns the name of a struct's definition like "api.Type"
+func definitionName(t reflect.Type) string {
+	return fmt.Sprintf("%s.%s", path.Base(t.PkgPath()), t.Name())
+}
+
+// Reads the doc comments of the structs in the api packages and their fields
+func readDocs(apiDir string) (map[string]string, error) {
+	docs := make(map[string]string)
+	for _, dir := range []string{apiDir, filepath.Join(apiDir, "product"), filepath.Join(apiDir, "resource")} {
+		pkgs, err := parser.ParseDir(token.NewFileSet(), dir, func(fi os.FileInfo) bool {
+			return !strings.HasSuffix(fi.Name(), "_test.go")
+		}, parser.ParseComments)
+		if err != nil {
+			return nil, fmt.Errorf("error reading doc comments in %s: %w", dir, err)
+		}
+		for pkgName, pkg := range pkgs {
+			for _, file := range pkg.Files {
+				addDocs(docs, pkgName, file)
+			}
+		}
+	}
+	return docs, nil
+}
+
+func addDocs(docs map[string]string, pkgName string, file *ast.File) {
+	for _, decl := range file.Decls {
+		genDecl, ok := decl.(*ast.GenDecl)
+		if !ok || genDecl.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range genDecl.Specs {
+			typeSpec := spec.(*ast.TypeSpec)
+			structType, ok := typeSpec.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			typeName := pkgName + "." + typeSpec.Name.Name
+			doc := typeSpec.Doc
+			if doc == nil && len(genDecl.Specs) == 1 {
+				doc = genDecl.Doc
+			}
+			docs[typeName] = commentText(doc)
+
+			for _, field := range structType.Fields.List {
+				doc := field.Doc
+				if doc == nil {
+					doc = field.Comment
+				}
+				for _, name := range field.Names {
+					docs[typeName+"."+name.Name] = commentText(doc)
+				}
+			}
+		}
+	}
+}
+
+// Returns the text of a comment, leaving out TODOs and commented out code
+func commentText(group *ast.CommentGroup) string {
+	if group == nil {
+		return ""
+	}
+	var lines []string
+	for _, line := range strings.Split(group.Text(), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "TODO") || strings.HasPrefix(line, "//") {
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
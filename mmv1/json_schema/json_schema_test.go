@@ -0,0 +1,146 @@
+package json_schema
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func TestSchemasUpToDate(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		file     string
+		generate func(string) (*Schema, error)
+	}{
+		{
+			file:     ProductSchemaFile,
+			generate: ProductSchema,
+		},
+		{
+			file:     ResourceSchemaFile,
+			generate: ResourceSchema,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.file, func(t *testing.T) {
+			t.Parallel()
+
+			s, err := tc.generate("../api")
+			if err != nil {
+				t.Fatal(err)
+			}
+			generated, err := Marshal(s)
+			if err != nil {
+				t.Fatal(err)
+			}
+			committed, err := os.ReadFile(filepath.Join("..", "schemas", tc.file))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(generated) != string(committed) {
+				t.Errorf("schemas/%s is out of date, run `go run . --json-schema schemas` in mmv1", tc.file)
+			}
+		})
+	}
+}
+
+func TestResourceSchema(t *testing.T) {
+	t.Parallel()
+
+	s, err := ResourceSchema("../api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Ref != "#/definitions/api.Resource" {
+		t.Fatalf("unexpected root $ref %q", s.Ref)
+	}
+
+	cases := []struct {
+		description  string
+		definition   string
+		property     string
+		expectedType string
+		expectedEnum []string
+		expectedDesc string
+	}{
+		{
+			description:  "property types are an enum",
+			definition:   "api.Type",
+			property:     "type",
+			expectedType: "string",
+			expectedEnum: api.PropertyTypes,
+		},
+		{
+			description:  "verbs are an enum",
+			definition:   "api.Resource",
+			property:     "update_verb",
+			expectedType: "string",
+			expectedEnum: api.UpdateVerbs,
+		},
+		{
+			description:  "untagged fields use the lowercased field name",
+			definition:   "api.Async",
+			property:     "type",
+			expectedType: "string",
+			expectedEnum: api.AsyncTypes,
+			expectedDesc: `Describes an operation, one of "OpAsync", "PollAsync"`,
+		},
+		{
+			description:  "inlined fields belong to the embedding struct",
+			definition:   "api.Async",
+			property:     "target_occurrences",
+			expectedType: "integer",
+			expectedDesc: "Number of times the desired state has to occur continuously\nduring polling before returning a success",
+		},
+		{
+			description:  "fields of other packages",
+			definition:   "resource.Examples",
+			property:     "vars",
+			expectedType: "object",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			definition, ok := s.Definitions[tc.definition]
+			if !ok {
+				t.Fatalf("missing definition %s", tc.definition)
+			}
+			property, ok := definition.Properties[tc.property]
+			if !ok {
+				t.Fatalf("missing property %s in %s", tc.property, tc.definition)
+			}
+			if property.Type != tc.expectedType {
+				t.Errorf("expected type %q, got %q", tc.expectedType, property.Type)
+			}
+			if !reflect.DeepEqual(property.Enum, tc.expectedEnum) {
+				t.Errorf("expected enum %v, got %v", tc.expectedEnum, property.Enum)
+			}
+			if tc.expectedDesc != "" && property.Description != tc.expectedDesc {
+				t.Errorf("expected description %q, got %q", tc.expectedDesc, property.Description)
+			}
+		})
+	}
+
+	for _, property := range []string{"source_yaml_file", "sourceyamlfile", "resource_metadata", "parent_metadata"} {
+		for name, definition := range s.Definitions {
+			if _, ok := definition.Properties[property]; ok {
+				t.Errorf("unexpected property %s in %s", property, name)
+			}
+		}
+	}
+	if len(s.Definitions["api.Type"].AllOf) == 0 {
+		t.Errorf("expected api.Type to require fields based on its type")
+	}
+}
@@ -0,0 +1,127 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Lints product and resource definitions for problems that don't stop
+// generation, but that reviewers would otherwise have to catch by hand.
+
+package lint
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// A check run over every resource. Findings are reported with the same paths
+// as Resource.Validate, so they can be located in the resource YAML file.
+type Rule struct {
+	// A unique, kebab-case ID, used to suppress the rule with `lint_ignore`
+	ID string
+
+	Description string
+
+	Check func(r *api.Resource) google.ValidationErrors
+}
+
+// A problem reported by a rule for a resource
+type Finding struct {
+	RuleID string
+
+	google.ValidationError
+}
+
+func (f Finding) String() string {
+	return fmt.Sprintf("%s [%s]", f.ValidationError.Error(), f.RuleID)
+}
+
+// Runs the rules over every resource of the products that is generated,
+// skipping the rules a resource suppresses with `lint_ignore`. The findings
+// are sorted by file, line and rule.
+func Run(products []*api.Product, rules []Rule) []Finding {
+	var findings []Finding
+	for _, p := range products {
+		for _, r := range p.Objects {
+			if r.Exclude {
+				continue
+			}
+			findings = append(findings, RunResource(r, rules)...)
+		}
+	}
+	sortFindings(findings)
+	return findings
+}
+
+// Runs the rules over a single resource, skipping the rules it suppresses
+// with `lint_ignore`. Suppressing a rule that doesn't exist is reported as a
+// finding of its own.
+func RunResource(r *api.Resource, rules []Rule) []Finding {
+	var findings []Finding
+
+	for _, id := range r.LintIgnore {
+		if !slices.ContainsFunc(rules, func(rule Rule) bool { return rule.ID == id }) {
+			findings = append(findings, newFindings(r, "lint-ignore", unknownRule(id))...)
+		}
+	}
+
+	for _, rule := range rules {
+		if slices.Contains(r.LintIgnore, rule.ID) {
+			continue
+		}
+		findings = append(findings, newFindings(r, rule.ID, rule.Check(r))...)
+	}
+	return findings
+}
+
+func unknownRule(id string) google.ValidationErrors {
+	var errs google.ValidationErrors
+	errs.Add("lint_ignore", "Unknown lint rule %s", id)
+	return errs
+}
+
+func newFindings(r *api.Resource, ruleID string, errs google.ValidationErrors) []Finding {
+	if r.SourceYamlFile != "" {
+		errs.Locate(r.SourceYamlFile)
+	}
+
+	var findings []Finding
+	for _, e := range errs {
+		findings = append(findings, Finding{RuleID: ruleID, ValidationError: *e})
+	}
+	return findings
+}
+
+func sortFindings(findings []Finding) {
+	sort.SliceStable(findings, func(i, j int) bool {
+		a, b := findings[i], findings[j]
+		if a.File != b.File {
+			return a.File < b.File
+		}
+		if a.Line != b.Line {
+			return a.Line < b.Line
+		}
+		return a.RuleID < b.RuleID
+	})
+}
+
+// Returns a human readable report of all findings, one per line.
+func Report(findings []Finding) string {
+	lines := []string{fmt.Sprintf("Found %d lint finding(s):", len(findings))}
+	for _, f := range findings {
+		lines = append(lines, f.String())
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,174 @@
+package lint
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/resource"
+)
+
+// Returns a resource that no rule reports findings for, changed by modify
+func newResource(modify func(r *api.Resource)) *api.Resource {
+	r := &api.Resource{
+		Name:       "Topic",
+		UpdateVerb: "PATCH",
+		References: resource.ReferenceLinks{Api: "https://cloud.google.com/pubsub/docs/reference/rest/v1/projects.topics"},
+		Examples:   []resource.Examples{{Name: "pubsub_topic_basic"}},
+		Parameters: []*api.Type{
+			{Name: "project", Type: "String", Description: "The project."},
+		},
+		Properties: []*api.Type{
+			{Name: "name", Type: "String", Description: "The name."},
+		},
+	}
+	if modify != nil {
+		modify(r)
+	}
+	return r
+}
+
+func TestRunResource(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		resource    *api.Resource
+		expected    []string
+	}{
+		{
+			description: "no findings",
+			resource:    newResource(nil),
+		},
+		{
+			description: "missing examples",
+			resource: newResource(func(r *api.Resource) {
+				r.Examples = nil
+			}),
+			expected: []string{"examples: Missing `examples` for resource Topic [missing-examples]"},
+		},
+		{
+			description: "missing api reference",
+			resource: newResource(func(r *api.Resource) {
+				r.References.Api = ""
+			}),
+			expected: []string{"references: Missing `references.api` for resource Topic [missing-api-reference]"},
+		},
+		{
+			description: "missing nested description",
+			resource: newResource(func(r *api.Resource) {
+				r.Properties = append(r.Properties, &api.Type{
+					Name:        "schemaSettings",
+					Type:        "Array",
+					Description: "Settings.",
+					ItemType: &api.Type{
+						Type: "NestedObject",
+						Properties: []*api.Type{
+							{Name: "schema", Type: "String"},
+						},
+					},
+				})
+			}),
+			expected: []string{"properties.schemaSettings.item_type.properties.schema: Missing `description` for field schema [missing-description]"},
+		},
+		{
+			description: "sensitive without ignore_read",
+			resource: newResource(func(r *api.Resource) {
+				r.Properties[0].Sensitive = true
+			}),
+			expected: []string{"properties.name.sensitive: Sensitive field name should set `ignore_read`, or suppress this rule if the API returns it [sensitive-without-ignore-read]"},
+		},
+		{
+			description: "sensitive with ignore_read",
+			resource: newResource(func(r *api.Resource) {
+				r.Properties[0].Sensitive = true
+				r.Properties[0].IgnoreRead = true
+			}),
+		},
+		{
+			description: "update mask without patch",
+			resource: newResource(func(r *api.Resource) {
+				r.UpdateMask = true
+				r.UpdateVerb = "PUT"
+			}),
+			expected: []string{"update_mask: `update_mask` is set, but resource Topic updates with PUT instead of PATCH [update-mask-without-patch]"},
+		},
+		{
+			description: "identity missing",
+			resource: newResource(func(r *api.Resource) {
+				r.Identity = []string{"name", "topic"}
+			}),
+			expected: []string{"identity: Identity topic is not a parameter or property of resource Topic [identity-missing-parameter]"},
+		},
+		{
+			description: "identity at a later version",
+			resource: newResource(func(r *api.Resource) {
+				r.Identity = []string{"name"}
+				r.Properties[0].MinVersion = "beta"
+			}),
+			expected: []string{"identity: Identity name is only available at version beta, but resource Topic is available at ga [identity-missing-parameter]"},
+		},
+		{
+			description: "enum with undocumented values",
+			resource: newResource(func(r *api.Resource) {
+				r.Properties = append(r.Properties, &api.Type{
+					Name:              "state",
+					Type:              "Enum",
+					Description:       "The state, ACTIVE when the topic can be used.",
+					EnumValues:        []string{"ACTIVE", "INGESTION_RESOURCE_ERROR"},
+					ExcludeDocsValues: true,
+				})
+			}),
+			expected: []string{"properties.state.exclude_docs_values: Field state excludes its values from the docs, but its description doesn't mention INGESTION_RESOURCE_ERROR [enum-undocumented-values]"},
+		},
+		{
+			description: "suppressed rule",
+			resource: newResource(func(r *api.Resource) {
+				r.Examples = nil
+				r.LintIgnore = []string{"missing-examples"}
+			}),
+		},
+		{
+			description: "unknown suppressed rule",
+			resource: newResource(func(r *api.Resource) {
+				r.LintIgnore = []string{"missing-exmaples"}
+			}),
+			expected: []string{"lint_ignore: Unknown lint rule missing-exmaples [lint-ignore]"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+			for _, f := range RunResource(tc.resource, Rules) {
+				got = append(got, f.String())
+			}
+			if !reflect.DeepEqual(got, tc.expected) {
+				t.Errorf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestRunSkipsExcludedResources(t *testing.T) {
+	t.Parallel()
+
+	products := []*api.Product{
+		{
+			Name: "PubSub",
+			Objects: []*api.Resource{
+				newResource(func(r *api.Resource) {
+					r.Exclude = true
+					r.Examples = nil
+				}),
+			},
+		},
+	}
+	if findings := Run(products, Rules); len(findings) != 0 {
+		t.Errorf("expected no findings, got %v", findings)
+	}
+}
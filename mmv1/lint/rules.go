@@ -0,0 +1,179 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lint
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// The rules run by default. New rules are added here.
+var Rules = []Rule{
+	{
+		ID:          "missing-examples",
+		Description: "Resources should have examples, which back their documentation and tests",
+		Check:       missingExamples,
+	},
+	{
+		ID:          "missing-api-reference",
+		Description: "Resources should link to their REST API reference in `references.api`",
+		Check:       missingApiReference,
+	},
+	{
+		ID:          "missing-description",
+		Description: "Properties and parameters should have a description",
+		Check:       missingDescription,
+	},
+	{
+		ID:          "sensitive-without-ignore-read",
+		Description: "Sensitive fields are usually not returned by the API, and should set `ignore_read`",
+		Check:       sensitiveWithoutIgnoreRead,
+	},
+	{
+		ID:          "update-mask-without-patch",
+		Description: "Resources using `update_mask` should update with PATCH",
+		Check:       updateMaskWithoutPatch,
+	},
+	{
+		ID:          "identity-missing-parameter",
+		Description: "Values of `identity` should be parameters or properties available wherever the resource is",
+		Check:       identityMissingParameter,
+	},
+	{
+		ID:          "enum-undocumented-values",
+		Description: "Enums with `exclude_docs_values` should document every value in their description",
+		Check:       enumUndocumentedValues,
+	},
+}
+
+func missingExamples(r *api.Resource) google.ValidationErrors {
+	var errs google.ValidationErrors
+	if len(r.Examples) == 0 {
+		errs.Add("examples", "Missing `examples` for resource %s", r.Name)
+	}
+	return errs
+}
+
+func missingApiReference(r *api.Resource) google.ValidationErrors {
+	var errs google.ValidationErrors
+	if r.References.Api == "" {
+		errs.Add("references", "Missing `references.api` for resource %s", r.Name)
+	}
+	return errs
+}
+
+func missingDescription(r *api.Resource) google.ValidationErrors {
+	var errs google.ValidationErrors
+	walkFields(r, func(path string, p *api.Type) {
+		if strings.TrimSpace(p.Description) == "" {
+			errs.Add(path, "Missing `description` for field %s", p.Name)
+		}
+	})
+	return errs
+}
+
+func sensitiveWithoutIgnoreRead(r *api.Resource) google.ValidationErrors {
+	var errs google.ValidationErrors
+	walkFields(r, func(path string, p *api.Type) {
+		if p.Sensitive && !p.IgnoreRead && !p.Output {
+			errs.Add(google.JoinValidationPath(path, "sensitive"), "Sensitive field %s should set `ignore_read`, or suppress this rule if the API returns it", p.Name)
+		}
+	})
+	return errs
+}
+
+func updateMaskWithoutPatch(r *api.Resource) google.ValidationErrors {
+	var errs google.ValidationErrors
+	if r.UpdateMask && r.UpdateVerb != "PATCH" {
+		errs.Add("update_mask", "`update_mask` is set, but resource %s updates with %s instead of PATCH", r.Name, r.UpdateVerb)
+	}
+	return errs
+}
+
+func identityMissingParameter(r *api.Resource) google.ValidationErrors {
+	var errs google.ValidationErrors
+	fields := append(slices.Clone(r.Parameters), r.Properties...)
+	for _, i := range r.Identity {
+		index := slices.IndexFunc(fields, func(p *api.Type) bool {
+			return p.Name == i
+		})
+		if index == -1 {
+			errs.Add("identity", "Identity %s is not a parameter or property of resource %s", i, r.Name)
+			continue
+		}
+		if p := fields[index]; versionIndex(p.MinVersion) > versionIndex(r.MinVersion) {
+			errs.Add("identity", "Identity %s is only available at version %s, but resource %s is available at %s", i, p.MinVersion, r.Name, versionName(r.MinVersion))
+		}
+	}
+	return errs
+}
+
+func enumUndocumentedValues(r *api.Resource) google.ValidationErrors {
+	var errs google.ValidationErrors
+	walkFields(r, func(path string, p *api.Type) {
+		enum := p
+		if p.IsA("Array") && p.ItemType != nil {
+			enum = p.ItemType
+		}
+		if !enum.IsA("Enum") || !enum.ExcludeDocsValues {
+			return
+		}
+		for _, v := range enum.EnumValues {
+			if !strings.Contains(p.Description, v) {
+				errs.Add(google.JoinValidationPath(path, "exclude_docs_values"), "Field %s excludes its values from the docs, but its description doesn't mention %s", p.Name, v)
+			}
+		}
+	})
+	return errs
+}
+
+// Calls fn for every parameter and property of the resource, including
+// nested ones, with their path within the resource YAML file.
+func walkFields(r *api.Resource, fn func(path string, p *api.Type)) {
+	walkProperties("parameters", r.Parameters, fn)
+	walkProperties("properties", r.Properties, fn)
+}
+
+func walkProperties(prefix string, props []*api.Type, fn func(path string, p *api.Type)) {
+	for _, p := range props {
+		path := google.JoinValidationPath(prefix, p.Name)
+		fn(path, p)
+
+		switch {
+		case p.IsA("NestedObject"):
+			walkProperties(google.JoinValidationPath(path, "properties"), p.Properties, fn)
+		case p.IsA("Array") && p.ItemType != nil && p.ItemType.IsA("NestedObject"):
+			walkProperties(google.JoinValidationPath(path, "item_type", "properties"), p.ItemType.Properties, fn)
+		case p.IsA("Map") && p.ValueType != nil:
+			walkProperties(google.JoinValidationPath(path, "value_type", "properties"), p.ValueType.Properties, fn)
+		}
+	}
+}
+
+// Returns the index of a version in product.ORDER, where versions that
+// aren't set are ga
+func versionIndex(name string) int {
+	return slices.Index(product.ORDER, versionName(name))
+}
+
+func versionName(name string) string {
+	if name == "" {
+		return "ga"
+	}
+	return name
+}
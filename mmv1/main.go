@@ -17,6 +17,10 @@ import (
 	"golang.org/x/exp/slices"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/example_check"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/json_schema"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/lint"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/openapi_generate"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/provider"
 )
@@ -45,22 +49,42 @@ var forceProvider = flag.String("provider", "", "optional provider name. If spec
 
 var openapiGenerate = flag.Bool("openapi-generate", false, "Generate MMv1 YAML from openapi directory (Experimental)")
 
+var openapiMerge = flag.Bool("openapi-merge", false, "When used with --openapi-generate, merge new fields into existing resource YAML instead of overwriting it")
+
 // Example usage: --yaml
 var yamlMode = flag.Bool("yaml", false, "copy text over from ruby yaml to go yaml")
 
 var showImportDiffs = flag.Bool("show-import-diffs", false, "write go import diffs to stdout")
 
+var cacheDir = flag.String("cache-dir", "", "optional directory to store the generation cache in. If specified, files whose inputs haven't changed since the previous run are not generated again.")
+
+var doNotCheckExamples = flag.Bool("no-example-check", false, "do not check the Terraform configs of resource examples against the schemas of the resources")
+
+var validateOnly = flag.Bool("validate-only", false, "validate product and resource YAML, report every problem found and exit without writing any files")
+
+var lintOnly = flag.Bool("lint", false, "run lint rules over product and resource YAML, report every finding and exit without writing any files")
+
+var jsonSchema = flag.String("json-schema", "", "write JSON Schemas for product and resource YAML to the given directory and exit")
+
 func main() {
 
 	flag.Parse()
 
+	if *jsonSchema != "" {
+		if err := json_schema.Write("api", *jsonSchema); err != nil {
+			log.Fatalf("error writing JSON Schemas: %v", err)
+		}
+		return
+	}
+
 	if *openapiGenerate {
 		parser := openapi_generate.NewOpenapiParser("openapi_generate/openapi", "products")
+		parser.Merge = *openapiMerge
 		parser.Run()
 		return
 	}
 
-	if outputPath == nil || *outputPath == "" {
+	if !*validateOnly && !*lintOnly && (outputPath == nil || *outputPath == "") {
 		log.Printf("No output path specified, exiting")
 		return
 	}
@@ -132,6 +156,52 @@ func main() {
 	}
 
 	startTime := time.Now()
+
+	// Every product is loaded and validated before anything is generated, so
+	// that all problems can be reported at once
+	productsForVersion, validationErrors := LoadProducts(allProductFiles, *overrideDirectory)
+	if len(validationErrors) == 0 && !*doNotCheckExamples {
+		handwrittenTypes, err := example_check.HandwrittenResourceTypes("third_party/terraform/provider/provider_mmv1_resources.go.tmpl")
+		if err != nil {
+			log.Fatalf("Failed to load the handwritten resources to check examples against: %s", err)
+		}
+		// Examples are checked against every product, as they often use
+		// resources from other products
+		validationErrors = example_check.Run(productsForVersion, handwrittenTypes)
+	}
+	if len(validationErrors) > 0 {
+		validationErrors.Sort()
+		fmt.Fprintln(os.Stderr, validationErrors.Report())
+		os.Exit(1)
+	}
+
+	if *validateOnly {
+		log.Printf("Validated %d products, no problems found", len(productsForVersion))
+		return
+	}
+
+	if *lintOnly {
+		var productsToLint []*api.Product
+		for _, productApi := range productsForVersion {
+			if !slices.Contains(productsToGenerate, productApi.SourceDirectory) {
+				continue
+			}
+			if *resourceToGenerate != "" {
+				productApi.Objects = slices.DeleteFunc(productApi.Objects, func(r *api.Resource) bool {
+					return r.Name != *resourceToGenerate
+				})
+			}
+			productsToLint = append(productsToLint, productApi)
+		}
+		findings := lint.Run(productsToLint, lint.Rules)
+		if len(findings) > 0 {
+			fmt.Fprintln(os.Stderr, lint.Report(findings))
+			os.Exit(1)
+		}
+		log.Printf("Linted %d products, no findings", len(productsToLint))
+		return
+	}
+
 	providerName := "default (terraform)"
 	if *forceProvider != "" {
 		providerName = *forceProvider
@@ -140,46 +210,29 @@ func main() {
 	log.Printf("Building %s version", *version)
 	log.Printf("Building %s provider", providerName)
 
+	if *cacheDir != "" {
+		if err := provider.EnableGenerationCache(*cacheDir, *outputPath, *version, providerName); err != nil {
+			log.Fatalf("Error loading generation cache: %s", err)
+		}
+	}
+
 	// Building compute takes a long time and can't be parallelized within the product
 	// so lets build it first
-	sort.Slice(allProductFiles, func(i int, j int) bool {
-		if allProductFiles[i] == "products/compute" {
-			return true
-		}
-		return false
+	productsByBuildOrder := slices.Clone(productsForVersion)
+	sort.SliceStable(productsByBuildOrder, func(i int, j int) bool {
+		return productsByBuildOrder[i].SourceDirectory == "products/compute" && productsByBuildOrder[j].SourceDirectory != "products/compute"
 	})
 
-	var providerToGenerate provider.Provider
-
-	productFileChannel := make(chan string, len(allProductFiles))
-	productsForVersionChannel := make(chan *api.Product, len(allProductFiles))
-	for _, pf := range allProductFiles {
-		productFileChannel <- pf
-	}
-
-	for i := 0; i < len(allProductFiles); i++ {
+	for _, productApi := range productsByBuildOrder {
 		wg.Add(1)
-		go GenerateProduct(productFileChannel, providerToGenerate, productsForVersionChannel, startTime, productsToGenerate, *resourceToGenerate, *overrideDirectory, generateCode, generateDocs)
+		go GenerateProduct(productApi, startTime, productsToGenerate, *resourceToGenerate, generateCode, generateDocs)
 	}
 	wg.Wait()
 
-	close(productFileChannel)
-	close(productsForVersionChannel)
-
-	var productsForVersion []*api.Product
-
-	for p := range productsForVersionChannel {
-		productsForVersion = append(productsForVersion, p)
-	}
-
-	slices.SortFunc(productsForVersion, func(p1, p2 *api.Product) int {
-		return strings.Compare(strings.ToLower(p1.Name), strings.ToLower(p2.Name))
-	})
-
 	// In order to only copy/compile files once per provider this must be called outside
 	// of the products loop. This will get called with the provider from the final iteration
 	// of the loop
-	providerToGenerate = setProvider(*forceProvider, *version, productsForVersion[0], startTime)
+	providerToGenerate := setProvider(*forceProvider, *version, productsForVersion[0], startTime)
 	providerToGenerate.CopyCommonFiles(*outputPath, generateCode, generateDocs)
 
 	if generateCode {
@@ -187,12 +240,43 @@ func main() {
 	}
 
 	provider.FixImports(*outputPath, *showImportDiffs)
+	provider.SaveGenerationCache()
 }
 
-func GenerateProduct(productChannel chan string, providerToGenerate provider.Provider, productsForVersionChannel chan *api.Product, startTime time.Time, productsToGenerate []string, resourceToGenerate, overrideDirectory string, generateCode, generateDocs bool) {
+// Loads and validates every product in parallel. Products that don't exist at
+// the requested version are skipped. The returned products are sorted by name.
+func LoadProducts(productFiles []string, overrideDirectory string) ([]*api.Product, google.ValidationErrors) {
+	var mu sync.Mutex
+	var products []*api.Product
+	var errs google.ValidationErrors
 
-	defer wg.Done()
-	productName := <-productChannel
+	for _, pf := range productFiles {
+		wg.Add(1)
+		go func(productName string) {
+			defer wg.Done()
+			productApi, productErrs := LoadProduct(productName, overrideDirectory)
+
+			mu.Lock()
+			defer mu.Unlock()
+			errs = append(errs, productErrs...)
+			if productApi != nil {
+				products = append(products, productApi)
+			}
+		}(pf)
+	}
+	wg.Wait()
+
+	slices.SortFunc(products, func(p1, p2 *api.Product) int {
+		return strings.Compare(strings.ToLower(p1.Name), strings.ToLower(p2.Name))
+	})
+
+	return products, errs
+}
+
+// Loads a product and its resources, merging in overrides, and validates
+// them. Returns a nil product if it does not exist at the requested version.
+func LoadProduct(productName, overrideDirectory string) (*api.Product, google.ValidationErrors) {
+	var errs google.ValidationErrors
 
 	productYamlPath := path.Join(productName, "product.yaml")
 
@@ -208,30 +292,46 @@ func GenerateProduct(productChannel chan string, providerToGenerate provider.Pro
 	overrideProductExists := !errors.Is(overrideProductErr, os.ErrNotExist)
 
 	if !(baseProductExists || overrideProductExists) {
-		log.Fatalf("%s does not contain a product.yaml file", productName)
+		errs.Add("", "%s does not contain a product.yaml file", productName)
+		errs.Locate(productYamlPath)
+		return nil, errs
 	}
 
 	productApi := &api.Product{}
 
+	var productErrs google.ValidationErrors
+	productFile := productYamlPath
 	if overrideProductExists {
 		if baseProductExists {
-			api.Compile(productYamlPath, productApi, overrideDirectory)
+			productErrs = api.Compile(productYamlPath, productApi, overrideDirectory)
 			overrideApiProduct := &api.Product{}
-			api.Compile(productOverridePath, overrideApiProduct, overrideDirectory)
+			productErrs = append(productErrs, api.Compile(productOverridePath, overrideApiProduct, overrideDirectory)...)
 
 			api.Merge(reflect.ValueOf(productApi), reflect.ValueOf(*overrideApiProduct))
 		} else {
-			api.Compile(productOverridePath, productApi, overrideDirectory)
+			productFile = productOverridePath
+			productErrs = api.Compile(productOverridePath, productApi, overrideDirectory)
 		}
 	} else {
-		api.Compile(productYamlPath, productApi, overrideDirectory)
+		productErrs = api.Compile(productYamlPath, productApi, overrideDirectory)
+	}
+
+	// Problems decoding a file are reported on their own, as the rest of
+	// the validation would be working with a partial object
+	if len(productErrs) > 0 {
+		return nil, productErrs
+	}
+
+	if productErrs = productApi.Validate(); len(productErrs) > 0 {
+		productErrs.Locate(productFile)
+		return nil, productErrs
 	}
 
 	var resources []*api.Resource = make([]*api.Resource, 0)
 
 	if !productApi.ExistsAtVersionOrLower(*version) {
 		log.Printf("%s does not have a '%s' version, skipping", productName, *version)
-		return
+		return nil, nil
 	}
 
 	resourceFiles, err := filepath.Glob(fmt.Sprintf("%s/*", productName))
@@ -255,13 +355,18 @@ func GenerateProduct(productChannel chan string, providerToGenerate provider.Pro
 		}
 
 		resource := &api.Resource{}
-		api.Compile(resourceYamlPath, resource, overrideDirectory)
+		if resourceErrs := api.Compile(resourceYamlPath, resource, overrideDirectory); len(resourceErrs) > 0 {
+			errs = append(errs, resourceErrs...)
+			continue
+		}
 		resource.SourceYamlFile = resourceYamlPath
 
 		resource.TargetVersionName = *version
 		resource.Properties = resource.AddLabelsRelatedFields(resource.PropertiesWithExcluded(), nil)
 		resource.SetDefault(productApi)
-		resource.Validate()
+		resourceErrs := resource.Validate()
+		resourceErrs.Locate(resourceYamlPath)
+		errs = append(errs, resourceErrs...)
 		resources = append(resources, resource)
 	}
 
@@ -279,22 +384,31 @@ func GenerateProduct(productChannel chan string, providerToGenerate provider.Pro
 
 			resource := &api.Resource{}
 
+			var resourceErrs google.ValidationErrors
+			resourceFile := overrideYamlPath
 			baseResourcePath := filepath.Join(productName, filepath.Base(overrideYamlPath))
 			_, baseResourceErr := os.Stat(baseResourcePath)
 			baseResourceExists := !errors.Is(baseResourceErr, os.ErrNotExist)
 			if baseResourceExists {
-				api.Compile(baseResourcePath, resource, overrideDirectory)
+				resourceFile = baseResourcePath
+				resourceErrs = api.Compile(baseResourcePath, resource, overrideDirectory)
 				overrideResource := &api.Resource{}
-				api.Compile(overrideYamlPath, overrideResource, overrideDirectory)
+				resourceErrs = append(resourceErrs, api.Compile(overrideYamlPath, overrideResource, overrideDirectory)...)
 				api.Merge(reflect.ValueOf(resource), reflect.ValueOf(*overrideResource))
 			} else {
-				api.Compile(overrideYamlPath, resource, overrideDirectory)
+				resourceErrs = api.Compile(overrideYamlPath, resource, overrideDirectory)
+			}
+			if len(resourceErrs) > 0 {
+				errs = append(errs, resourceErrs...)
+				continue
 			}
 
 			resource.TargetVersionName = *version
 			resource.Properties = resource.AddLabelsRelatedFields(resource.PropertiesWithExcluded(), nil)
 			resource.SetDefault(productApi)
-			resource.Validate()
+			resourceErrs = resource.Validate()
+			resourceErrs.Locate(resourceFile)
+			errs = append(errs, resourceErrs...)
 			resources = append(resources, resource)
 		}
 
@@ -306,11 +420,17 @@ func GenerateProduct(productChannel chan string, providerToGenerate provider.Pro
 	}
 
 	productApi.Objects = resources
-	productApi.Validate()
+	productApi.SourceDirectory = productName
 
-	providerToGenerate = setProvider(*forceProvider, *version, productApi, startTime)
+	return productApi, errs
+}
+
+func GenerateProduct(productApi *api.Product, startTime time.Time, productsToGenerate []string, resourceToGenerate string, generateCode, generateDocs bool) {
+
+	defer wg.Done()
+	productName := productApi.SourceDirectory
 
-	productsForVersionChannel <- productApi
+	providerToGenerate := setProvider(*forceProvider, *version, productApi, startTime)
 
 	if !slices.Contains(productsToGenerate, productName) {
 		log.Printf("%s not specified, skipping generation", productName)
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"flag"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/provider"
+)
+
+var updateGolden = flag.Bool("update", false, "update the golden files of generated code")
+
+func TestLoadProduct_MissingProductYaml(t *testing.T) {
+	t.Parallel()
+
+	productName := filepath.Join(t.TempDir(), "products", "widget")
+
+	product, errs := LoadProduct(productName, "")
+	if product != nil {
+		t.Errorf("expected no product, got %s", product.Name)
+	}
+	if len(errs) != 1 {
+		t.Fatalf("expected 1 error, got %d: %v", len(errs), errs)
+	}
+	if got, want := errs[0].File, filepath.Join(productName, "product.yaml"); got != want {
+		t.Errorf("expected the error in file %q, got %q", want, got)
+	}
+}
+
+// Compares the code generated for the framework resource in
+// testdata/framework_resource with its golden files. Run with -update to
+// regenerate them after changing the framework templates.
+func TestGenerateFrameworkResource(t *testing.T) {
+	resource := loadTestResource(t, filepath.Join("testdata", "framework_resource", "pubsub"))
+	templateData := provider.NewTemplateData(t.TempDir(), provider.GA_VERSION)
+
+	checkGolden(t, "framework_resource", "resource_pubsub_widget.go", func(path string) {
+		templateData.GenerateFrameworkResourceFile(path, resource)
+	})
+	checkGolden(t, "framework_resource", "pubsub_widget_model.go", func(path string) {
+		templateData.GenerateFrameworkModelFile(path, resource)
+	})
+}
+
+// Compares the code generated for the custom methods of the resource in
+// testdata/custom_methods with its golden file, and checks that each method is
+// called by the CRUD function of its phase.
+func TestGenerateCustomMethods(t *testing.T) {
+	resource := loadTestResource(t, filepath.Join("testdata", "custom_methods", "pubsub"))
+	templateData := provider.NewTemplateData(t.TempDir(), provider.GA_VERSION)
+
+	got := checkGolden(t, "custom_methods", "resource_pubsub_gadget.go", func(path string) {
+		templateData.GenerateResourceFile(path, resource)
+	})
+
+	file, err := parser.ParseFile(token.NewFileSet(), "resource_pubsub_gadget.go", got, 0)
+	if err != nil {
+		t.Fatalf("error parsing the generated resource: %v", err)
+	}
+	calls := make(map[string][]string)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		ast.Inspect(fn, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if ident, ok := call.Fun.(*ast.Ident); ok && strings.HasSuffix(ident.Name, "Method") {
+					calls[ident.Name] = append(calls[ident.Name], fn.Name.Name)
+				}
+			}
+			return true
+		})
+	}
+
+	want := map[string][]string{
+		"resourcePubsubGadgetStartMethod": {"resourcePubsubGadgetCreate"},
+		"resourcePubsubGadgetStopMethod":  {"resourcePubsubGadgetUpdate"},
+		"resourcePubsubGadgetDrainMethod": {"resourcePubsubGadgetDelete"},
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected the custom methods to be called by %v, got %v", want, calls)
+	}
+}
+
+// Compares the code generated for the resource with typed API structs in
+// testdata/api_types with its golden files, and checks that its CRUD code
+// sends and reads the typed API object.
+func TestGenerateApiTypes(t *testing.T) {
+	resource := loadTestResource(t, filepath.Join("testdata", "api_types", "pubsub"))
+	templateData := provider.NewTemplateData(t.TempDir(), provider.GA_VERSION)
+
+	checkGolden(t, "api_types", "resource_pubsub_sprocket_api_types.go", func(path string) {
+		templateData.GenerateApiTypesFile(path, resource)
+	})
+	got := checkGolden(t, "api_types", "resource_pubsub_sprocket.go", func(path string) {
+		templateData.GenerateResourceFile(path, resource)
+	})
+
+	file, err := parser.ParseFile(token.NewFileSet(), "resource_pubsub_sprocket.go", got, 0)
+	if err != nil {
+		t.Fatalf("error parsing the generated resource: %v", err)
+	}
+	calls := make(map[string][]string)
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		ast.Inspect(fn, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if ident, ok := call.Fun.(*ast.Ident); ok && (strings.HasPrefix(ident.Name, "Expand") || strings.HasPrefix(ident.Name, "Flatten")) {
+					calls[ident.Name] = append(calls[ident.Name], fn.Name.Name)
+				}
+			}
+			return true
+		})
+	}
+
+	want := map[string][]string{
+		"ExpandPubsubSprocketApiObject":          {"resourcePubsubSprocketCreate"},
+		"ExpandPubsubSprocketApiObjectForUpdate": {"resourcePubsubSprocketUpdate"},
+		"FlattenPubsubSprocketApiObject":         {"resourcePubsubSprocketRead"},
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("expected the typed API object to be used by %v, got %v", want, calls)
+	}
+	if strings.Contains(string(got), "obj := make(map[string]interface{})") {
+		t.Errorf("expected no untyped request body in the generated resource")
+	}
+}
+
+// Loads the only resource of the product in dir at the GA version.
+func TestGenerateBatching(t *testing.T) {
+	resource := loadTestResource(t, filepath.Join("testdata", "batching", "pubsub"))
+	templateData := provider.NewTemplateData(t.TempDir(), provider.GA_VERSION)
+
+	got := checkGolden(t, "batching", "resource_pubsub_doohickey.go", func(path string) {
+		templateData.GenerateResourceFile(path, resource)
+	})
+
+	file, err := parser.ParseFile(token.NewFileSet(), "resource_pubsub_doohickey.go", got, 0)
+	if err != nil {
+		t.Fatalf("error parsing the generated resource: %v", err)
+	}
+	var waits []string
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok {
+			continue
+		}
+		ast.Inspect(fn, func(n ast.Node) bool {
+			if call, ok := n.(*ast.CallExpr); ok {
+				if ident, ok := call.Fun.(*ast.Ident); ok && ident.Name == "PubsubOperationWaitTimeContext" {
+					waits = append(waits, fn.Name.Name)
+				}
+			}
+			return true
+		})
+	}
+
+	// The batch waits for its operation, so create and delete don't wait for
+	// it again
+	want := []string{"resourcePubsubDoohickeySendBatchedRequest"}
+	if !reflect.DeepEqual(waits, want) {
+		t.Errorf("expected the operation to be waited for by %v, got %v", want, waits)
+	}
+}
+
+func loadTestResource(t *testing.T, dir string) api.Resource {
+	previousVersion := *version
+	*version = provider.GA_VERSION
+	t.Cleanup(func() { *version = previousVersion })
+
+	product, errs := LoadProduct(dir, "")
+	if len(errs) > 0 {
+		t.Fatalf("unexpected errors loading the product: %v", errs)
+	}
+	if len(product.Objects) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(product.Objects))
+	}
+	resource := *product.Objects[0]
+	resource.ImportPath = provider.ImportPathFromVersion(provider.GA_VERSION)
+	return resource
+}
+
+// Generates a file with generate and compares it with its golden file in
+// testdata/<testdata>/golden, which is written instead with -update. Returns
+// the generated file.
+func checkGolden(t *testing.T, testdata, name string, generate func(path string)) []byte {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), name)
+	generate(path)
+	got, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", testdata, "golden", name+".golden")
+	if *updateGolden {
+		if err := os.MkdirAll(filepath.Dir(golden), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(golden, got, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(got) != string(want) {
+		t.Errorf("generated %s doesn't match %s, run the test with -update to regenerate it", name, golden)
+	}
+	return got
+}
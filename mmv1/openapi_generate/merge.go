@@ -0,0 +1,249 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openapi_generate
+
+import (
+	"log"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"gopkg.in/yaml.v2"
+	yamlv3 "gopkg.in/yaml.v3"
+)
+
+// The comment added above properties that no longer exist in the OpenAPI spec
+const removedFieldComment = "# This field was not found in the OpenAPI spec. Review whether it was removed from the API."
+
+// The changes made while merging a generated resource into an existing one
+type mergeReport struct {
+	// Paths of the properties added to the existing resource
+	Added []string
+
+	// Paths of the properties that were marked for review as they are no
+	// longer in the spec
+	Removed []string
+
+	// Paths of nested properties that could not be merged automatically
+	Skipped []string
+}
+
+// A block of lines to insert before a line of the existing file
+type lineInsertion struct {
+	// 0-based index of the line to insert before
+	before int
+	lines  []string
+}
+
+// Merges the properties of a resource generated from an OpenAPI spec into the
+// existing YAML definition of that resource.
+//
+// New properties are appended to the end of their properties list, with their
+// min_version set when the spec is not for the GA API. Properties that no
+// longer exist in the spec are kept and marked with a comment for review.
+// Every other line of the existing file is kept as is, so that hand-written
+// overrides such as custom_code, diff_suppress_func and examples are
+// preserved and the result can be reviewed as a patch.
+func mergeResource(existingPath string, generated api.Resource, versionName string) ([]byte, mergeReport) {
+	var report mergeReport
+
+	content, err := os.ReadFile(existingPath)
+	if err != nil {
+		log.Fatalf("error reading existing resource %s: %v", existingPath, err)
+	}
+
+	// Only the properties of the existing resource are decoded, as decoding
+	// examples renders their templates
+	var existing struct {
+		Properties []*api.Type
+	}
+	if err := yaml.Unmarshal(content, &existing); err != nil {
+		log.Fatalf("error parsing existing resource %s: %v", existingPath, err)
+	}
+
+	var doc yamlv3.Node
+	if err := yamlv3.Unmarshal(content, &doc); err != nil {
+		log.Fatalf("error parsing existing resource %s: %v", existingPath, err)
+	}
+
+	minVersion := ""
+	if versionName != "ga" {
+		minVersion = versionName
+	}
+
+	lines := strings.Split(string(content), "\n")
+	var insertions []lineInsertion
+	if len(doc.Content) > 0 {
+		properties := mappingValue(doc.Content[0], "properties")
+		insertions = mergeProperties(properties, existing.Properties, generated.Properties, "properties", minVersion, lines, &report)
+	}
+
+	return applyInsertions(lines, insertions), report
+}
+
+// Merges a list of generated properties into the sequence node holding the
+// existing ones, recursing into nested objects present in both.
+func mergeProperties(node *yamlv3.Node, existing, generated []*api.Type, path, minVersion string, lines []string, report *mergeReport) []lineInsertion {
+	var insertions []lineInsertion
+
+	var added []*api.Type
+	for _, g := range generated {
+		if findProperty(existing, g.Name) == nil {
+			added = append(added, g)
+		}
+	}
+
+	// The existing properties can only be edited in place when they are
+	// written as a block sequence matching the decoded properties
+	if node == nil || node.Kind != yamlv3.SequenceNode || node.Style&yamlv3.FlowStyle != 0 || len(node.Content) != len(existing) || len(node.Content) == 0 {
+		if len(added) > 0 {
+			report.Skipped = append(report.Skipped, path)
+		}
+		return nil
+	}
+
+	// The column of the dash of each entry
+	indent := node.Content[0].Column - 3
+
+	for i, e := range existing {
+		entry := node.Content[i]
+		propertyPath := google.JoinValidationPath(path, e.Name)
+
+		g := findProperty(generated, apiName(e))
+		if g == nil {
+			report.Removed = append(report.Removed, propertyPath)
+			if entry.Line < 2 || !strings.Contains(lines[entry.Line-2], removedFieldComment) {
+				insertions = append(insertions, lineInsertion{
+					before: entry.Line - 1,
+					lines:  []string{strings.Repeat(" ", indent) + removedFieldComment},
+				})
+			}
+			continue
+		}
+
+		// Properties nested in a field that already has the min version
+		// inherit it
+		nestedMinVersion := minVersion
+		if e.MinVersion == minVersion {
+			nestedMinVersion = ""
+		}
+
+		switch {
+		case e.Type == "NestedObject" && g.Type == "NestedObject":
+			insertions = append(insertions, mergeProperties(mappingValue(entry, "properties"), e.Properties, g.Properties, propertyPath, nestedMinVersion, lines, report)...)
+		case e.Type == "Array" && g.Type == "Array" && e.ItemType != nil && g.ItemType != nil && e.ItemType.Type == "NestedObject" && g.ItemType.Type == "NestedObject":
+			itemType := mappingValue(entry, "item_type")
+			if itemType != nil {
+				insertions = append(insertions, mergeProperties(mappingValue(itemType, "properties"), e.ItemType.Properties, g.ItemType.Properties, propertyPath, nestedMinVersion, lines, report)...)
+			}
+		}
+	}
+
+	if len(added) == 0 {
+		return insertions
+	}
+
+	var newLines []string
+	for _, a := range added {
+		report.Added = append(report.Added, google.JoinValidationPath(path, a.Name))
+		a.MinVersion = minVersion
+
+		bytes, err := yaml.Marshal([]*api.Type{a})
+		if err != nil {
+			log.Fatalf("error marshalling property %s: %v", a.Name, err)
+		}
+		for _, line := range strings.Split(strings.TrimSuffix(string(bytes), "\n"), "\n") {
+			newLines = append(newLines, strings.Repeat(" ", indent)+line)
+		}
+	}
+
+	insertions = append(insertions, lineInsertion{
+		before: endOfSequence(node, indent, lines),
+		lines:  newLines,
+	})
+	return insertions
+}
+
+// Returns the index of the line after the last content line of a block
+// sequence. Lines that are blank or indented deeper than the dashes of the
+// sequence belong to its last entry.
+func endOfSequence(node *yamlv3.Node, indent int, lines []string) int {
+	last := node.Content[len(node.Content)-1].Line - 1
+	end := last + 1
+	for i := last + 1; i < len(lines); i++ {
+		trimmed := strings.TrimLeft(lines[i], " ")
+		if trimmed == "" {
+			continue
+		}
+		if len(lines[i])-len(trimmed) <= indent {
+			break
+		}
+		end = i + 1
+	}
+	return end
+}
+
+// Inserts blocks of lines into the existing lines of a file
+func applyInsertions(lines []string, insertions []lineInsertion) []byte {
+	sort.SliceStable(insertions, func(i, j int) bool {
+		return insertions[i].before < insertions[j].before
+	})
+
+	var merged []string
+	next := 0
+	for i, line := range lines {
+		for next < len(insertions) && insertions[next].before == i {
+			merged = append(merged, insertions[next].lines...)
+			next++
+		}
+		merged = append(merged, line)
+	}
+	for ; next < len(insertions); next++ {
+		merged = append(merged, insertions[next].lines...)
+	}
+	return []byte(strings.Join(merged, "\n"))
+}
+
+// Returns the value of a key within a mapping node, or nil if it is not set
+func mappingValue(node *yamlv3.Node, key string) *yamlv3.Node {
+	if node == nil || node.Kind != yamlv3.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+func findProperty(properties []*api.Type, name string) *api.Type {
+	for _, p := range properties {
+		if apiName(p) == name {
+			return p
+		}
+	}
+	return nil
+}
+
+// Returns the name of a property within the API, which may differ from its
+// name in Terraform.
+func apiName(t *api.Type) string {
+	if t.ApiName != "" {
+		return t.ApiName
+	}
+	return t.Name
+}
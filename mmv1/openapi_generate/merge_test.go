@@ -0,0 +1,192 @@
+package openapi_generate
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+const existingResource = `# Copyright 2025 Google Inc.
+
+---
+name: 'Instance'
+description: |
+  An instance.
+base_url: 'projects/{{project}}/locations/{{location}}/instances'
+custom_code:
+  constants: 'templates/terraform/constants/instance.go.tmpl'
+properties:
+  - name: 'displayName'
+    type: String
+    description: |
+      The display name.
+    diff_suppress_func: 'tpgresource.CaseDiffSuppress'
+  - name: 'config'
+    type: NestedObject
+    description: The config.
+    properties:
+      - name: 'size'
+        type: Integer
+        description: The size.
+  - name: 'rules'
+    type: Array
+    description: The rules.
+    item_type:
+      type: NestedObject
+      properties:
+        - name: 'action'
+          type: String
+          description: The action.
+  - name: 'preview'
+    type: NestedObject
+    description: A beta field.
+    min_version: 'beta'
+    properties:
+      - name: 'enabled'
+        type: Boolean
+        description: Whether the preview is enabled.
+  - name: 'legacy'
+    type: String
+    description: A field removed from the API.
+examples:
+  - name: 'instance_basic'
+    primary_resource_id: 'example'
+`
+
+func TestMergeResource(t *testing.T) {
+	t.Parallel()
+
+	existingPath := filepath.Join(t.TempDir(), "Instance.yaml")
+	if err := os.WriteFile(existingPath, []byte(existingResource), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	generated := api.Resource{
+		Name: "Instance",
+		Properties: []*api.Type{
+			{Name: "displayName", Type: "String", Description: "The display name."},
+			{
+				Name:        "config",
+				Type:        "NestedObject",
+				Description: "The config.",
+				Properties: []*api.Type{
+					{Name: "size", Type: "Integer", Description: "The size."},
+					{Name: "tier", Type: "String", Description: "The tier."},
+				},
+			},
+			{
+				Name:        "rules",
+				Type:        "Array",
+				Description: "The rules.",
+				ItemType: &api.Type{
+					Type: "NestedObject",
+					Properties: []*api.Type{
+						{Name: "action", Type: "String", Description: "The action."},
+						{Name: "priority", Type: "Integer", Description: "The priority."},
+					},
+				},
+			},
+			{
+				Name:        "preview",
+				Type:        "NestedObject",
+				Description: "A beta field.",
+				Properties: []*api.Type{
+					{Name: "enabled", Type: "Boolean", Description: "Whether the preview is enabled."},
+					{Name: "channel", Type: "String", Description: "The channel."},
+				},
+			},
+			{Name: "labels", Type: "KeyValueLabels", Description: "Labels."},
+		},
+	}
+
+	merged, report := mergeResource(existingPath, generated, "beta")
+
+	expected := `# Copyright 2025 Google Inc.
+
+---
+name: 'Instance'
+description: |
+  An instance.
+base_url: 'projects/{{project}}/locations/{{location}}/instances'
+custom_code:
+  constants: 'templates/terraform/constants/instance.go.tmpl'
+properties:
+  - name: 'displayName'
+    type: String
+    description: |
+      The display name.
+    diff_suppress_func: 'tpgresource.CaseDiffSuppress'
+  - name: 'config'
+    type: NestedObject
+    description: The config.
+    properties:
+      - name: 'size'
+        type: Integer
+        description: The size.
+      - name: tier
+        type: String
+        description: The tier.
+        min_version: beta
+  - name: 'rules'
+    type: Array
+    description: The rules.
+    item_type:
+      type: NestedObject
+      properties:
+        - name: 'action'
+          type: String
+          description: The action.
+        - name: priority
+          type: Integer
+          description: The priority.
+          min_version: beta
+  - name: 'preview'
+    type: NestedObject
+    description: A beta field.
+    min_version: 'beta'
+    properties:
+      - name: 'enabled'
+        type: Boolean
+        description: Whether the preview is enabled.
+      - name: channel
+        type: String
+        description: The channel.
+  # This field was not found in the OpenAPI spec. Review whether it was removed from the API.
+  - name: 'legacy'
+    type: String
+    description: A field removed from the API.
+  - name: labels
+    type: KeyValueLabels
+    description: Labels.
+    min_version: beta
+examples:
+  - name: 'instance_basic'
+    primary_resource_id: 'example'
+`
+	if string(merged) != expected {
+		t.Errorf("expected merged resource:\n%s\nto be:\n%s", merged, expected)
+	}
+
+	expectedReport := mergeReport{
+		Added:   []string{"properties.config.tier", "properties.rules.priority", "properties.preview.channel", "properties.labels"},
+		Removed: []string{"properties.legacy"},
+	}
+	if !reflect.DeepEqual(report, expectedReport) {
+		t.Errorf("expected report %+v to be %+v", report, expectedReport)
+	}
+
+	// Merging the same spec again doesn't change the resource
+	if err := os.WriteFile(existingPath, merged, 0644); err != nil {
+		t.Fatal(err)
+	}
+	remerged, report := mergeResource(existingPath, generated, "beta")
+	if string(remerged) != expected {
+		t.Errorf("expected merging twice to not change the resource, got:\n%s", remerged)
+	}
+	if len(report.Added) != 0 {
+		t.Errorf("expected no added properties when merging twice, got %v", report.Added)
+	}
+}
@@ -27,6 +27,7 @@ import (
 	"strings"
 
 	"log"
+	"net/http"
 
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
 	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api/product"
@@ -39,6 +40,10 @@ import (
 type Parser struct {
 	Folder string
 	Output string
+
+	// If true, resources that already exist in Output are merged with the
+	// spec instead of being overwritten. See mergeResource.
+	Merge bool
 }
 
 func NewOpenapiParser(folder, output string) Parser {
@@ -90,17 +95,22 @@ func (parser Parser) WriteYaml(filePath string) {
 		log.Fatalf("error reading header %v", err)
 	}
 
-	resourcePaths := findResources(doc)
-	productPath := buildProduct(filePath, parser.Output, doc, header)
+	resources := findResources(doc)
+	productPath := buildProduct(filePath, parser.Output, doc, header, parser.Merge)
 
 	// Disables line wrap for long strings
 	yaml.FutureLineWrap()
 	log.Printf("Generated product %+v/product.yaml", productPath)
-	for _, pathArray := range resourcePaths {
-		resource := buildResource(filePath, pathArray[0], pathArray[1], doc)
+	for _, ops := range resources {
+		resource := buildResource(filePath, ops, doc)
 
-		// marshal method
 		resourceOutPathMarshal := filepath.Join(productPath, fmt.Sprintf("%s.yaml", resource.Name))
+		if _, err := os.Stat(resourceOutPathMarshal); parser.Merge && err == nil {
+			parser.mergeYaml(resourceOutPathMarshal, resource, versionName(doc))
+			continue
+		}
+
+		// marshal method
 		bytes, err := yaml.Marshal(resource)
 		if err != nil {
 			log.Fatalf("error marshalling yaml %v: %v", resourceOutPathMarshal, err)
@@ -126,30 +136,102 @@ func (parser Parser) WriteYaml(filePath string) {
 	}
 }
 
-func findResources(doc *openapi3.T) [][]string {
-	var resourcePaths [][]string
+func (parser Parser) mergeYaml(resourcePath string, resource api.Resource, versionName string) {
+	merged, report := mergeResource(resourcePath, resource, versionName)
+	if err := os.WriteFile(resourcePath, merged, 0644); err != nil {
+		log.Fatalf("error writing resource file %v", err)
+	}
+
+	log.Printf("Merged resource %s", resourcePath)
+	for _, p := range report.Added {
+		log.Printf("  added %s", p)
+	}
+	for _, p := range report.Removed {
+		log.Printf("  not found in the spec, marked for review: %s", p)
+	}
+	for _, p := range report.Skipped {
+		log.Printf("  could not merge new fields into %s, add them by hand", p)
+	}
+}
+
+// The operations that make up the lifecycle of a single resource, along with
+// the paths they are found at.
+type resourceOperations struct {
+	Name string
+
+	CreatePath string
+	Create     *openapi3.Operation
+
+	GetPath string
+	Get     *openapi3.Operation
+
+	UpdatePath string
+	Update     *openapi3.Operation
+
+	DeletePath string
+	Delete     *openapi3.Operation
+
+	ListPath string
+	List     *openapi3.Operation
+}
+
+func findResources(doc *openapi3.T) []resourceOperations {
+	var resources []resourceOperations
 
 	pathMap := doc.Paths.Map()
-	for key, pathValue := range pathMap {
+	for _, key := range sortedPaths(doc) {
+		pathValue := pathMap[key]
 		if pathValue.Post == nil {
 			continue
 		}
 
 		// Not very clever way of identifying create resource methods
 		if strings.HasPrefix(pathValue.Post.OperationID, "Create") {
-			resourcePath := key
 			resourceName := strings.Replace(pathValue.Post.OperationID, "Create", "", 1)
-			resourcePaths = append(resourcePaths, []string{resourcePath, resourceName})
+			ops := resourceOperations{
+				Name:       resourceName,
+				CreatePath: key,
+				Create:     pathValue.Post,
+			}
+			ops.GetPath, ops.Get = findOperation(doc, http.MethodGet, "Get"+resourceName)
+			ops.UpdatePath, ops.Update = findOperation(doc, http.MethodPatch, "Update"+resourceName, "Patch"+resourceName)
+			ops.DeletePath, ops.Delete = findOperation(doc, http.MethodDelete, "Delete"+resourceName)
+			ops.ListPath, ops.List = findOperation(doc, http.MethodGet, "List"+google.Plural(resourceName))
+			resources = append(resources, ops)
 		}
 	}
 
-	return resourcePaths
+	return resources
 }
 
-func buildProduct(filePath, output string, root *openapi3.T, header []byte) string {
+// Returns the paths of the spec in a stable order, so that generation is
+// deterministic.
+func sortedPaths(doc *openapi3.T) []string {
+	var paths []string
+	for key := range doc.Paths.Map() {
+		paths = append(paths, key)
+	}
+	slices.Sort(paths)
+	return paths
+}
+
+// Finds the operation with the given method whose OperationID matches one of
+// the given ids. Create, Get, Update and Delete use different paths in the
+// OpenAPI spec, so every path is searched.
+func findOperation(doc *openapi3.T, method string, operationIds ...string) (string, *openapi3.Operation) {
+	for _, key := range sortedPaths(doc) {
+		op := doc.Paths.Value(key).GetOperation(method)
+		if op != nil && slices.Contains(operationIds, op.OperationID) {
+			return key, op
+		}
+	}
+	return "", nil
+}
+
+func buildProduct(filePath, output string, root *openapi3.T, header []byte, merge bool) string {
 
 	version := root.Info.Version
-	server := root.Servers[0].URL
+	server := strings.TrimSuffix(root.Servers[0].URL, "/")
 
 	productName := strings.Split(filepath.Base(filePath), "_")[0]
 	productPath := filepath.Join(output, productName)
@@ -158,12 +240,21 @@ func buildProduct(filePath, output string, root *openapi3.T, header []byte) stri
 		log.Fatalf("error creating product output directory %v: %v", productPath, err)
 	}
 
+	productOutPathMarshal := filepath.Join(output, fmt.Sprintf("/%s/product.yaml", productName))
+	if _, err := os.Stat(productOutPathMarshal); merge && err == nil {
+		log.Printf("Keeping existing product %s", productOutPathMarshal)
+		return productPath
+	}
+
 	apiProduct := &api.Product{}
 	apiVersion := &product.Version{}
 
-	apiVersion.BaseUrl = fmt.Sprintf("%s/%s/", server, version)
-	// TODO(slevenick) figure out how to tell the API version
-	apiVersion.Name = "ga"
+	if strings.HasSuffix(server, "/"+version) {
+		apiVersion.BaseUrl = fmt.Sprintf("%s/", server)
+	} else {
+		apiVersion.BaseUrl = fmt.Sprintf("%s/%s/", server, version)
+	}
+	apiVersion.Name = versionName(root)
 	apiProduct.Versions = []*product.Version{apiVersion}
 
 	// Standard titling is "Service Name API"
@@ -174,8 +265,6 @@ func buildProduct(filePath, output string, root *openapi3.T, header []byte) stri
 	//Scopes should be added soon to OpenAPI, until then use global scope
 	apiProduct.Scopes = []string{"https://www.googleapis.com/auth/cloud-platform"}
 
-	productOutPathMarshal := filepath.Join(output, fmt.Sprintf("/%s/product.yaml", productName))
-
 	// Default yaml marshaller
 	bytes, err := yaml.Marshal(apiProduct)
 	if err != nil {
@@ -201,6 +290,30 @@ func buildProduct(filePath, output string, root *openapi3.T, header []byte) stri
 	return productPath
 }
 
+// Returns the MMv1 version name of the API described by the spec. Google APIs
+// follow AIP-185, so alpha and beta APIs carry the channel in their version,
+// e.g. v1beta1. The version is read from the info block, falling back to the
+// server URL.
+func versionName(root *openapi3.T) string {
+	candidates := []string{root.Info.Version}
+	for _, server := range root.Servers {
+		candidates = append(candidates, server.URL)
+	}
+
+	re := regexp.MustCompile(`v\d+(alpha|beta)`)
+	for _, candidate := range candidates {
+		match := re.FindStringSubmatch(strings.ToLower(candidate))
+		if match == nil {
+			continue
+		}
+		if match[1] == "alpha" {
+			return "alpha"
+		}
+		return "beta"
+	}
+	return "ga"
+}
+
 func baseUrl(resourcePath string) string {
 	base := strings.ReplaceAll(resourcePath, "{", "{{")
 	base = strings.ReplaceAll(base, "}", "}}")
@@ -209,12 +322,153 @@ func baseUrl(resourcePath string) string {
 	base = strings.ReplaceAll(base, "locationsId", "location")
 	base = stripVersion(base)
 	r := regexp.MustCompile(`\{\{(\w+)\}\}`)
-	matches := r.FindStringSubmatch(base)
-	for i := 0; i < len(matches); i++ {
-		match := matches[i]
-		base = strings.ReplaceAll(base, match, google.Underscore(match))
+	return r.ReplaceAllStringFunc(base, google.Underscore)
+}
+
+// Expands path variables holding a full resource name, such as {parent} or
+// {name}, into the URL segments described by the pattern of the parameter.
+// e.g. /v1/{parent}/instances with a parent pattern of
+// ^projects/[^/]+/locations/[^/]+$ becomes
+// /v1/projects/{project}/locations/{location}/instances
+func expandPath(resourcePath string, op *openapi3.Operation) string {
+	if op == nil {
+		return resourcePath
 	}
-	return base
+	for _, param := range op.Parameters {
+		if param.Value == nil || param.Value.In != openapi3.ParameterInPath || param.Value.Schema == nil || param.Value.Schema.Value == nil {
+			continue
+		}
+		variable := fmt.Sprintf("{%s}", param.Value.Name)
+		segments := patternSegments(param.Value.Schema.Value.Pattern)
+		if !strings.Contains(resourcePath, variable) || len(segments) == 0 {
+			continue
+		}
+
+		var expanded []string
+		for _, segment := range segments {
+			expanded = append(expanded, segment.collection, fmt.Sprintf("{%s}", segment.variable))
+		}
+		resourcePath = strings.ReplaceAll(resourcePath, variable, strings.Join(expanded, "/"))
+	}
+	return resourcePath
+}
+
+// A collection / identifier pair within a resource name pattern
+type patternSegment struct {
+	collection string
+	variable   string
+}
+
+// Parses a resource name pattern such as ^projects/[^/]+/locations/[^/]+$
+// into its collection / identifier pairs. Returns nil if the pattern doesn't
+// follow that shape.
+func patternSegments(pattern string) []patternSegment {
+	pattern = strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "$")
+	if pattern == "" {
+		return nil
+	}
+
+	// Identifiers match any character but a slash, which needs to be
+	// replaced before splitting the pattern into segments
+	parts := strings.Split(strings.ReplaceAll(pattern, "[^/]+", "*"), "/")
+	if len(parts)%2 != 0 {
+		return nil
+	}
+
+	var segments []patternSegment
+	for i := 0; i < len(parts); i += 2 {
+		if parts[i+1] != "*" {
+			return nil
+		}
+		segments = append(segments, patternSegment{
+			collection: parts[i],
+			variable:   singular(parts[i]),
+		})
+	}
+	return segments
+}
+
+// Returns the singular form of a collection name, e.g. projects -> project
+func singular(collection string) string {
+	switch {
+	case strings.HasSuffix(collection, "ies"):
+		return strings.TrimSuffix(collection, "ies") + "y"
+	case strings.HasSuffix(collection, "sses"), strings.HasSuffix(collection, "shes"):
+		return strings.TrimSuffix(collection, "es")
+	default:
+		return strings.TrimSuffix(collection, "s")
+	}
+}
+
+// Replaces the last variable of a path with the given name. The last variable
+// of the path of a Get, Update or Delete operation identifies the resource
+// itself, and is named after the id parameter of the create operation.
+func replaceLastVariable(resourcePath, name string) string {
+	start := strings.LastIndex(resourcePath, "{")
+	end := strings.LastIndex(resourcePath, "}")
+	if start == -1 || end < start {
+		return resourcePath
+	}
+	return fmt.Sprintf("%s{%s}%s", resourcePath[:start], name, resourcePath[end+1:])
+}
+
+// Returns the MMv1 URL of an operation on an existing resource
+func operationUrl(resourcePath string, op *openapi3.Operation, idParam string) string {
+	return baseUrl(replaceLastVariable(expandPath(resourcePath, op), idParam))
+}
+
+// Returns true if the operation returns a long-running operation, as
+// described by AIP-151.
+func isLongRunning(op *openapi3.Operation) bool {
+	if op == nil || op.Responses == nil {
+		return false
+	}
+
+	for _, response := range []*openapi3.ResponseRef{op.Responses.Status(200), op.Responses.Default()} {
+		if response == nil || response.Value == nil {
+			continue
+		}
+		content := response.Value.Content.Get("application/json")
+		if content == nil || content.Schema == nil {
+			continue
+		}
+		if strings.HasSuffix(content.Schema.Ref, "/Operation") {
+			return true
+		}
+		if schema := content.Schema.Value; schema != nil && schema.Properties["done"] != nil && (schema.Properties["response"] != nil || schema.Properties["metadata"] != nil) {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the name of the array field holding resources in the response of a
+// List operation, as described by AIP-132.
+func collectionKey(op *openapi3.Operation) string {
+	if op == nil || op.Responses == nil {
+		return ""
+	}
+	response := op.Responses.Status(200)
+	if response == nil || response.Value == nil {
+		return ""
+	}
+	content := response.Value.Content.Get("application/json")
+	if content == nil || content.Schema == nil || content.Schema.Value == nil {
+		return ""
+	}
+
+	var keys []string
+	for key, prop := range content.Schema.Value.Properties {
+		// unreachable lists locations that couldn't be listed
+		if key == "unreachable" || prop.Value == nil || !prop.Value.Type.Is("array") {
+			continue
+		}
+		keys = append(keys, key)
+	}
+	if len(keys) != 1 {
+		return ""
+	}
+	return keys[0]
 }
 
 // OpenAPI paths are prefixed with the version of the API, which already exists
@@ -225,17 +479,21 @@ func stripVersion(path string) string {
 	return re.ReplaceAllString(path, "")
 }
 
-func buildResource(filePath, resourcePath, resourceName string, root *openapi3.T) api.Resource {
+func buildResource(filePath string, ops resourceOperations, root *openapi3.T) api.Resource {
 	resource := api.Resource{}
+	resourceName := ops.Name
 
-	parsedObjects := parseOpenApi(resourcePath, resourceName, root)
+	parsedObjects := parseOpenApi(ops.CreatePath, resourceName, root)
 
 	parameters := parsedObjects[0].([]*api.Type)
 	properties := parsedObjects[1].([]*api.Type)
 	queryParam := parsedObjects[2].(string)
 
-	baseUrl := baseUrl(resourcePath)
+	baseUrl := baseUrl(expandPath(ops.CreatePath, ops.Create))
 	selfLink := fmt.Sprintf("%s/{{%s}}", baseUrl, google.Underscore(queryParam))
+	if ops.Get != nil {
+		selfLink = operationUrl(ops.GetPath, ops.Get, queryParam)
+	}
 
 	resource.Name = resourceName
 	resource.BaseUrl = baseUrl
@@ -247,19 +505,48 @@ func buildResource(filePath, resourcePath, resourceName string, root *openapi3.T
 	resource.CreateUrl = fmt.Sprintf("%s?%s={{%s}}", baseUrl, queryParam, google.Underscore(queryParam))
 	resource.Description = "Description"
 
-	resource.AutogenAsync = true
-	async := api.NewAsync()
-	async.Operation.BaseUrl = "{{op_id}}"
-	async.Result.ResourceInsideResponse = true
-	resource.Async = async
+	var asyncActions []string
+	if isLongRunning(ops.Create) {
+		asyncActions = append(asyncActions, "create")
+	}
+	if isLongRunning(ops.Delete) {
+		asyncActions = append(asyncActions, "delete")
+	}
+	if isLongRunning(ops.Update) {
+		asyncActions = append(asyncActions, "update")
+	}
+	if len(asyncActions) > 0 {
+		resource.AutogenAsync = true
+		async := api.NewAsync()
+		async.Actions = asyncActions
+		async.Operation.BaseUrl = "{{op_id}}"
+		async.Result.ResourceInsideResponse = true
+		resource.Async = async
+	}
 
-	if hasUpdate(resourceName, root) {
+	if ops.Update != nil {
 		resource.UpdateVerb = "PATCH"
-		resource.UpdateMask = true
+		resource.UpdateMask = hasParameter(ops.Update, "updateMask")
+		if updateUrl := operationUrl(ops.UpdatePath, ops.Update, queryParam); updateUrl != selfLink {
+			resource.UpdateUrl = updateUrl
+		}
 	} else {
 		resource.Immutable = true
 	}
 
+	if ops.Delete != nil {
+		if deleteUrl := operationUrl(ops.DeletePath, ops.Delete, queryParam); deleteUrl != selfLink {
+			resource.DeleteUrl = deleteUrl
+		}
+	} else {
+		resource.ExcludeDelete = true
+	}
+
+	// Only set when it differs from the default of the camelcase plural name
+	if key := collectionKey(ops.List); key != "" && key != google.Camelize(google.Plural(resourceName), "lower") {
+		resource.CollectionUrlKey = key
+	}
+
 	example := r.Examples{}
 	example.Name = "name_of_example_file"
 	example.PrimaryResourceId = "example"
@@ -275,14 +562,9 @@ func buildResource(filePath, resourcePath, resourceName string, root *openapi3.T
 	return resource
 }
 
-func hasUpdate(resourceName string, root *openapi3.T) bool {
-	// Create and Update have different paths in the OpenAPI spec, so look
-	// through all paths to find one that matches the expected operation name
-	for _, pathValue := range root.Paths.Map() {
-		if pathValue.Patch == nil {
-			continue
-		}
-		if pathValue.Patch.OperationID == fmt.Sprintf("Update%s", resourceName) {
+func hasParameter(op *openapi3.Operation, name string) bool {
+	for _, param := range op.Parameters {
+		if param.Value != nil && param.Value.Name == name {
 			return true
 		}
 	}
@@ -299,6 +581,16 @@ func parseOpenApi(resourcePath, resourceName string, root *openapi3.T) []any {
 		if strings.Contains(strings.ToLower(param.Value.Name), strings.ToLower(resourceName)) {
 			idParam = param.Value.Name
 		}
+
+		// A {parent} path parameter holds the full name of the parent
+		// resource, so it is split into a parameter per identifier
+		if param.Value.In == openapi3.ParameterInPath && param.Value.Schema != nil && param.Value.Schema.Value != nil {
+			if segments := patternSegments(param.Value.Schema.Value.Pattern); len(segments) > 0 {
+				parameters = append(parameters, parentParameters(segments)...)
+				continue
+			}
+		}
+
 		paramObj := writeObject(param.Value.Name, param.Value.Schema, propType(param.Value.Schema), true)
 		description := param.Value.Description
 		if strings.TrimSpace(description) == "" {
@@ -324,6 +616,27 @@ func parseOpenApi(resourcePath, resourceName string, root *openapi3.T) []any {
 	return returnArray
 }
 
+// Returns a url parameter for each identifier of the parent resource. The
+// project is omitted as it is inferred from the presence of {{project}} in the
+// URL.
+func parentParameters(segments []patternSegment) []*api.Type {
+	var parameters []*api.Type
+	for _, segment := range segments {
+		if segment.variable == "project" {
+			continue
+		}
+		parameters = append(parameters, &api.Type{
+			Name:         segment.variable,
+			Type:         "String",
+			Description:  fmt.Sprintf("The %s of the parent resource.", segment.variable),
+			UrlParamOnly: true,
+			Required:     true,
+			Immutable:    true,
+		})
+	}
+	return parameters
+}
+
 func propType(prop *openapi3.SchemaRef) openapi3.Types {
 	if len(prop.Value.AllOf) > 0 {
 		return *prop.Value.AllOf[0].Value.Type
@@ -439,7 +752,13 @@ func writeObject(name string, obj *openapi3.SchemaRef, objType openapi3.Types, u
 
 func buildProperties(props openapi3.Schemas, required []string) []*api.Type {
 	properties := []*api.Type{}
-	for k, prop := range props {
+	var keys []string
+	for k := range props {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	for _, k := range keys {
+		prop := props[k]
 		propObj := writeObject(k, prop, propType(prop), false)
 		if slices.Contains(required, k) {
 			propObj.Required = true
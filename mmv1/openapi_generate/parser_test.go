@@ -0,0 +1,351 @@
+package openapi_generate
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const testSpec = `
+openapi: 3.0.0
+info:
+  title: Test API
+  version: v1beta1
+servers:
+  - url: https://test.googleapis.com
+paths:
+  /v1beta1/{parent}/instances:
+    post:
+      operationId: CreateInstance
+      parameters:
+        - name: parent
+          in: path
+          required: true
+          schema:
+            type: string
+            pattern: ^projects/[^/]+/locations/[^/]+$
+        - name: instanceId
+          in: query
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Instance'
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Operation'
+    get:
+      operationId: ListInstances
+      parameters:
+        - name: parent
+          in: path
+          required: true
+          schema:
+            type: string
+            pattern: ^projects/[^/]+/locations/[^/]+$
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+                properties:
+                  testInstances:
+                    type: array
+                    items:
+                      $ref: '#/components/schemas/Instance'
+                  nextPageToken:
+                    type: string
+                  unreachable:
+                    type: array
+                    items:
+                      type: string
+  /v1beta1/{name}:
+    get:
+      operationId: GetInstance
+      parameters:
+        - name: name
+          in: path
+          required: true
+          schema:
+            type: string
+            pattern: ^projects/[^/]+/locations/[^/]+/instances/[^/]+$
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Instance'
+    patch:
+      operationId: UpdateInstance
+      parameters:
+        - name: name
+          in: path
+          required: true
+          schema:
+            type: string
+            pattern: ^projects/[^/]+/locations/[^/]+/instances/[^/]+$
+        - name: updateMask
+          in: query
+          schema:
+            type: string
+      requestBody:
+        content:
+          application/json:
+            schema:
+              $ref: '#/components/schemas/Instance'
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                $ref: '#/components/schemas/Operation'
+    delete:
+      operationId: DeleteInstance
+      parameters:
+        - name: name
+          in: path
+          required: true
+          schema:
+            type: string
+            pattern: ^projects/[^/]+/locations/[^/]+/instances/[^/]+$
+      responses:
+        '200':
+          description: OK
+          content:
+            application/json:
+              schema:
+                type: object
+components:
+  schemas:
+    Instance:
+      type: object
+      properties:
+        name:
+          type: string
+        description:
+          type: string
+    Operation:
+      type: object
+      properties:
+        name:
+          type: string
+        done:
+          type: boolean
+        response:
+          type: object
+`
+
+func loadTestSpec(t *testing.T) *openapi3.T {
+	loader := &openapi3.Loader{Context: context.Background()}
+	doc, err := loader.LoadFromData([]byte(testSpec))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return doc
+}
+
+func TestFindResources(t *testing.T) {
+	t.Parallel()
+
+	doc := loadTestSpec(t)
+	resources := findResources(doc)
+	if len(resources) != 1 {
+		t.Fatalf("expected 1 resource, got %d", len(resources))
+	}
+
+	ops := resources[0]
+	got := map[string]string{
+		"create": ops.CreatePath,
+		"get":    ops.GetPath,
+		"update": ops.UpdatePath,
+		"delete": ops.DeletePath,
+		"list":   ops.ListPath,
+	}
+	want := map[string]string{
+		"create": "/v1beta1/{parent}/instances",
+		"get":    "/v1beta1/{name}",
+		"update": "/v1beta1/{name}",
+		"delete": "/v1beta1/{name}",
+		"list":   "/v1beta1/{parent}/instances",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("expected operation paths %v to be %v", got, want)
+	}
+}
+
+func TestBuildResource(t *testing.T) {
+	t.Parallel()
+
+	doc := loadTestSpec(t)
+	resource := buildResource("test_api.yaml", findResources(doc)[0], doc)
+
+	cases := []struct {
+		description string
+		got         any
+		expected    any
+	}{
+		{
+			description: "base url expands the parent",
+			got:         resource.BaseUrl,
+			expected:    "projects/{{project}}/locations/{{location}}/instances",
+		},
+		{
+			description: "self link is read from the get operation",
+			got:         resource.SelfLink,
+			expected:    "projects/{{project}}/locations/{{location}}/instances/{{instance_id}}",
+		},
+		{
+			description: "update uses an update mask",
+			got:         []any{resource.UpdateVerb, resource.UpdateMask, resource.Immutable},
+			expected:    []any{"PATCH", true, false},
+		},
+		{
+			description: "delete url matches the self link",
+			got:         resource.DeleteUrl,
+			expected:    "",
+		},
+		{
+			description: "collection url key is read from the list response",
+			got:         resource.CollectionUrlKey,
+			expected:    "testInstances",
+		},
+		{
+			description: "only long-running operations are async",
+			got:         resource.Async.Actions,
+			expected:    []string{"create", "update"},
+		},
+		{
+			description: "parent identifiers become parameters",
+			got:         parameterNames(resource.Parameters),
+			expected:    []string{"location", "instanceId"},
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			if !reflect.DeepEqual(tc.got, tc.expected) {
+				t.Errorf("expected %v to be %v", tc.got, tc.expected)
+			}
+		})
+	}
+}
+
+func TestVersionName(t *testing.T) {
+	t.Parallel()
+
+	cases := []struct {
+		description string
+		version     string
+		server      string
+		expected    string
+	}{
+		{
+			description: "ga version",
+			version:     "v1",
+			server:      "https://test.googleapis.com",
+			expected:    "ga",
+		},
+		{
+			description: "beta version",
+			version:     "v1beta1",
+			server:      "https://test.googleapis.com",
+			expected:    "beta",
+		},
+		{
+			description: "alpha version",
+			version:     "v2alpha",
+			server:      "https://test.googleapis.com",
+			expected:    "alpha",
+		},
+		{
+			description: "version in the server url",
+			version:     "",
+			server:      "https://test.googleapis.com/v1beta",
+			expected:    "beta",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			root := &openapi3.T{
+				Info:    &openapi3.Info{Version: tc.version},
+				Servers: openapi3.Servers{{URL: tc.server}},
+			}
+			if got := versionName(root); got != tc.expected {
+				t.Errorf("expected %q to be %q", got, tc.expected)
+			}
+		})
+	}
+}
+
+func parameterNames(parameters []*api.Type) []string {
+	var names []string
+	for _, p := range parameters {
+		names = append(names, p.Name)
+	}
+	return names
+}
+
+func TestExpandPath(t *testing.T) {
+	t.Parallel()
+
+	pathParam := func(schema *openapi3.SchemaRef) *openapi3.ParameterRef {
+		return &openapi3.ParameterRef{Value: &openapi3.Parameter{Name: "parent", In: openapi3.ParameterInPath, Schema: schema}}
+	}
+
+	cases := []struct {
+		description string
+		param       *openapi3.ParameterRef
+		expected    string
+	}{
+		{
+			description: "pattern expands the parameter",
+			param:       pathParam(&openapi3.SchemaRef{Value: &openapi3.Schema{Pattern: "^projects/[^/]+/locations/[^/]+$"}}),
+			expected:    "/v1/projects/{project}/locations/{location}/instances",
+		},
+		{
+			description: "no schema",
+			param:       pathParam(nil),
+			expected:    "/v1/{parent}/instances",
+		},
+		{
+			description: "unresolved schema reference",
+			param:       pathParam(&openapi3.SchemaRef{Ref: "#/components/schemas/Parent"}),
+			expected:    "/v1/{parent}/instances",
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			op := &openapi3.Operation{Parameters: openapi3.Parameters{tc.param}}
+			if got := expandPath("/v1/{parent}/instances", op); got != tc.expected {
+				t.Errorf("expected %q to be %q", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,419 @@
+// Copyright 2025 Google Inc.
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//	http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package provider
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// The generation cache lets a run skip template execution for output files
+// whose inputs have not changed since the previous run. An output file is
+// considered up to date when:
+//   - the key computed from its inputs matches the one recorded for it, and
+//   - the file on disk still has the content recorded at the end of that run.
+//
+// The key of an output file covers the generator binary, the target version,
+// the templates used to render the file, every shared template under
+// templates/terraform, the template input (products and resources after
+// overrides are merged and defaults are set) and the content of every
+// template or third_party file referenced by a path within that input, such as
+// custom code and example configs.
+type GenerationCache struct {
+	path string
+
+	// The key shared by every file generated in this run
+	baseKey string
+
+	mu      sync.Mutex
+	entries map[string]generationCacheEntry
+
+	// The files generated during this run, whose output hash is recorded
+	// when the cache is saved
+	generated map[string]bool
+
+	hits   int
+	misses int
+
+	// Fingerprints of the structs written so far, keyed by pointer. Objects
+	// are shared between many generated files, so each one is only walked
+	// once per run.
+	digests sync.Map
+}
+
+type generationCacheEntry struct {
+	Key        string `json:"key"`
+	OutputHash string `json:"output_hash"`
+}
+
+var generationCache *GenerationCache
+
+// Content hashes of files referenced by template inputs, keyed by path
+var fileHashes sync.Map
+
+// Struct fields that are never part of a fingerprint. They are either back
+// references to a parent object, values that are derived while templates are
+// executed, or change on every run.
+var fingerprintSkippedFields = map[string]bool{
+	"ParentMetadata":   true,
+	"ResourceMetadata": true,
+	"Prefix":           true,
+	"StartTime":        true,
+}
+
+// Enables the generation cache for this run. The cache is stored in cacheDir,
+// in a file that is specific to the output folder, version and provider.
+func EnableGenerationCache(cacheDir, outputFolder, versionName, providerName string) error {
+	absOutput, err := filepath.Abs(outputFolder)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(cacheDir, os.ModePerm); err != nil {
+		return err
+	}
+
+	executableHash, err := executableHash()
+	if err != nil {
+		return err
+	}
+
+	sharedTemplates, err := templateFiles("templates/terraform")
+	if err != nil {
+		return err
+	}
+
+	h := sha256.New()
+	fmt.Fprintf(h, "executable:%s\nversion:%s\nprovider:%s\n", executableHash, versionName, providerName)
+	for _, t := range sharedTemplates {
+		fmt.Fprintf(h, "template:%s:%s\n", t, hashFile(t))
+	}
+
+	cacheName := sha256.Sum256([]byte(strings.Join([]string{absOutput, versionName, providerName}, "\n")))
+	cache := &GenerationCache{
+		path:      filepath.Join(cacheDir, fmt.Sprintf("%s.json", hex.EncodeToString(cacheName[:8]))),
+		baseKey:   hex.EncodeToString(h.Sum(nil)),
+		entries:   make(map[string]generationCacheEntry),
+		generated: make(map[string]bool),
+	}
+
+	contents, err := os.ReadFile(cache.path)
+	if err == nil {
+		if err := json.Unmarshal(contents, &cache.entries); err != nil {
+			log.Printf("Ignoring unreadable generation cache %s: %s", cache.path, err)
+			cache.entries = make(map[string]generationCacheEntry)
+		}
+	} else if !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	log.Printf("Using generation cache %s", cache.path)
+	generationCache = cache
+	return nil
+}
+
+// Returns the templates in dir and its subfolders, sorted by path. Templates
+// can include each other, so a change to any of them can change every file.
+func templateFiles(dir string) ([]string, error) {
+	var templates []string
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !d.IsDir() && strings.HasSuffix(d.Name(), ".tmpl") {
+			templates = append(templates, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(templates)
+	return templates, nil
+}
+
+// Records the content of every file generated during this run and writes the
+// cache to disk. This must be called after all post-processing of generated
+// files, such as fixing imports and adding headers, has happened.
+func SaveGenerationCache() {
+	if generationCache == nil {
+		return
+	}
+	if err := generationCache.save(); err != nil {
+		log.Printf("Error saving generation cache %s: %s", generationCache.path, err)
+	}
+}
+
+func (c *GenerationCache) save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for filePath := range c.generated {
+		entry := c.entries[filePath]
+		entry.OutputHash = hashFile(filePath)
+		if entry.OutputHash == "" {
+			delete(c.entries, filePath)
+			continue
+		}
+		c.entries[filePath] = entry
+	}
+
+	contents, err := json.MarshalIndent(c.entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Generation cache: %d files up to date, %d files generated", c.hits, c.misses)
+	return os.WriteFile(c.path, contents, 0644)
+}
+
+// Computes the key of an output file from the templates and input used to
+// render it.
+func (c *GenerationCache) Key(templates []string, input any) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "base:%s\n", c.baseKey)
+	for _, t := range templates {
+		fmt.Fprintf(h, "template:%s:%s\n", t, hashFile(t))
+	}
+
+	f := fingerprinter{w: h, files: make(map[string]bool), visiting: make(map[uintptr]bool), digests: &c.digests}
+	f.write(reflect.ValueOf(input), false)
+
+	files := make([]string, 0, len(f.files))
+	for file := range f.files {
+		files = append(files, file)
+	}
+	sort.Strings(files)
+	for _, file := range files {
+		fmt.Fprintf(h, "file:%s:%s\n", file, hashFile(file))
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Returns true if the output file was generated from the same key by a
+// previous run and hasn't been modified since.
+func (c *GenerationCache) UpToDate(filePath, key string) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[filePath]
+	upToDate := ok && entry.Key == key && entry.OutputHash != "" && entry.OutputHash == hashFile(filePath)
+	if upToDate {
+		c.hits++
+	} else {
+		c.misses++
+	}
+	return upToDate
+}
+
+// Records the key of a file generated during this run.
+func (c *GenerationCache) Generated(filePath, key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[filePath] = generationCacheEntry{Key: key}
+	c.generated[filePath] = true
+}
+
+// Writes a deterministic representation of a value to a hash. Products
+// referenced from a resource are written without their resources, so that a
+// change to one resource doesn't invalidate its siblings.
+type fingerprinter struct {
+	w io.Writer
+
+	// Files referenced by string values within the input
+	files map[string]bool
+
+	// Pointers being written, to guard against reference cycles
+	visiting map[uintptr]bool
+
+	digests *sync.Map
+}
+
+type digestKey struct {
+	pointer uintptr
+	shallow bool
+}
+
+type digest struct {
+	sum   string
+	files []string
+}
+
+func (f fingerprinter) write(v reflect.Value, shallow bool) {
+	switch v.Kind() {
+	case reflect.Invalid:
+		io.WriteString(f.w, "nil;")
+	case reflect.Interface:
+		if v.IsNil() {
+			io.WriteString(f.w, "nil;")
+			return
+		}
+		f.write(v.Elem(), shallow)
+	case reflect.Pointer:
+		if v.IsNil() {
+			io.WriteString(f.w, "nil;")
+			return
+		}
+		if v.Elem().Kind() != reflect.Struct {
+			f.write(v.Elem(), shallow)
+			return
+		}
+		if f.visiting[v.Pointer()] {
+			io.WriteString(f.w, "cycle;")
+			return
+		}
+		f.writeDigest(v, shallow)
+	case reflect.Struct:
+		fmt.Fprintf(f.w, "%s{", v.Type().Name())
+		for i := 0; i < v.NumField(); i++ {
+			field := v.Type().Field(i)
+			if fingerprintSkippedFields[field.Name] {
+				continue
+			}
+			if shallow && field.Name == "Objects" {
+				continue
+			}
+			fmt.Fprintf(f.w, "%s:", field.Name)
+			// Resources point back to their product, which only needs to be
+			// written without its other resources
+			f.write(v.Field(i), shallow || field.Name == "ProductMetadata")
+		}
+		io.WriteString(f.w, "}")
+	case reflect.Slice, reflect.Array:
+		fmt.Fprintf(f.w, "[%d:", v.Len())
+		for i := 0; i < v.Len(); i++ {
+			f.write(v.Index(i), shallow)
+		}
+		io.WriteString(f.w, "]")
+	case reflect.Map:
+		keys := v.MapKeys()
+		sort.Slice(keys, func(i, j int) bool {
+			return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j])
+		})
+		fmt.Fprintf(f.w, "map[%d:", v.Len())
+		for _, k := range keys {
+			f.write(k, shallow)
+			f.write(v.MapIndex(k), shallow)
+		}
+		io.WriteString(f.w, "]")
+	case reflect.String:
+		s := v.String()
+		fmt.Fprintf(f.w, "%q;", s)
+		if isReferencedFile(s) {
+			f.files[s] = true
+		}
+	case reflect.Bool:
+		fmt.Fprintf(f.w, "%t;", v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		fmt.Fprintf(f.w, "%d;", v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		fmt.Fprintf(f.w, "%d;", v.Uint())
+	case reflect.Float32, reflect.Float64:
+		fmt.Fprintf(f.w, "%g;", v.Float())
+	default:
+		// Functions and channels don't affect template output
+	}
+}
+
+// Writes the fingerprint of the struct a pointer points to, computing it if it
+// hasn't been written before.
+func (f fingerprinter) writeDigest(v reflect.Value, shallow bool) {
+	key := digestKey{pointer: v.Pointer(), shallow: shallow}
+
+	d, ok := f.digests.Load(key)
+	if !ok {
+		h := sha256.New()
+		sub := fingerprinter{w: h, files: make(map[string]bool), visiting: f.visiting, digests: f.digests}
+		f.visiting[key.pointer] = true
+		sub.write(v.Elem(), shallow)
+		delete(f.visiting, key.pointer)
+
+		computed := digest{sum: hex.EncodeToString(h.Sum(nil))}
+		for file := range sub.files {
+			computed.files = append(computed.files, file)
+		}
+		d, _ = f.digests.LoadOrStore(key, computed)
+	}
+
+	fmt.Fprintf(f.w, "&%s;", d.(digest).sum)
+	for _, file := range d.(digest).files {
+		f.files[file] = true
+	}
+}
+
+// Returns true if the string looks like the path of a template or
+// third_party file that exists, e.g. a custom code template.
+func isReferencedFile(s string) bool {
+	if s == "" || len(s) > 512 || strings.ContainsAny(s, " \n\t{}") || !strings.Contains(s, "/") {
+		return false
+	}
+	info, err := os.Stat(s)
+	return err == nil && info.Mode().IsRegular()
+}
+
+// Returns the content hash of a file, or an empty string if it can't be read.
+func hashFile(filePath string) string {
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return ""
+	}
+
+	// Generated files change during a run, so hashes are memoized by size and
+	// modification time as well as path
+	memoKey := fmt.Sprintf("%s:%d:%d", filePath, info.Size(), info.ModTime().UnixNano())
+	if h, ok := fileHashes.Load(memoKey); ok {
+		return h.(string)
+	}
+
+	f, err := os.Open(filePath)
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return ""
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+	fileHashes.Store(memoKey, sum)
+	return sum
+}
+
+// Returns the content hash of the running generator, so that changes to the
+// generator itself invalidate the cache.
+func executableHash() (string, error) {
+	executable, err := os.Executable()
+	if err != nil {
+		return "", err
+	}
+	sum := hashFile(executable)
+	if sum == "" {
+		return "", fmt.Errorf("cannot read generator executable %s", executable)
+	}
+	return sum, nil
+}
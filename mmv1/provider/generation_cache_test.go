@@ -0,0 +1,176 @@
+package provider
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+)
+
+func newTestGenerationCache(t *testing.T) *GenerationCache {
+	return &GenerationCache{
+		path:      filepath.Join(t.TempDir(), "cache.json"),
+		baseKey:   "base",
+		entries:   make(map[string]generationCacheEntry),
+		generated: make(map[string]bool),
+	}
+}
+
+func TestGenerationCacheKey(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	customCode := filepath.Join(dir, "custom_code.go.tmpl")
+	if err := os.WriteFile(customCode, []byte("original"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	newResource := func() *api.Resource {
+		product := &api.Product{Name: "Pubsub"}
+		resource := &api.Resource{
+			Name:            "Topic",
+			ProductMetadata: product,
+			Properties: []*api.Type{
+				{Name: "name", Type: "String"},
+			},
+		}
+		resource.CustomCode.CustomImport = customCode
+		resource.Properties[0].ResourceMetadata = resource
+		product.Objects = []*api.Resource{resource}
+		return resource
+	}
+
+	cases := []struct {
+		description string
+		modify      func(r *api.Resource)
+		changed     bool
+	}{
+		{
+			description: "same input",
+			modify:      func(r *api.Resource) {},
+			changed:     false,
+		},
+		{
+			description: "property changed",
+			modify:      func(r *api.Resource) { r.Properties[0].Description = "changed" },
+			changed:     true,
+		},
+		{
+			description: "custom code path changed",
+			modify:      func(r *api.Resource) { r.CustomCode.CustomImport = filepath.Join(dir, "other.go.tmpl") },
+			changed:     true,
+		},
+		{
+			description: "sibling resource added to the product",
+			modify: func(r *api.Resource) {
+				r.ProductMetadata.Objects = append(r.ProductMetadata.Objects, &api.Resource{Name: "Subscription"})
+			},
+			changed: false,
+		},
+		{
+			description: "derived prefix set",
+			modify:      func(r *api.Resource) { r.Properties[0].Prefix = "Pubsub" },
+			changed:     false,
+		},
+	}
+
+	for _, tc := range cases {
+		tc := tc
+
+		t.Run(tc.description, func(t *testing.T) {
+			t.Parallel()
+
+			original := newTestGenerationCache(t).Key([]string{"resource.go.tmpl"}, newResource())
+
+			modified := newResource()
+			tc.modify(modified)
+			key := newTestGenerationCache(t).Key([]string{"resource.go.tmpl"}, modified)
+
+			if got, want := key != original, tc.changed; got != want {
+				t.Errorf("expected key change to be %t, got %t", want, got)
+			}
+		})
+	}
+}
+
+func TestGenerationCacheReferencedFileChanged(t *testing.T) {
+	t.Parallel()
+
+	customCode := filepath.Join(t.TempDir(), "custom_code.go.tmpl")
+	if err := os.WriteFile(customCode, []byte("original"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	resource := &api.Resource{Name: "Topic"}
+	resource.CustomCode.CustomImport = customCode
+
+	original := newTestGenerationCache(t).Key(nil, resource)
+	if err := os.WriteFile(customCode, []byte("changed content"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if newTestGenerationCache(t).Key(nil, resource) == original {
+		t.Errorf("expected key to change when %s changes", customCode)
+	}
+}
+
+func TestGenerationCacheUpToDate(t *testing.T) {
+	t.Parallel()
+
+	output := filepath.Join(t.TempDir(), "resource_pubsub_topic.go")
+	cache := newTestGenerationCache(t)
+
+	if cache.UpToDate(output, "key") {
+		t.Fatalf("expected a file that was never generated not to be up to date")
+	}
+
+	if err := os.WriteFile(output, []byte("package pubsub"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cache.Generated(output, "key")
+	if err := cache.save(); err != nil {
+		t.Fatal(err)
+	}
+
+	if !cache.UpToDate(output, "key") {
+		t.Errorf("expected the generated file to be up to date")
+	}
+	if cache.UpToDate(output, "other") {
+		t.Errorf("expected the generated file not to be up to date with a different key")
+	}
+
+	if err := os.WriteFile(output, []byte("package pubsub // edited"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if cache.UpToDate(output, "key") {
+		t.Errorf("expected an edited file not to be up to date")
+	}
+}
+
+func TestGenerationCacheTemplateFiles(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	for _, f := range []string{"resource.go.tmpl", "custom_expand/bool.go.tmpl", "custom_expand/nested/string.go.tmpl", "README.md"} {
+		path := filepath.Join(dir, f)
+		if err := os.MkdirAll(filepath.Dir(path), os.ModePerm); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(f), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	templates, err := templateFiles(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := []string{
+		filepath.Join(dir, "custom_expand/bool.go.tmpl"),
+		filepath.Join(dir, "custom_expand/nested/string.go.tmpl"),
+		filepath.Join(dir, "resource.go.tmpl"),
+	}
+	if !reflect.DeepEqual(templates, expected) {
+		t.Errorf("expected templates %v, got %v", expected, templates)
+	}
+}
@@ -82,10 +82,65 @@ func (td *TemplateData) GenerateResourceFile(filePath string, resource api.Resou
 		"templates/terraform/update_mask.go.tmpl",
 		"templates/terraform/nested_query.go.tmpl",
 		"templates/terraform/unordered_list_customize_diff.go.tmpl",
+		"templates/terraform/custom_methods.go.tmpl",
+		"templates/terraform/batching.go.tmpl",
+		"templates/terraform/state_lineage_upgraders.go.tmpl",
 	}
 	td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
+func (td *TemplateData) GenerateFrameworkResourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/framework_resource.go.tmpl"
+	td.GenerateFile(filePath, templatePath, resource, true, templatePath)
+}
+
+func (td *TemplateData) GenerateApiTypesFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/api_types.go.tmpl"
+	td.GenerateFile(filePath, templatePath, resource, true, templatePath)
+}
+
+func (td *TemplateData) GenerateFrameworkModelFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/framework_model.go.tmpl"
+	td.GenerateFile(filePath, templatePath, resource, true, templatePath)
+}
+
+func (td *TemplateData) GenerateDatasourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/datasource.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateDatasourceDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/datasource.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
+func (td *TemplateData) GenerateListDatasourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/list_datasource.go.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, true, templates...)
+}
+
+func (td *TemplateData) GenerateListResourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/list_resource.go.tmpl"
+	td.GenerateFile(filePath, templatePath, resource, true, templatePath)
+}
+
+func (td *TemplateData) GenerateListDatasourceDocumentationFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/list_datasource.html.markdown.tmpl"
+	templates := []string{
+		templatePath,
+	}
+	td.GenerateFile(filePath, templatePath, resource, false, templates...)
+}
+
 func (td *TemplateData) GenerateMetadataFile(filePath string, resource api.Resource) {
 	templatePath := "templates/terraform/metadata.yaml.tmpl"
 	templates := []string{
@@ -175,6 +230,16 @@ func (td *TemplateData) GenerateIamPolicyTestFile(filePath string, resource api.
 	td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
+func (td *TemplateData) GenerateStateLineageUpgradersTestFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/state_lineage_upgraders_test.go.tmpl"
+	td.GenerateFile(filePath, templatePath, resource, true, templatePath)
+}
+
+func (td *TemplateData) GenerateFakeResourceFile(filePath string, resource api.Resource) {
+	templatePath := "templates/terraform/fake_resource_test.go.tmpl"
+	td.GenerateFile(filePath, templatePath, resource, true, templatePath)
+}
+
 func (td *TemplateData) GenerateSweeperFile(filePath string, resource api.Resource) {
 	templatePath := "templates/terraform/sweeper_file.go.tmpl"
 	templates := []string{
@@ -200,9 +265,20 @@ func (td *TemplateData) GenerateTGCIamResourceFile(filePath string, resource api
 	td.GenerateFile(filePath, templatePath, resource, true, templates...)
 }
 
-func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, goFormat bool, templates ...string) {
+// Renders a template into filePath. Returns false if nothing was written,
+// either because the template rendered no content or because the file is
+// already up to date according to the generation cache.
+func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, goFormat bool, templates ...string) bool {
 	templateFileName := filepath.Base(templatePath)
 
+	var cacheKey string
+	if generationCache != nil {
+		cacheKey = generationCache.Key(templates, input)
+		if generationCache.UpToDate(filePath, cacheKey) {
+			return false
+		}
+	}
+
 	funcMap := template.FuncMap{
 		"TemplatePath": func() string { return templatePath },
 	}
@@ -222,7 +298,7 @@ func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, g
 
 	sourceByte := contents.Bytes()
 	if len(sourceByte) == 0 {
-		return
+		return false
 	}
 
 	if goFormat {
@@ -241,6 +317,11 @@ func (td *TemplateData) GenerateFile(filePath, templatePath string, input any, g
 	if err != nil {
 		glog.Exit(err)
 	}
+
+	if generationCache != nil {
+		generationCache.Generated(filePath, cacheKey)
+	}
+	return true
 }
 
 func (td *TemplateData) ImportPath() string {
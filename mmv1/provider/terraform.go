@@ -39,6 +39,8 @@ type Terraform struct {
 
 	IAMResourceCount int
 
+	DatasourceCount int
+
 	ResourcesForVersion []map[string]string
 
 	TargetVersionName string
@@ -54,6 +56,7 @@ func NewTerraform(product *api.Product, versionName string, startTime time.Time)
 	t := Terraform{
 		ResourceCount:     0,
 		IAMResourceCount:  0,
+		DatasourceCount:   0,
 		Product:           product,
 		TargetVersionName: versionName,
 		Version:           *product.VersionObjOrClosest(versionName),
@@ -100,11 +103,17 @@ func (t *Terraform) GenerateObject(object api.Resource, outputFolder, productPat
 	if !object.IsExcluded() {
 		log.Printf("Generating %s resource", object.Name)
 		t.GenerateResource(object, *templateData, outputFolder, generateCode, generateDocs)
+		t.GenerateDatasource(object, *templateData, outputFolder, generateCode, generateDocs)
+		t.GenerateListDatasource(object, *templateData, outputFolder, generateCode, generateDocs)
+		t.GenerateListResource(object, *templateData, outputFolder, generateCode)
+		t.GenerateApiTypes(object, *templateData, outputFolder, generateCode)
 
 		if generateCode {
 			// log.Printf("Generating %s tests", object.Name)
 			t.GenerateResourceTests(object, *templateData, outputFolder)
 			t.GenerateResourceSweeper(object, *templateData, outputFolder)
+			t.GenerateStateLineageUpgradersTests(object, *templateData, outputFolder)
+			t.GenerateFakeResource(object, *templateData, outputFolder)
 			// log.Printf("Generating %s metadata", object.Name)
 			t.GenerateResourceMetadata(object, *templateData, outputFolder)
 		}
@@ -126,7 +135,12 @@ func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateD
 			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
 		}
 		targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s.go", t.ResourceGoFilename(object)))
-		templateData.GenerateResourceFile(targetFilePath, object)
+		if object.FrameworkResource {
+			templateData.GenerateFrameworkResourceFile(targetFilePath, object)
+			t.GenerateFrameworkModel(object, templateData, outputFolder)
+		} else {
+			templateData.GenerateResourceFile(targetFilePath, object)
+		}
 	}
 
 	if generateDocs {
@@ -139,6 +153,100 @@ func (t *Terraform) GenerateResource(object api.Resource, templateData TemplateD
 	}
 }
 
+// Generates the singular data source of the resource, which reads an existing
+// resource through the read of the resource.
+func (t *Terraform) GenerateDatasource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	if object.Datasource == nil {
+		return
+	}
+
+	if generateCode {
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
+		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s.go", t.ResourceGoFilename(object)))
+		templateData.GenerateDatasourceFile(targetFilePath, object)
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "d")
+		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", t.FullResourceName(object)))
+		templateData.GenerateDatasourceDocumentationFile(targetFilePath, object)
+	}
+}
+
+// Generates the plural data source of the resource, which lists the resources
+// of its collection.
+func (t *Terraform) GenerateListDatasource(object api.Resource, templateData TemplateData, outputFolder string, generateCode, generateDocs bool) {
+	if object.ListDatasource == nil {
+		return
+	}
+	datasourceName := strings.TrimPrefix(object.ListDatasourceName(), "google_")
+
+	if generateCode {
+		targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
+		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("data_source_%s.go", datasourceName))
+		templateData.GenerateListDatasourceFile(targetFilePath, object)
+	}
+
+	if generateDocs {
+		targetFolder := path.Join(outputFolder, "website", "docs", "d")
+		if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+			log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+		}
+		targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s.html.markdown", datasourceName))
+		templateData.GenerateListDatasourceDocumentationFile(targetFilePath, object)
+	}
+}
+
+// Generates the list resource of the resource, which `terraform query` uses to
+// find its existing objects.
+func (t *Terraform) GenerateListResource(object api.Resource, templateData TemplateData, outputFolder string, generateCode bool) {
+	if !generateCode || !object.HasListResource() {
+		return
+	}
+
+	targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
+	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+	}
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_list.go", t.ResourceGoFilename(object)))
+	templateData.GenerateListResourceFile(targetFilePath, object)
+}
+
+// Generates the typed API structs of a resource with generate_api_types, with
+// their typed expanders and flatteners.
+func (t *Terraform) GenerateApiTypes(object api.Resource, templateData TemplateData, outputFolder string, generateCode bool) {
+	if !generateCode || !object.GenerateApiTypes {
+		return
+	}
+
+	targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
+	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+	}
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_api_types.go", t.ResourceGoFilename(object)))
+	templateData.GenerateApiTypesFile(targetFilePath, object)
+}
+
+// Generates the typed model of a plugin-framework resource, which is shared
+// with the provider through the fwmodels package.
+func (t *Terraform) GenerateFrameworkModel(object api.Resource, templateData TemplateData, outputFolder string) {
+	targetFolder := path.Join(outputFolder, t.FolderName(), "fwmodels")
+	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+	}
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_model.go", t.ResourceGoFilename(object)))
+	templateData.GenerateFrameworkModelFile(targetFilePath, object)
+}
+
 func (t *Terraform) GenerateResourceMetadata(object api.Resource, templateData TemplateData, outputFolder string) {
 	productName := t.Product.ApiName
 	targetFolder := path.Join(outputFolder, t.FolderName(), "services", productName)
@@ -172,6 +280,37 @@ func (t *Terraform) GenerateResourceTests(object api.Resource, templateData Temp
 	templateData.GenerateTestFile(targetFilePath, object)
 }
 
+// Generates the unit tests of the state upgraders generated from the state
+// lineage of the properties.
+func (t *Terraform) GenerateStateLineageUpgradersTests(object api.Resource, templateData TemplateData, outputFolder string) {
+	if object.FrameworkResource || len(object.StateLineageUpgrades()) == 0 {
+		return
+	}
+
+	targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
+	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+	}
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_state_upgraders_generated_test.go", t.ResourceGoFilename(object)))
+	templateData.GenerateStateLineageUpgradersTestFile(targetFilePath, object)
+}
+
+// Generates the registration of the resource with the fake GCP API server
+// used by offline tests. Resources nested in the body of a parent resource
+// aren't supported by the fake server.
+func (t *Terraform) GenerateFakeResource(object api.Resource, templateData TemplateData, outputFolder string) {
+	if object.NestedQuery != nil {
+		return
+	}
+
+	targetFolder := path.Join(outputFolder, t.FolderName(), "services", t.Product.ApiName)
+	if err := os.MkdirAll(targetFolder, os.ModePerm); err != nil {
+		log.Println(fmt.Errorf("error creating parent directory %v: %v", targetFolder, err))
+	}
+	targetFilePath := path.Join(targetFolder, fmt.Sprintf("resource_%s_fake_generated_test.go", t.ResourceGoFilename(object)))
+	templateData.GenerateFakeResourceFile(targetFilePath, object)
+}
+
 func (t *Terraform) GenerateResourceSweeper(object api.Resource, templateData TemplateData, outputFolder string) {
 	if !object.ShouldGenerateSweepers() {
 		return
@@ -327,7 +466,7 @@ func (t Terraform) getCommonCopyFiles(versionName string, generateCode, generate
 	// save the folder name to foldersCopiedToGoogleDir
 	var foldersCopiedToGoogleDir []string
 	if generateCode {
-		foldersCopiedToGoogleDir = []string{"third_party/terraform/services", "third_party/terraform/acctest", "third_party/terraform/sweeper", "third_party/terraform/provider", "third_party/terraform/tpgdclresource", "third_party/terraform/tpgiamresource", "third_party/terraform/tpgresource", "third_party/terraform/transport", "third_party/terraform/fwmodels", "third_party/terraform/fwprovider", "third_party/terraform/fwtransport", "third_party/terraform/fwresource", "third_party/terraform/fwutils", "third_party/terraform/fwvalidators", "third_party/terraform/verify", "third_party/terraform/envvar", "third_party/terraform/functions", "third_party/terraform/test-fixtures"}
+		foldersCopiedToGoogleDir = []string{"third_party/terraform/services", "third_party/terraform/acctest", "third_party/terraform/sweeper", "third_party/terraform/provider", "third_party/terraform/tpgdclresource", "third_party/terraform/tpgiamresource", "third_party/terraform/tpgresource", "third_party/terraform/transport", "third_party/terraform/fwmodels", "third_party/terraform/fwprovider", "third_party/terraform/fwtransport", "third_party/terraform/fwresource", "third_party/terraform/fwutils", "third_party/terraform/fwvalidators", "third_party/terraform/fakegcp", "third_party/terraform/verify", "third_party/terraform/envvar", "third_party/terraform/functions", "third_party/terraform/test-fixtures"}
 	}
 	googleDir := "google"
 	if versionName != "ga" {
@@ -501,9 +640,9 @@ func (t Terraform) CompileFileList(outputFolder string, files map[string]string,
 
 		formatFile := filepath.Ext(targetFile) == ".go"
 
-		fileTemplate.GenerateFile(targetFile, source, providerWithProducts, formatFile, templates...)
-		// continue to next file if no file was generated
-		if _, err := os.Stat(targetFile); errors.Is(err, os.ErrNotExist) {
+		// continue to next file if no file was generated, or if it was already
+		// up to date and has been post-processed by a previous run
+		if !fileTemplate.GenerateFile(targetFile, source, providerWithProducts, formatFile, templates...) {
 			continue
 		}
 		t.replaceImportPath(outputFolder, target)
@@ -701,11 +840,30 @@ func (t *Terraform) generateResourcesForVersion(products []*api.Product) {
 				continue
 			}
 
-			var resourceName string
+			var resourceName, frameworkResourceName, listResourceFuncName, datasourceFuncName, listDatasourceName, listDatasourceFuncName string
 
 			if !object.IsExcluded() {
 				t.ResourceCount++
-				resourceName = fmt.Sprintf("%s.Resource%s", service, object.ResourceName())
+				if object.FrameworkResource {
+					frameworkResourceName = fmt.Sprintf("%s.New%sResource", service, object.ResourceName())
+				} else {
+					resourceName = fmt.Sprintf("%s.Resource%s", service, object.ResourceName())
+				}
+
+				if object.HasListResource() {
+					listResourceFuncName = fmt.Sprintf("%s.%s", service, object.ListResourceFuncName())
+				}
+
+				if object.Datasource != nil {
+					t.DatasourceCount++
+					datasourceFuncName = fmt.Sprintf("%s.DataSource%s", service, object.ResourceName())
+				}
+
+				if object.ListDatasource != nil {
+					t.DatasourceCount++
+					listDatasourceName = object.ListDatasourceName()
+					listDatasourceFuncName = fmt.Sprintf("%s.%s", service, object.ListDatasourceFuncName())
+				}
 			}
 
 			var iamClassName string
@@ -719,14 +877,38 @@ func (t *Terraform) generateResourcesForVersion(products []*api.Product) {
 			}
 
 			t.ResourcesForVersion = append(t.ResourcesForVersion, map[string]string{
-				"TerraformName": object.TerraformName(),
-				"ResourceName":  resourceName,
-				"IamClassName":  iamClassName,
+				"TerraformName":          object.TerraformName(),
+				"ResourceName":           resourceName,
+				"FrameworkResourceName":  frameworkResourceName,
+				"ListResourceFuncName":   listResourceFuncName,
+				"DatasourceFuncName":     datasourceFuncName,
+				"ListDatasourceName":     listDatasourceName,
+				"ListDatasourceFuncName": listDatasourceFuncName,
+				"IamClassName":           iamClassName,
 			})
 		}
 	}
 }
 
+// Returns the service packages of the generated plugin-framework resources
+// and list resources that the framework provider doesn't import already.
+func (t Terraform) FrameworkResourceServices() []string {
+	var services []string
+	for _, object := range t.ResourcesForVersion {
+		for _, key := range []string{"FrameworkResourceName", "ListResourceFuncName"} {
+			service, _, found := strings.Cut(object[key], ".")
+			if !found || service == "resourcemanager" || (service == "firebase" && t.TargetVersionName != "ga") {
+				continue
+			}
+			if !slices.Contains(services, service) {
+				services = append(services, service)
+			}
+		}
+	}
+	slices.Sort(services)
+	return services
+}
+
 // # Adapted from the method used in templating
 // # See: mmv1/compile/core.rb
 func commentBlock(text []string, lang string) string {
@@ -125,6 +125,13 @@ func (tgc TerraformGoogleConversion) GenerateResource(object api.Resource, templ
 
 	targetFilePath := path.Join(targetFolder, fmt.Sprintf("%s_%s.go", productName, google.Underscore(object.Name)))
 	templateData.GenerateTGCResourceFile(targetFilePath, object)
+
+	// Converters can build the typed API objects of the resource with its
+	// expanders
+	if object.GenerateApiTypes {
+		targetFilePath = path.Join(targetFolder, fmt.Sprintf("%s_%s_api_types.go", productName, google.Underscore(object.Name)))
+		templateData.GenerateApiTypesFile(targetFilePath, object)
+	}
 }
 
 // Generate the IAM policy for this object. This is used to query and test
@@ -230,7 +237,9 @@ func (tgc TerraformGoogleConversion) CompileFileList(outputFolder string, files
 
 		formatFile := filepath.Ext(targetFile) == ".go"
 
-		fileTemplate.GenerateFile(targetFile, source, tgc, formatFile, templates...)
+		if !fileTemplate.GenerateFile(targetFile, source, tgc, formatFile, templates...) {
+			continue
+		}
 		tgc.replaceImportPath(outputFolder, target)
 	}
 }
@@ -114,7 +114,9 @@ func (tgc TerraformGoogleConversionNext) CompileFileList(outputFolder string, fi
 
 		formatFile := filepath.Ext(targetFile) == ".go"
 
-		fileTemplate.GenerateFile(targetFile, source, tgc, formatFile, templates...)
+		if !fileTemplate.GenerateFile(targetFile, source, tgc, formatFile, templates...) {
+			continue
+		}
 		tgc.replaceImportPath(outputFolder, target)
 	}
 }
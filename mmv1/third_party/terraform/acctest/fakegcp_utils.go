@@ -0,0 +1,66 @@
+package acctest
+
+import (
+	"os"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+
+	"github.com/hashicorp/terraform-provider-google/google/envvar"
+	"github.com/hashicorp/terraform-provider-google/google/fakegcp"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+// The access token of the providers of offline tests, which the fake server
+// doesn't check
+const fakeGcpAccessToken = "fake-access-token"
+
+// FakeGcpTest runs a test case against a fakegcp server for the resources
+// registered in the test's package, instead of GCP. The providers of the test
+// point the custom endpoints of the resources' products at the server and use
+// a placeholder access token, so the test runs without credentials. As the
+// providers are configured through environment variables, the test can't be
+// parallel.
+//
+// Offline tests run under a plain `go test`, without TF_ACC, but need a
+// terraform binary, either on the PATH or at TF_ACC_TERRAFORM_PATH.
+func FakeGcpTest(t *testing.T, c resource.TestCase) {
+	t.Helper()
+
+	if IsVcrEnabled() {
+		t.Skip("Offline tests don't send requests to GCP, so there is nothing to record or replay")
+	}
+	if os.Getenv("TF_ACC_TERRAFORM_PATH") == "" {
+		if _, err := exec.LookPath("terraform"); err != nil {
+			t.Fatalf("Offline tests need a terraform binary on the PATH or at TF_ACC_TERRAFORM_PATH: %s", err)
+		}
+	}
+	// resource.Test skips test cases unless TF_ACC is set
+	t.Setenv("TF_ACC", "1")
+
+	s := fakegcp.NewServer(t)
+	for field, endpoint := range s.CustomEndpoints() {
+		// eg: GOOGLE_PUBSUB_CUSTOM_ENDPOINT
+		t.Setenv("GOOGLE_"+strings.ToUpper(field), endpoint)
+	}
+
+	for _, v := range envvar.CredsEnvVars {
+		t.Setenv(v, "")
+	}
+	t.Setenv("GOOGLE_OAUTH_ACCESS_TOKEN", fakeGcpAccessToken)
+	setFakeGcpEnvDefault(t, envvar.ProjectEnvVars, "fake-project")
+	setFakeGcpEnvDefault(t, envvar.RegionEnvVars, "us-central1")
+	setFakeGcpEnvDefault(t, envvar.ZoneEnvVars, "us-central1-a")
+
+	resource.Test(t, ignoreTerraformLabels(c))
+}
+
+// setFakeGcpEnvDefault sets the first of vars to value, unless one of them is
+// already set.
+func setFakeGcpEnvDefault(t *testing.T, vars []string, value string) {
+	if transport_tpg.MultiEnvSearch(vars) == "" {
+		t.Setenv(vars[0], value)
+	}
+}
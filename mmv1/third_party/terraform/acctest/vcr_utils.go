@@ -148,10 +148,14 @@ func VcrTest(t *testing.T, c resource.TestCase) {
 		c = initializeReleaseDiffTest(c, t.Name())
 	}
 
-	// terraform_labels is a computed field to which "goog-terraform-provisioned": "true" is always
-	// added by the provider. ImportStateVerify "checks for strict equality and does not respect
-	// DiffSuppressFunc or CustomizeDiff" so any test using ImportStateVerify must ignore
-	// terraform_labels.
+	resource.Test(t, ignoreTerraformLabels(c))
+}
+
+// terraform_labels is a computed field to which "goog-terraform-provisioned": "true" is always
+// added by the provider. ImportStateVerify "checks for strict equality and does not respect
+// DiffSuppressFunc or CustomizeDiff" so any test using ImportStateVerify must ignore
+// terraform_labels.
+func ignoreTerraformLabels(c resource.TestCase) resource.TestCase {
 	var steps []resource.TestStep
 	for _, s := range c.Steps {
 		if s.ImportStateVerify && !slices.Contains(s.ImportStateVerifyIgnore, "terraform_labels") {
@@ -160,8 +164,7 @@ func VcrTest(t *testing.T, c resource.TestCase) {
 		steps = append(steps, s)
 	}
 	c.Steps = steps
-
-	resource.Test(t, c)
+	return c
 }
 
 // We need to explicitly close the VCR recorder to save the cassette
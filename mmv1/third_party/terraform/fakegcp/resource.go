@@ -0,0 +1,209 @@
+// Package fakegcp is an in-process fake of the GCP APIs of generated
+// resources, for running provider tests without network access or
+// credentials. It implements generic create, read, update, delete and list
+// methods and long-running operations, driven by the URLs, identity, async
+// and update mask behaviour of each resource emitted by MMv1.
+package fakegcp
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Resource describes the API of a resource, as emitted by MMv1. URLs are
+// relative to BaseUrl and use the same {{param}} placeholders as the
+// generated code, eg: projects/{{project}}/topics/{{name}}
+type Resource struct {
+	// The Terraform type of the resource, eg: google_pubsub_topic
+	Name string
+	// The product of the resource, as in its custom endpoint field, eg:
+	// pubsub for pubsub_custom_endpoint
+	Product string
+	// The base URL of the product, eg: https://pubsub.googleapis.com/v1/
+	BaseUrl string
+
+	SelfLink      string
+	CollectionUrl string
+	// The key of the resources in the response of the collection URL
+	CollectionKey string
+	CreateUrl     string
+	CreateVerb    string
+	UpdateUrl     string
+	UpdateVerb    string
+	DeleteUrl     string
+	DeleteVerb    string
+
+	// The fields that identify the resource, used to find the URL of a
+	// created resource when they aren't part of the create URL
+	Identity []IdentityField
+	// Whether updates only change the fields in the updateMask query
+	// parameter
+	UpdateMask bool
+	// Async is set if the resource's create, update or delete methods return
+	// long-running operations.
+	Async *Async
+}
+
+// IdentityField maps a URL placeholder of a resource to its API field.
+type IdentityField struct {
+	Param string
+	Field string
+}
+
+// Async describes the long-running operations of a resource.
+type Async struct {
+	// The methods returning operations: create, update or delete
+	Actions []string
+	// The URL of an operation, eg: {{op_id}}
+	OperationBaseUrl string
+	// Whether the resource is returned in the response of the operation
+	ResourceInsideResponse bool
+}
+
+func (a *Async) allow(action string) bool {
+	if a == nil {
+		return false
+	}
+	for _, v := range a.Actions {
+		if v == action {
+			return true
+		}
+	}
+	return false
+}
+
+var (
+	resourcesMu sync.Mutex
+	resources   = make(map[string]*Resource)
+)
+
+// AddResource registers a resource to be served by the fake servers created
+// without resources. Generated resources register themselves in their
+// package's tests.
+func AddResource(r *Resource) {
+	resourcesMu.Lock()
+	defer resourcesMu.Unlock()
+	resources[r.Name] = r
+}
+
+// Resources returns the registered resources, sorted by name.
+func Resources() []*Resource {
+	resourcesMu.Lock()
+	defer resourcesMu.Unlock()
+
+	var rs []*Resource
+	for _, r := range resources {
+		rs = append(rs, r)
+	}
+	sort.Slice(rs, func(i, j int) bool {
+		return rs[i].Name < rs[j].Name
+	})
+	return rs
+}
+
+// The path of the endpoint of a product on the fake server. Each product has
+// its own prefix, as the paths of different APIs can collide.
+func endpointPath(product, baseUrl string) string {
+	path := "/"
+	// Base URLs can contain placeholders, eg: https://{{region}}-run.googleapis.com/
+	if i := strings.Index(baseUrl, "://"); i >= 0 {
+		baseUrl = baseUrl[i+len("://"):]
+	}
+	if i := strings.Index(baseUrl, "/"); i >= 0 {
+		path = baseUrl[i:]
+	}
+	if !strings.HasSuffix(path, "/") {
+		path += "/"
+	}
+	return "/" + product + path
+}
+
+var placeholderRegex = regexp.MustCompile(`{{%?([[:word:]]+)}}`)
+
+// urlTemplate matches request URLs against the URL of a resource.
+type urlTemplate struct {
+	raw    string
+	regex  *regexp.Regexp
+	params []string
+	// The placeholders in the query of the URL, by query parameter
+	queryParams map[string]string
+}
+
+func newUrlTemplate(raw string) *urlTemplate {
+	path, query, _ := strings.Cut(raw, "?")
+	t := &urlTemplate{
+		raw:         raw,
+		queryParams: make(map[string]string),
+	}
+
+	var pattern strings.Builder
+	pattern.WriteString("^")
+	last := 0
+	for _, m := range placeholderRegex.FindAllStringSubmatchIndex(path, -1) {
+		pattern.WriteString(regexp.QuoteMeta(path[last:m[0]]))
+		// {{%param}} placeholders can contain slashes
+		if strings.HasPrefix(path[m[0]:], "{{%") {
+			pattern.WriteString("(.+)")
+		} else {
+			pattern.WriteString("([^/]+)")
+		}
+		t.params = append(t.params, path[m[2]:m[3]])
+		last = m[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(path[last:]))
+	pattern.WriteString("$")
+	t.regex = regexp.MustCompile(pattern.String())
+
+	if values, err := url.ParseQuery(query); err == nil {
+		for k, v := range values {
+			if len(v) > 0 {
+				if m := placeholderRegex.FindStringSubmatch(v[0]); m != nil {
+					t.queryParams[k] = m[1]
+				}
+			}
+		}
+	}
+	return t
+}
+
+// match returns the values of the placeholders of the template in a request
+// path and query, or false if the path doesn't match.
+func (t *urlTemplate) match(path string, query url.Values) (map[string]string, bool) {
+	m := t.regex.FindStringSubmatch(path)
+	if m == nil {
+		return nil, false
+	}
+	params := make(map[string]string)
+	for i, p := range t.params {
+		params[p] = m[i+1]
+	}
+	for k, p := range t.queryParams {
+		if v := query.Get(k); v != "" {
+			params[p] = v
+		}
+	}
+	return params, true
+}
+
+// expand fills the placeholders of the path of the template, or returns an
+// error naming the first placeholder without a value.
+func (t *urlTemplate) expand(value func(param string, multiSegment bool) (string, bool)) (string, error) {
+	path, _, _ := strings.Cut(t.raw, "?")
+	var missing string
+	expanded := placeholderRegex.ReplaceAllStringFunc(path, func(s string) string {
+		m := placeholderRegex.FindStringSubmatch(s)
+		v, ok := value(m[1], strings.HasPrefix(s, "{{%"))
+		if !ok && missing == "" {
+			missing = m[1]
+		}
+		return v
+	})
+	if missing != "" {
+		return "", fmt.Errorf("no value for {{%s}} of %s", missing, t.raw)
+	}
+	return expanded, nil
+}
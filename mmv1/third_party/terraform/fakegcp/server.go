@@ -0,0 +1,405 @@
+package fakegcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// The value of the identity fields of created resources the API would
+// assign, eg: fake-1
+const generatedIdPrefix = "fake-"
+
+type servedResource struct {
+	*Resource
+	prefix     string
+	selfLink   *urlTemplate
+	collection *urlTemplate
+	create     *urlTemplate
+	update     *urlTemplate
+	delete     *urlTemplate
+}
+
+type object struct {
+	res    *servedResource
+	fields map[string]interface{}
+}
+
+// Server is a fake of the GCP APIs of a set of resources, storing the
+// resources created through it in memory. Operations are done as soon as
+// they are returned.
+type Server struct {
+	*httptest.Server
+
+	mu         sync.Mutex
+	resources  []*servedResource
+	objects    map[string]*object
+	operations map[string]map[string]interface{}
+	nextId     int
+}
+
+// NewServer starts a fake server for the given resources, or for every
+// registered resource if none are given. It is closed at the end of the
+// test.
+func NewServer(t testing.TB, rs ...*Resource) *Server {
+	if len(rs) == 0 {
+		rs = Resources()
+	}
+
+	s := &Server{
+		objects:    make(map[string]*object),
+		operations: make(map[string]map[string]interface{}),
+	}
+	for _, r := range rs {
+		s.resources = append(s.resources, &servedResource{
+			Resource:   r,
+			prefix:     endpointPath(r.Product, r.BaseUrl),
+			selfLink:   newUrlTemplate(r.SelfLink),
+			collection: newUrlTemplate(r.CollectionUrl),
+			create:     newUrlTemplate(r.CreateUrl),
+			update:     newUrlTemplate(r.UpdateUrl),
+			delete:     newUrlTemplate(r.DeleteUrl),
+		})
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	t.Cleanup(s.Close)
+	return s
+}
+
+// Endpoint returns the base URL of a product on the server, to use as its
+// custom endpoint.
+func (s *Server) Endpoint(product string) string {
+	for _, res := range s.resources {
+		if res.Product == product {
+			return s.URL + res.prefix
+		}
+	}
+	return s.URL + "/" + product + "/"
+}
+
+// CustomEndpoints returns the custom endpoint fields of the provider that
+// point the products of the served resources at the server, eg:
+// pubsub_custom_endpoint
+func (s *Server) CustomEndpoints() map[string]string {
+	endpoints := make(map[string]string)
+	for _, res := range s.resources {
+		endpoints[res.Product+"_custom_endpoint"] = s.URL + res.prefix
+	}
+	return endpoints
+}
+
+// Object returns the stored fields of a resource, by its path relative to
+// the endpoint of its product.
+func (s *Server) Object(product, path string) (map[string]interface{}, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	o, ok := s.objects[strings.TrimPrefix(s.Endpoint(product), s.URL)+path]
+	if !ok {
+		return nil, false
+	}
+	return o.fields, true
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	body := make(map[string]interface{})
+	if raw, err := io.ReadAll(r.Body); err != nil || (len(raw) > 0 && json.Unmarshal(raw, &body) != nil) {
+		writeError(w, http.StatusBadRequest, "INVALID_ARGUMENT", "the request body is not a JSON object")
+		return
+	}
+
+	status, resp := s.serve(r.Method, r.URL.Path, r.URL.Query(), body)
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(resp); err != nil {
+		panic(fmt.Sprintf("encoding the response of %s %s: %s", r.Method, r.URL.Path, err))
+	}
+}
+
+func (s *Server) serve(method, path string, query url.Values, body map[string]interface{}) (int, interface{}) {
+	if op, ok := s.operation(path); ok {
+		return http.StatusOK, op
+	}
+	if o, ok := s.objects[path]; ok && method == "GET" {
+		return http.StatusOK, o.fields
+	}
+
+	for _, res := range s.resources {
+		rel, ok := strings.CutPrefix(path, res.prefix)
+		if !ok {
+			continue
+		}
+
+		if method == "GET" {
+			if _, ok := res.selfLink.match(rel, query); ok {
+				return errorResponse(http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("%s %q not found", res.Name, rel))
+			}
+			if _, ok := res.collection.match(rel, query); ok {
+				return s.list(res, path)
+			}
+			continue
+		}
+
+		// PUT and PATCH to the URL of a resource can be a create or an update
+		if method == res.UpdateVerb {
+			if params, ok := res.update.match(rel, query); ok {
+				if objectPath, err := s.objectPath(res, params, body); err == nil {
+					if _, ok := s.objects[objectPath]; ok {
+						return s.updateObject(objectPath, method, query, body)
+					}
+				}
+			}
+		}
+		if method == res.CreateVerb {
+			if params, ok := res.create.match(rel, query); ok {
+				return s.createObject(res, params, body)
+			}
+		}
+		if method == res.DeleteVerb {
+			if params, ok := res.delete.match(rel, query); ok {
+				return s.deleteObject(res, params, body)
+			}
+		}
+	}
+
+	// Fields updated by a method of the resource, eg: projects/p/topics/t:setLabels
+	if method != "GET" && method != "DELETE" {
+		objectPath := ""
+		for p := range s.objects {
+			action, ok := strings.CutPrefix(path, p)
+			if ok && (strings.HasPrefix(action, ":") || strings.HasPrefix(action, "/")) && len(p) > len(objectPath) {
+				objectPath = p
+			}
+		}
+		if o, ok := s.objects[objectPath]; ok {
+			for k, v := range body {
+				o.fields[k] = v
+			}
+			return s.respond(o.res, "update", objectPath, o.fields)
+		}
+	}
+
+	return errorResponse(http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("no fake of %s %s", method, path))
+}
+
+// objectPath returns the path of the resource, from the placeholders of the
+// request URL or its identity fields in the body.
+func (s *Server) objectPath(res *servedResource, params map[string]string, body map[string]interface{}) (string, error) {
+	rel, err := res.selfLink.expand(func(param string, multiSegment bool) (string, bool) {
+		if v := params[param]; v != "" {
+			return v, true
+		}
+		v, ok := body[identityField(res, param)].(string)
+		if !ok || v == "" {
+			return "", false
+		}
+		// Identity fields can be the full names of resources
+		if !multiSegment {
+			v = v[strings.LastIndex(v, "/")+1:]
+		}
+		return v, true
+	})
+	if err != nil {
+		return "", err
+	}
+	return res.prefix + rel, nil
+}
+
+func identityField(res *servedResource, param string) string {
+	for _, f := range res.Identity {
+		if f.Param == param {
+			return f.Field
+		}
+	}
+	return camelize(param)
+}
+
+func (s *Server) createObject(res *servedResource, params map[string]string, body map[string]interface{}) (int, interface{}) {
+	// Identity fields are returned by the API, which assigns the ones not set
+	// by the request
+	for _, f := range res.Identity {
+		if body[f.Field] != nil {
+			continue
+		}
+		if v := params[f.Param]; v != "" {
+			body[f.Field] = v
+			continue
+		}
+		s.nextId++
+		body[f.Field] = fmt.Sprintf("%s%d", generatedIdPrefix, s.nextId)
+	}
+
+	objectPath, err := s.objectPath(res, params, body)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+	}
+	if _, ok := s.objects[objectPath]; ok {
+		return errorResponse(http.StatusConflict, "ALREADY_EXISTS", fmt.Sprintf("%s %q already exists", res.Name, objectPath))
+	}
+
+	s.objects[objectPath] = &object{res: res, fields: body}
+	return s.respond(res, "create", objectPath, body)
+}
+
+func (s *Server) updateObject(objectPath, method string, query url.Values, body map[string]interface{}) (int, interface{}) {
+	o := s.objects[objectPath]
+	switch mask := query.Get("updateMask"); {
+	case o.res.UpdateMask && mask != "":
+		for _, field := range strings.Split(mask, ",") {
+			var path []string
+			for _, f := range strings.Split(field, ".") {
+				path = append(path, camelize(f))
+			}
+			applyUpdateMaskPath(o.fields, body, path)
+		}
+	case method == "PUT":
+		o.fields = body
+	default:
+		for k, v := range body {
+			o.fields[k] = v
+		}
+	}
+	return s.respond(o.res, "update", objectPath, o.fields)
+}
+
+// applyUpdateMaskPath sets the field at path from src, or clears it if src
+// doesn't have it.
+func applyUpdateMaskPath(dst, src map[string]interface{}, path []string) {
+	k := path[0]
+	if len(path) == 1 {
+		if v, ok := src[k]; ok {
+			dst[k] = v
+		} else {
+			delete(dst, k)
+		}
+		return
+	}
+
+	srcChild, _ := src[k].(map[string]interface{})
+	dstChild, ok := dst[k].(map[string]interface{})
+	if !ok {
+		if srcChild == nil {
+			return
+		}
+		dstChild = make(map[string]interface{})
+		dst[k] = dstChild
+	}
+	if srcChild == nil {
+		srcChild = make(map[string]interface{})
+	}
+	applyUpdateMaskPath(dstChild, srcChild, path[1:])
+}
+
+func (s *Server) deleteObject(res *servedResource, params map[string]string, body map[string]interface{}) (int, interface{}) {
+	objectPath, err := s.objectPath(res, params, body)
+	if err != nil {
+		return errorResponse(http.StatusBadRequest, "INVALID_ARGUMENT", err.Error())
+	}
+	if _, ok := s.objects[objectPath]; !ok {
+		return errorResponse(http.StatusNotFound, "NOT_FOUND", fmt.Sprintf("%s %q not found", res.Name, objectPath))
+	}
+
+	delete(s.objects, objectPath)
+	return s.respond(res, "delete", objectPath, nil)
+}
+
+func (s *Server) list(res *servedResource, collectionPath string) (int, interface{}) {
+	var paths []string
+	for p, o := range s.objects {
+		if o.res == res && strings.HasPrefix(p, collectionPath+"/") {
+			paths = append(paths, p)
+		}
+	}
+	sort.Strings(paths)
+
+	resp := make(map[string]interface{})
+	if len(paths) > 0 {
+		var items []interface{}
+		for _, p := range paths {
+			items = append(items, s.objects[p].fields)
+		}
+		resp[res.CollectionKey] = items
+	}
+	return http.StatusOK, resp
+}
+
+// respond returns the resource, or an operation if the action of the
+// resource is async.
+func (s *Server) respond(res *servedResource, action, objectPath string, fields map[string]interface{}) (int, interface{}) {
+	if !res.Async.allow(action) {
+		if fields == nil {
+			return http.StatusOK, map[string]interface{}{}
+		}
+		return http.StatusOK, fields
+	}
+
+	s.nextId++
+	id := fmt.Sprintf("operation-%d", s.nextId)
+	name := id
+	if strings.HasPrefix(res.Async.OperationBaseUrl, "{{op_id}}") {
+		name = "operations/" + id
+	}
+	op := map[string]interface{}{
+		"name":       name,
+		"done":       true,
+		"status":     "DONE",
+		"targetLink": s.URL + objectPath,
+	}
+	if res.Async.ResourceInsideResponse && fields != nil {
+		op["response"] = fields
+	}
+	s.operations[id] = op
+	return http.StatusOK, op
+}
+
+// operation returns the operation polled at path, eg:
+// projects/p/global/operations/operation-1 or operations/operation-1:wait
+func (s *Server) operation(path string) (map[string]interface{}, bool) {
+	i := strings.LastIndex(path, "/operations/")
+	if i < 0 {
+		return nil, false
+	}
+	id := path[i+len("/operations/"):]
+	id = strings.TrimSuffix(strings.TrimSuffix(id, "/wait"), ":wait")
+	op, ok := s.operations[id]
+	return op, ok
+}
+
+func errorResponse(code int, status, message string) (int, interface{}) {
+	return code, map[string]interface{}{
+		"error": map[string]interface{}{
+			"code":    code,
+			"message": message,
+			"status":  status,
+		},
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, status, message string) {
+	code, resp := errorResponse(code, status, message)
+	w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// camelize converts the snake_case names of URL placeholders and update
+// mask fields to the lowerCamelCase of API fields.
+func camelize(s string) string {
+	parts := strings.Split(s, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
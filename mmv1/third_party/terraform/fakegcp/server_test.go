@@ -0,0 +1,225 @@
+package fakegcp
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"reflect"
+	"testing"
+)
+
+var testWidget = &Resource{
+	Name:          "google_test_widget",
+	Product:       "widgets",
+	BaseUrl:       "https://widgets.googleapis.com/v1/",
+	SelfLink:      "projects/{{project}}/locations/{{location}}/widgets/{{name}}",
+	CollectionUrl: "projects/{{project}}/locations/{{location}}/widgets",
+	CollectionKey: "widgets",
+	CreateUrl:     "projects/{{project}}/locations/{{location}}/widgets?widgetId={{name}}",
+	CreateVerb:    "POST",
+	UpdateUrl:     "projects/{{project}}/locations/{{location}}/widgets/{{name}}",
+	UpdateVerb:    "PATCH",
+	DeleteUrl:     "projects/{{project}}/locations/{{location}}/widgets/{{name}}",
+	DeleteVerb:    "DELETE",
+	Identity:      []IdentityField{{Param: "name", Field: "name"}},
+	UpdateMask:    true,
+	Async: &Async{
+		Actions:                []string{"create", "delete", "update"},
+		OperationBaseUrl:       "{{op_id}}",
+		ResourceInsideResponse: true,
+	},
+}
+
+var testGadget = &Resource{
+	Name:          "google_test_gadget",
+	Product:       "gadgets",
+	BaseUrl:       "https://gadgets.googleapis.com/gadgets/v1/",
+	SelfLink:      "projects/{{project}}/global/gadgets/{{gadget_id}}",
+	CollectionUrl: "projects/{{project}}/global/gadgets",
+	CollectionKey: "items",
+	CreateUrl:     "projects/{{project}}/global/gadgets",
+	CreateVerb:    "POST",
+	UpdateUrl:     "projects/{{project}}/global/gadgets/{{gadget_id}}",
+	UpdateVerb:    "PUT",
+	DeleteUrl:     "projects/{{project}}/global/gadgets/{{gadget_id}}",
+	DeleteVerb:    "DELETE",
+	Identity:      []IdentityField{{Param: "gadget_id", Field: "gadgetId"}},
+}
+
+func testServer_send(t *testing.T, method, url string, body interface{}) (int, map[string]interface{}) {
+	var reqBody bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&reqBody).Encode(body); err != nil {
+			t.Fatalf("unable to encode request body: %v", err)
+		}
+	}
+	req, err := http.NewRequest(method, url, &reqBody)
+	if err != nil {
+		t.Fatalf("unable to construct request: %v", err)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	defer resp.Body.Close()
+
+	var respBody map[string]interface{}
+	if err := json.NewDecoder(resp.Body).Decode(&respBody); err != nil {
+		t.Fatalf("expected a JSON response to %s %s: %v", method, url, err)
+	}
+	return resp.StatusCode, respBody
+}
+
+// Check the lifecycle of a resource with long-running operations and update
+// masks
+func TestServer_AsyncResource(t *testing.T) {
+	s := NewServer(t, testWidget)
+	collection := s.Endpoint("widgets") + "projects/p/locations/l/widgets"
+	widget := collection + "/w"
+
+	code, op := testServer_send(t, "POST", collection+"?widgetId=w", map[string]interface{}{
+		"description": "a widget",
+		"labels":      map[string]interface{}{"env": "test"},
+	})
+	if code != http.StatusOK || op["done"] != true {
+		t.Fatalf("expected a done operation, got %d: %v", code, op)
+	}
+	if resp, _ := op["response"].(map[string]interface{}); resp["description"] != "a widget" {
+		t.Errorf("expected the widget inside the operation response, got %v", op["response"])
+	}
+	if code, polled := testServer_send(t, "GET", s.Endpoint("widgets")+op["name"].(string), nil); code != http.StatusOK || polled["name"] != op["name"] {
+		t.Errorf("expected the operation to be polled, got %d: %v", code, polled)
+	}
+	if code, _ := testServer_send(t, "POST", collection+"?widgetId=w", map[string]interface{}{}); code != http.StatusConflict {
+		t.Errorf("expected creating the widget again to conflict, got %d", code)
+	}
+
+	code, _ = testServer_send(t, "PATCH", widget+"?updateMask=description", map[string]interface{}{
+		"description": "an updated widget",
+		"labels":      map[string]interface{}{"env": "prod"},
+	})
+	if code != http.StatusOK {
+		t.Fatalf("expected the update to succeed, got %d", code)
+	}
+	code, got := testServer_send(t, "GET", widget, nil)
+	expected := map[string]interface{}{
+		"name":        "w",
+		"description": "an updated widget",
+		"labels":      map[string]interface{}{"env": "test"},
+	}
+	if code != http.StatusOK || !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected only the fields in the update mask to be updated, got %d: %v", code, got)
+	}
+
+	if code, list := testServer_send(t, "GET", collection, nil); code != http.StatusOK || len(list["widgets"].([]interface{})) != 1 {
+		t.Errorf("expected 1 widget to be listed, got %d: %v", code, list)
+	}
+
+	if code, op := testServer_send(t, "DELETE", widget, nil); code != http.StatusOK || op["done"] != true {
+		t.Errorf("expected a done operation, got %d: %v", code, op)
+	}
+	if code, _ := testServer_send(t, "GET", widget, nil); code != http.StatusNotFound {
+		t.Errorf("expected the deleted widget to not be found, got %d", code)
+	}
+	if code, list := testServer_send(t, "GET", collection, nil); code != http.StatusOK || len(list) != 0 {
+		t.Errorf("expected no widgets to be listed, got %d: %v", code, list)
+	}
+}
+
+// Check the lifecycle of a resource identified by a field of its body, whose
+// identity is assigned by the API if unset
+func TestServer_SyncResource(t *testing.T) {
+	s := NewServer(t, testWidget, testGadget)
+	collection := s.Endpoint("gadgets") + "projects/p/global/gadgets"
+
+	code, created := testServer_send(t, "POST", collection, map[string]interface{}{"size": "large"})
+	if code != http.StatusOK || created["gadgetId"] != generatedIdPrefix+"1" {
+		t.Fatalf("expected a gadget with a generated id, got %d: %v", code, created)
+	}
+	gadget := collection + "/" + generatedIdPrefix + "1"
+
+	if code, _ := testServer_send(t, "POST", gadget+"/setLabels", map[string]interface{}{"labels": map[string]interface{}{"env": "test"}}); code != http.StatusOK {
+		t.Errorf("expected the labels to be set, got %d", code)
+	}
+	if got, ok := s.Object("gadgets", "projects/p/global/gadgets/fake-1"); !ok || got["labels"] == nil || got["size"] != "large" {
+		t.Errorf("expected the labels to be merged into the gadget, got %v", got)
+	}
+
+	if code, _ := testServer_send(t, "PUT", gadget, map[string]interface{}{"gadgetId": "fake-1", "size": "small"}); code != http.StatusOK {
+		t.Errorf("expected the update to succeed, got %d", code)
+	}
+	if code, got := testServer_send(t, "GET", gadget, nil); code != http.StatusOK || !reflect.DeepEqual(got, map[string]interface{}{"gadgetId": "fake-1", "size": "small"}) {
+		t.Errorf("expected the gadget to be replaced, got %d: %v", code, got)
+	}
+
+	if code, _ := testServer_send(t, "GET", s.Endpoint("widgets")+"projects/p/global/gadgets/fake-1", nil); code != http.StatusNotFound {
+		t.Errorf("expected the gadget to not be found at the endpoint of another product, got %d", code)
+	}
+	if code, _ := testServer_send(t, "DELETE", gadget, nil); code != http.StatusOK {
+		t.Errorf("expected the delete to succeed, got %d", code)
+	}
+	if code, _ := testServer_send(t, "DELETE", gadget, nil); code != http.StatusNotFound {
+		t.Errorf("expected deleting the gadget again to not find it, got %d", code)
+	}
+}
+
+func TestServer_CustomEndpoints(t *testing.T) {
+	s := NewServer(t, testWidget, testGadget)
+
+	expected := map[string]string{
+		"widgets_custom_endpoint": s.URL + "/widgets/v1/",
+		"gadgets_custom_endpoint": s.URL + "/gadgets/gadgets/v1/",
+	}
+	if got := s.CustomEndpoints(); !reflect.DeepEqual(got, expected) {
+		t.Errorf("expected %v, got %v", expected, got)
+	}
+}
+
+func TestUrlTemplate(t *testing.T) {
+	cases := map[string]struct {
+		Template string
+		Path     string
+		Query    url.Values
+		Expected map[string]string
+		NoMatch  bool
+	}{
+		"path": {
+			Template: "projects/{{project}}/topics/{{name}}",
+			Path:     "projects/p/topics/t",
+			Expected: map[string]string{"project": "p", "name": "t"},
+		},
+		"query": {
+			Template: "projects/{{project}}/instances?instanceId={{instance_id}}",
+			Path:     "projects/p/instances",
+			Query:    url.Values{"instanceId": []string{"i"}},
+			Expected: map[string]string{"project": "p", "instance_id": "i"},
+		},
+		"multi segment": {
+			Template: "{{%name}}:setIamPolicy",
+			Path:     "projects/p/topics/t:setIamPolicy",
+			Expected: map[string]string{"name": "projects/p/topics/t"},
+		},
+		"single segment": {
+			Template: "projects/{{project}}/topics/{{name}}",
+			Path:     "projects/p/topics/t/subscriptions/s",
+			NoMatch:  true,
+		},
+		"literal": {
+			Template: "projects/{{project}}/topics",
+			Path:     "projects/p/subscriptions",
+			NoMatch:  true,
+		},
+	}
+
+	for tn, tc := range cases {
+		params, ok := newUrlTemplate(tc.Template).match(tc.Path, tc.Query)
+		if ok == tc.NoMatch {
+			t.Errorf("bad: %s, expected match to be %t", tn, !tc.NoMatch)
+			continue
+		}
+		if !tc.NoMatch && !reflect.DeepEqual(params, tc.Expected) {
+			t.Errorf("bad: %s, expected %v, got %v", tn, tc.Expected, params)
+		}
+	}
+}
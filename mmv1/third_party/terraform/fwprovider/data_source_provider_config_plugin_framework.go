@@ -45,6 +45,8 @@ type GoogleProviderConfigPluginFrameworkModel struct {
 	Zone                               types.String `tfsdk:"zone"`
 	Scopes                             types.List   `tfsdk:"scopes"`
 	//	omit Batching
+	//	omit RateLimits
+	//	omit HttpLogFile
 	UserProjectOverride                       types.Bool   `tfsdk:"user_project_override"`
 	RequestTimeout                            types.String `tfsdk:"request_timeout"`
 	RequestReason                             types.String `tfsdk:"request_reason"`
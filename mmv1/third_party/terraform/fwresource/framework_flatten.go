@@ -0,0 +1,102 @@
+package fwresource
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The following functions convert values of a decoded JSON API response into
+// plugin-framework values. Missing values are flattened to null.
+
+func FlattenString(v interface{}) types.String {
+	if v == nil {
+		return types.StringNull()
+	}
+	if s, ok := v.(string); ok {
+		return types.StringValue(s)
+	}
+	return types.StringValue(fmt.Sprintf("%v", v))
+}
+
+// FlattenInt64 handles integers sent as JSON numbers, and int64 values sent as
+// strings.
+func FlattenInt64(v interface{}) types.Int64 {
+	switch v := v.(type) {
+	case float64:
+		return types.Int64Value(int64(v))
+	case string:
+		if i, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return types.Int64Value(i)
+		}
+	}
+	return types.Int64Null()
+}
+
+func FlattenFloat64(v interface{}) types.Float64 {
+	switch v := v.(type) {
+	case float64:
+		return types.Float64Value(v)
+	case string:
+		if f, err := strconv.ParseFloat(v, 64); err == nil {
+			return types.Float64Value(f)
+		}
+	}
+	return types.Float64Null()
+}
+
+func FlattenBool(v interface{}) types.Bool {
+	switch v := v.(type) {
+	case bool:
+		return types.BoolValue(v)
+	case string:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return types.BoolValue(b)
+		}
+	}
+	return types.BoolNull()
+}
+
+func FlattenStringMap(ctx context.Context, v interface{}, diags *diag.Diagnostics) types.Map {
+	m, ok := v.(map[string]interface{})
+	if !ok {
+		return types.MapNull(types.StringType)
+	}
+
+	elements := make(map[string]attr.Value, len(m))
+	for k, e := range m {
+		elements[k] = FlattenString(e)
+	}
+	value, d := types.MapValue(types.StringType, elements)
+	diags.Append(d...)
+	return value
+}
+
+// FlattenList flattens a list of primitive values of the given element type.
+func FlattenList(ctx context.Context, elemType attr.Type, v interface{}, diags *diag.Diagnostics) types.List {
+	l, ok := v.([]interface{})
+	if !ok {
+		return types.ListNull(elemType)
+	}
+
+	elements := make([]attr.Value, 0, len(l))
+	for _, e := range l {
+		switch elemType {
+		case types.Int64Type:
+			elements = append(elements, FlattenInt64(e))
+		case types.Float64Type:
+			elements = append(elements, FlattenFloat64(e))
+		case types.BoolType:
+			elements = append(elements, FlattenBool(e))
+		default:
+			elements = append(elements, FlattenString(e))
+		}
+	}
+	value, d := types.ListValue(elemType, elements)
+	diags.Append(d...)
+	return value
+}
@@ -0,0 +1,34 @@
+package fwresource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestFlattenInt64(t *testing.T) {
+	cases := map[string]struct {
+		Value    interface{}
+		Expected types.Int64
+	}{
+		"json number": {
+			Value:    float64(10),
+			Expected: types.Int64Value(10),
+		},
+		"int64 sent as a string": {
+			Value:    "9007199254740993",
+			Expected: types.Int64Value(9007199254740993),
+		},
+		"missing value": {
+			Value:    nil,
+			Expected: types.Int64Null(),
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			if got := FlattenInt64(tc.Value); !got.Equal(tc.Expected) {
+				t.Fatalf("Incorrect value: got %s, want %s", got, tc.Expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,127 @@
+package fwresource
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+// The following functions are the plugin-framework counterparts of
+// tpgresource.SetLabelsDiff, tpgresource.SetAnnotationsDiff and
+// tpgresource.SetLabels, for resources with labels or annotations at the root
+// level. labels and annotations only hold the values configured in Terraform,
+// while effective_labels and effective_annotations hold every value of the
+// resource in GCP and are sent to the API.
+
+// PlanLabels returns the planned terraform_labels and effective_labels of a
+// resource from its planned labels, the provider's default labels and its
+// prior terraform_labels and effective_labels, which are null on create.
+func PlanLabels(ctx context.Context, config *transport_tpg.Config, labels, priorTerraformLabels, priorEffectiveLabels types.Map, skipAttribution bool, diags *diag.Diagnostics) (terraformLabels, effectiveLabels types.Map) {
+	// If labels are computed, so are terraform_labels and effective_labels
+	if labels.IsUnknown() {
+		return types.MapUnknown(types.StringType), types.MapUnknown(types.StringType)
+	}
+
+	prior := stringMap(ctx, priorEffectiveLabels, diags)
+
+	// Merge provider default labels with the user defined labels in the
+	// resource to get terraform managed labels
+	terraform := make(map[string]string)
+	if config != nil {
+		for k, v := range config.DefaultLabels {
+			terraform[k] = v
+		}
+
+		// Append optional label indicating the resource was provisioned using Terraform
+		if !skipAttribution && config.AddTerraformAttributionLabel {
+			_, hasExistingLabel := prior[transport_tpg.AttributionKey]
+			if hasExistingLabel ||
+				config.TerraformAttributionLabelAdditionStrategy == transport_tpg.ProactiveAttributionStrategy ||
+				(config.TerraformAttributionLabelAdditionStrategy == transport_tpg.CreateOnlyAttributionStrategy && priorEffectiveLabels.IsNull()) {
+				terraform[transport_tpg.AttributionKey] = transport_tpg.AttributionValue
+			}
+		}
+	}
+	for k, v := range stringMap(ctx, labels, diags) {
+		terraform[k] = v
+	}
+
+	terraformLabels = stringMapValue(ctx, terraform, diags)
+	return terraformLabels, planEffective(ctx, terraform, priorTerraformLabels, priorEffectiveLabels, diags)
+}
+
+// PlanAnnotations returns the planned effective_annotations of a resource from
+// its planned annotations and its prior annotations and effective_annotations,
+// which are null on create.
+func PlanAnnotations(ctx context.Context, annotations, priorAnnotations, priorEffectiveAnnotations types.Map, diags *diag.Diagnostics) types.Map {
+	// If annotations are computed, so are effective_annotations
+	if annotations.IsUnknown() {
+		return types.MapUnknown(types.StringType)
+	}
+	return planEffective(ctx, stringMap(ctx, annotations, diags), priorAnnotations, priorEffectiveAnnotations, diags)
+}
+
+// FlattenLabels flattens the labels or annotations of an API response, keeping
+// only the keys of the current value so that values set outside of Terraform
+// don't cause a diff. Values that aren't set are left null.
+func FlattenLabels(ctx context.Context, v interface{}, current types.Map, diags *diag.Diagnostics) types.Map {
+	if current.IsNull() || current.IsUnknown() {
+		return current
+	}
+
+	res, _ := v.(map[string]interface{})
+	labels := make(map[string]string)
+	for k := range stringMap(ctx, current, diags) {
+		if value, ok := res[k].(string); ok {
+			labels[k] = value
+		}
+	}
+	return stringMapValue(ctx, labels, diags)
+}
+
+// FlattenEffectiveLabels flattens every label or annotation of an API
+// response. APIs omit empty maps, which are flattened to an empty map instead
+// of null so that they match the planned value.
+func FlattenEffectiveLabels(ctx context.Context, v interface{}, diags *diag.Diagnostics) types.Map {
+	res, _ := v.(map[string]interface{})
+	labels := make(map[string]string, len(res))
+	for k, e := range res {
+		if value, ok := e.(string); ok {
+			labels[k] = value
+		}
+	}
+	return stringMapValue(ctx, labels, diags)
+}
+
+// planEffective adds the managed values to the prior effective values, and
+// removes the ones that aren't managed anymore.
+func planEffective(ctx context.Context, managed map[string]string, priorManaged, priorEffective types.Map, diags *diag.Diagnostics) types.Map {
+	effective := stringMap(ctx, priorEffective, diags)
+	for k := range stringMap(ctx, priorManaged, diags) {
+		if _, ok := managed[k]; !ok {
+			delete(effective, k)
+		}
+	}
+	for k, v := range managed {
+		effective[k] = v
+	}
+	return stringMapValue(ctx, effective, diags)
+}
+
+func stringMap(ctx context.Context, v types.Map, diags *diag.Diagnostics) map[string]string {
+	m := make(map[string]string)
+	if v.IsNull() || v.IsUnknown() {
+		return m
+	}
+	diags.Append(v.ElementsAs(ctx, &m, false)...)
+	return m
+}
+
+func stringMapValue(ctx context.Context, m map[string]string, diags *diag.Diagnostics) types.Map {
+	value, d := types.MapValueFrom(ctx, types.StringType, m)
+	diags.Append(d...)
+	return value
+}
@@ -0,0 +1,157 @@
+package fwresource
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+func testStringMap(t *testing.T, m map[string]string) types.Map {
+	value, d := types.MapValueFrom(context.Background(), types.StringType, m)
+	if d.HasError() {
+		t.Fatal(d)
+	}
+	return value
+}
+
+func TestPlanLabels(t *testing.T) {
+	cases := map[string]struct {
+		Config                  *transport_tpg.Config
+		Labels                  types.Map
+		PriorTerraformLabels    types.Map
+		PriorEffectiveLabels    types.Map
+		SkipAttribution         bool
+		ExpectedTerraformLabels types.Map
+		ExpectedEffectiveLabels types.Map
+	}{
+		"merges default labels on create": {
+			Config: &transport_tpg.Config{
+				DefaultLabels: map[string]string{"env": "dev", "team": "a"},
+			},
+			Labels:                  testStringMap(t, map[string]string{"team": "b"}),
+			PriorTerraformLabels:    types.MapNull(types.StringType),
+			PriorEffectiveLabels:    types.MapNull(types.StringType),
+			ExpectedTerraformLabels: testStringMap(t, map[string]string{"env": "dev", "team": "b"}),
+			ExpectedEffectiveLabels: testStringMap(t, map[string]string{"env": "dev", "team": "b"}),
+		},
+		"keeps labels set outside of terraform and removes the ones not managed anymore": {
+			Config:                  &transport_tpg.Config{},
+			Labels:                  testStringMap(t, map[string]string{"a": "2"}),
+			PriorTerraformLabels:    testStringMap(t, map[string]string{"a": "1", "b": "1"}),
+			PriorEffectiveLabels:    testStringMap(t, map[string]string{"a": "1", "b": "1", "external": "1"}),
+			ExpectedTerraformLabels: testStringMap(t, map[string]string{"a": "2"}),
+			ExpectedEffectiveLabels: testStringMap(t, map[string]string{"a": "2", "external": "1"}),
+		},
+		"adds the attribution label on create": {
+			Config: &transport_tpg.Config{
+				AddTerraformAttributionLabel:              true,
+				TerraformAttributionLabelAdditionStrategy: transport_tpg.CreateOnlyAttributionStrategy,
+			},
+			Labels:                  types.MapNull(types.StringType),
+			PriorTerraformLabels:    types.MapNull(types.StringType),
+			PriorEffectiveLabels:    types.MapNull(types.StringType),
+			ExpectedTerraformLabels: testStringMap(t, map[string]string{transport_tpg.AttributionKey: transport_tpg.AttributionValue}),
+			ExpectedEffectiveLabels: testStringMap(t, map[string]string{transport_tpg.AttributionKey: transport_tpg.AttributionValue}),
+		},
+		"doesn't add the attribution label on update of a resource without it": {
+			Config: &transport_tpg.Config{
+				AddTerraformAttributionLabel:              true,
+				TerraformAttributionLabelAdditionStrategy: transport_tpg.CreateOnlyAttributionStrategy,
+			},
+			Labels:                  types.MapNull(types.StringType),
+			PriorTerraformLabels:    testStringMap(t, map[string]string{}),
+			PriorEffectiveLabels:    testStringMap(t, map[string]string{}),
+			ExpectedTerraformLabels: testStringMap(t, map[string]string{}),
+			ExpectedEffectiveLabels: testStringMap(t, map[string]string{}),
+		},
+		"skips the attribution label": {
+			Config: &transport_tpg.Config{
+				AddTerraformAttributionLabel:              true,
+				TerraformAttributionLabelAdditionStrategy: transport_tpg.ProactiveAttributionStrategy,
+			},
+			Labels:                  types.MapNull(types.StringType),
+			PriorTerraformLabels:    types.MapNull(types.StringType),
+			PriorEffectiveLabels:    types.MapNull(types.StringType),
+			SkipAttribution:         true,
+			ExpectedTerraformLabels: testStringMap(t, map[string]string{}),
+			ExpectedEffectiveLabels: testStringMap(t, map[string]string{}),
+		},
+		"unknown labels": {
+			Config:                  &transport_tpg.Config{},
+			Labels:                  types.MapUnknown(types.StringType),
+			PriorTerraformLabels:    types.MapNull(types.StringType),
+			PriorEffectiveLabels:    types.MapNull(types.StringType),
+			ExpectedTerraformLabels: types.MapUnknown(types.StringType),
+			ExpectedEffectiveLabels: types.MapUnknown(types.StringType),
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var diags diag.Diagnostics
+			terraformLabels, effectiveLabels := PlanLabels(context.Background(), tc.Config, tc.Labels, tc.PriorTerraformLabels, tc.PriorEffectiveLabels, tc.SkipAttribution, &diags)
+			if diags.HasError() {
+				t.Fatal(diags)
+			}
+			if !terraformLabels.Equal(tc.ExpectedTerraformLabels) {
+				t.Errorf("Incorrect terraform_labels: got %s, want %s", terraformLabels, tc.ExpectedTerraformLabels)
+			}
+			if !effectiveLabels.Equal(tc.ExpectedEffectiveLabels) {
+				t.Errorf("Incorrect effective_labels: got %s, want %s", effectiveLabels, tc.ExpectedEffectiveLabels)
+			}
+		})
+	}
+}
+
+func TestPlanAnnotations(t *testing.T) {
+	var diags diag.Diagnostics
+	got := PlanAnnotations(
+		context.Background(),
+		testStringMap(t, map[string]string{"a": "2"}),
+		testStringMap(t, map[string]string{"a": "1", "b": "1"}),
+		testStringMap(t, map[string]string{"a": "1", "b": "1", "external": "1"}),
+		&diags,
+	)
+	if diags.HasError() {
+		t.Fatal(diags)
+	}
+	if expected := testStringMap(t, map[string]string{"a": "2", "external": "1"}); !got.Equal(expected) {
+		t.Errorf("Incorrect effective_annotations: got %s, want %s", got, expected)
+	}
+}
+
+func TestFlattenLabels(t *testing.T) {
+	res := map[string]interface{}{"a": "1", "external": "1"}
+
+	cases := map[string]struct {
+		Current  types.Map
+		Expected types.Map
+	}{
+		"keeps only the configured labels": {
+			Current:  testStringMap(t, map[string]string{"a": "0", "b": "0"}),
+			Expected: testStringMap(t, map[string]string{"a": "1"}),
+		},
+		"labels that aren't configured stay null": {
+			Current:  types.MapNull(types.StringType),
+			Expected: types.MapNull(types.StringType),
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var diags diag.Diagnostics
+			if got := FlattenLabels(context.Background(), res, tc.Current, &diags); !got.Equal(tc.Expected) {
+				t.Fatalf("Incorrect value: got %s, want %s", got, tc.Expected)
+			}
+		})
+	}
+}
+
+func TestFlattenEffectiveLabels(t *testing.T) {
+	var diags diag.Diagnostics
+	if got, expected := FlattenEffectiveLabels(context.Background(), nil, &diags), testStringMap(t, map[string]string{}); !got.Equal(expected) {
+		t.Fatalf("Incorrect value: got %s, want %s", got, expected)
+	}
+}
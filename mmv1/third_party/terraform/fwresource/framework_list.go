@@ -0,0 +1,38 @@
+package fwresource
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// SetListResultFromResourceData sets the identity of a list result, and its
+// resource when the list request includes resources, from the ResourceData of
+// a resource implemented with the SDK. The id of the ResourceData must be set,
+// and its identity set with tpgresource.SetResourceIdentity, as terraform
+// imports listed resources by their identity.
+func SetListResultFromResourceData(d *schema.ResourceData, includeResource bool, result *list.ListResult) {
+	if state := d.State(); state == nil || len(state.Identity) == 0 {
+		result.Diagnostics.AddError("Error converting resource identity", fmt.Sprintf("the identity of %q isn't set", d.Id()))
+		return
+	}
+
+	identity, err := d.TfTypeIdentityState()
+	if err != nil {
+		result.Diagnostics.AddError("Error converting resource identity", err.Error())
+		return
+	}
+	result.Identity.Raw = *identity
+
+	if !includeResource {
+		return
+	}
+
+	state, err := d.TfTypeResourceState()
+	if err != nil {
+		result.Diagnostics.AddError("Error converting resource state", err.Error())
+		return
+	}
+	result.Resource.Raw = *state
+}
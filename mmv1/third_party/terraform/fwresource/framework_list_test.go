@@ -0,0 +1,106 @@
+package fwresource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/list"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	"github.com/hashicorp/terraform-provider-google/google/tpgresource"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+var listTestResource = &schema.Resource{
+	Schema: map[string]*schema.Schema{
+		"name": {
+			Type:     schema.TypeString,
+			Required: true,
+		},
+		"project": {
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+		},
+	},
+	Identity: &schema.ResourceIdentity{
+		SchemaFunc: func() map[string]*schema.Schema {
+			return map[string]*schema.Schema{
+				"name": {
+					Type:              schema.TypeString,
+					RequiredForImport: true,
+				},
+				"project": {
+					Type:              schema.TypeString,
+					OptionalForImport: true,
+				},
+			}
+		},
+	},
+}
+
+func TestSetListResultFromResourceData(t *testing.T) {
+	cases := map[string]struct {
+		SetIdentity     bool
+		IncludeResource bool
+		ExpectError     bool
+	}{
+		"sets the identity of the listed resource": {
+			SetIdentity: true,
+		},
+		"sets the identity and state of the listed resource": {
+			SetIdentity:     true,
+			IncludeResource: true,
+		},
+		"fails when the identity isn't set": {
+			ExpectError: true,
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			d := listTestResource.Data(nil)
+			if err := d.Set("name", "my-topic"); err != nil {
+				t.Fatal(err)
+			}
+			if err := d.Set("project", "my-project"); err != nil {
+				t.Fatal(err)
+			}
+			d.SetId("projects/my-project/topics/my-topic")
+			if tc.SetIdentity {
+				if err := tpgresource.SetResourceIdentity(d, &transport_tpg.Config{}, "name", "project"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			result := list.ListResult{
+				Identity: &tfsdk.ResourceIdentity{},
+				Resource: &tfsdk.Resource{},
+			}
+			SetListResultFromResourceData(d, tc.IncludeResource, &result)
+			if tc.ExpectError {
+				if !result.Diagnostics.HasError() {
+					t.Fatalf("expected an error, got none")
+				}
+				return
+			}
+			if result.Diagnostics.HasError() {
+				t.Fatalf("unexpected error: %v", result.Diagnostics)
+			}
+
+			expected := tftypes.NewValue(
+				tftypes.Object{AttributeTypes: map[string]tftypes.Type{"name": tftypes.String, "project": tftypes.String}},
+				map[string]tftypes.Value{
+					"name":    tftypes.NewValue(tftypes.String, "my-topic"),
+					"project": tftypes.NewValue(tftypes.String, "my-project"),
+				},
+			)
+			if !result.Identity.Raw.Equal(expected) {
+				t.Errorf("expected identity %s, got %s", expected, result.Identity.Raw)
+			}
+			if got := !result.Resource.Raw.IsNull(); got != tc.IncludeResource {
+				t.Errorf("expected resource set to be %t, got %t", tc.IncludeResource, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package fwresource
+
+import (
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"strconv"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+// ReplaceVarsFramework replaces references to fields (in the form of {{var}}) in a URL
+// template with their values. The result can be URL-encoded by prepending '%' to the field
+// name e.g. {{%var}}. When the project, region or zone is not given, the provider's value
+// is used.
+// Other references are drawn from the provider config, e.g. {{PubsubBasePath}}.
+func ReplaceVarsFramework(linkTmpl string, values map[string]string, config *transport_tpg.Config) string {
+	re := regexp.MustCompile("{{([%[:word:]]+)}}")
+
+	replaceFunc := func(s string) string {
+		m := re.FindStringSubmatch(s)[1]
+		escape := m[0] == '%'
+		if escape {
+			m = m[1:]
+		}
+
+		v, ok := values[m]
+		if !ok || v == "" {
+			switch {
+			case m == "project" && config != nil:
+				v = config.Project
+			case m == "region" && config != nil:
+				v = config.Region
+			case m == "zone" && config != nil:
+				v = config.Zone
+			case !ok && config != nil:
+				// Attempt to draw values from the provider config
+				if f := reflect.Indirect(reflect.ValueOf(config)).FieldByName(m); f.IsValid() {
+					v = f.String()
+				}
+			}
+		}
+
+		if escape {
+			return url.PathEscape(v)
+		}
+		return v
+	}
+
+	return re.ReplaceAllStringFunc(linkTmpl, replaceFunc)
+}
+
+// ParseImportIdFramework matches an import id against a list of regexes, e.g.
+// - projects/(?P<project>[^/]+)/topics/(?P<name>[^/]+) (applied first)
+// - (?P<name>[^/]+) (applied last)
+// and returns the values of the named groups of the first one that matches.
+func ParseImportIdFramework(id string, idRegexes []string, diags *diag.Diagnostics) map[string]string {
+	for _, idFormat := range idRegexes {
+		re, err := regexp.Compile(idFormat)
+		if err != nil {
+			diags.AddError("Import is not supported", fmt.Sprintf("Invalid import id format %s: %s", idFormat, err))
+			return nil
+		}
+
+		if fieldValues := re.FindStringSubmatch(id); fieldValues != nil {
+			values := make(map[string]string)
+			// Starting at index 1, the first match is the full string.
+			for i := 1; i < len(fieldValues); i++ {
+				if name := re.SubexpNames()[i]; name != "" {
+					values[name] = fieldValues[i]
+				}
+			}
+			return values
+		}
+	}
+
+	diags.AddError("Invalid import id", fmt.Sprintf("Import id %q doesn't match any of the accepted formats: %v", id, idRegexes))
+	return nil
+}
+
+// ValueString returns the string form of a primitive value as used in URLs, or an
+// empty string if the value is null or unknown.
+func ValueString(v attr.Value) string {
+	if v.IsNull() || v.IsUnknown() {
+		return ""
+	}
+
+	switch v := v.(type) {
+	case types.String:
+		return v.ValueString()
+	case types.Int64:
+		return strconv.FormatInt(v.ValueInt64(), 10)
+	case types.Float64:
+		return strconv.FormatFloat(v.ValueFloat64(), 'f', -1, 64)
+	case types.Bool:
+		return strconv.FormatBool(v.ValueBool())
+	}
+	return v.String()
+}
@@ -0,0 +1,97 @@
+package fwresource
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+func TestReplaceVarsFramework(t *testing.T) {
+	config := &transport_tpg.Config{
+		Region:         "provider-region",
+		PubsubBasePath: "https://pubsub.googleapis.com/v1/",
+	}
+
+	cases := map[string]struct {
+		LinkTmpl    string
+		Values      map[string]string
+		ExpectedUrl string
+	}{
+		"replaces values of fields": {
+			LinkTmpl:    "projects/{{project}}/topics/{{name}}",
+			Values:      map[string]string{"project": "my-project", "name": "my-topic"},
+			ExpectedUrl: "projects/my-project/topics/my-topic",
+		},
+		"url-encodes values prefixed with %": {
+			LinkTmpl:    "topics/{{%name}}",
+			Values:      map[string]string{"name": "a/b"},
+			ExpectedUrl: "topics/a%2Fb",
+		},
+		"region is pulled from the provider config when unset": {
+			LinkTmpl:    "regions/{{region}}",
+			Values:      map[string]string{"region": ""},
+			ExpectedUrl: "regions/provider-region",
+		},
+		"unknown references are drawn from the provider config": {
+			LinkTmpl:    "{{PubsubBasePath}}topics",
+			ExpectedUrl: "https://pubsub.googleapis.com/v1/topics",
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			url := ReplaceVarsFramework(tc.LinkTmpl, tc.Values, config)
+			if url != tc.ExpectedUrl {
+				t.Fatalf("Incorrect url: got %s, want %s", url, tc.ExpectedUrl)
+			}
+		})
+	}
+}
+
+func TestParseImportIdFramework(t *testing.T) {
+	idRegexes := []string{
+		"^projects/(?P<project>[^/]+)/topics/(?P<name>[^/]+)$",
+		"^(?P<name>[^/]+)$",
+	}
+
+	cases := map[string]struct {
+		Id             string
+		ExpectedValues map[string]string
+		ExpectedError  bool
+	}{
+		"matches the first format": {
+			Id:             "projects/my-project/topics/my-topic",
+			ExpectedValues: map[string]string{"project": "my-project", "name": "my-topic"},
+		},
+		"matches a shorter format": {
+			Id:             "my-topic",
+			ExpectedValues: map[string]string{"name": "my-topic"},
+		},
+		"error when no format matches": {
+			Id:            "projects/my-project/subscriptions/a/b",
+			ExpectedError: true,
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var diags diag.Diagnostics
+
+			values := ParseImportIdFramework(tc.Id, idRegexes, &diags)
+
+			if diags.HasError() {
+				if tc.ExpectedError {
+					return
+				}
+				t.Fatalf("Got %d unexpected error(s) during test: %s", diags.ErrorsCount(), diags.Errors())
+			}
+			if tc.ExpectedError {
+				t.Fatalf("Expected an error for id %s", tc.Id)
+			}
+
+			if !reflect.DeepEqual(values, tc.ExpectedValues) {
+				t.Fatalf("Incorrect values: got %v, want %v", values, tc.ExpectedValues)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package appengine
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -31,10 +32,20 @@ func (w *AppEngineOperationWaiter) QueryOp() (interface{}, error) {
 	if len(matches) != 2 {
 		return nil, fmt.Errorf("Expected %d results of parsing operation name, got %d from %s", 2, len(matches), w.Op.Name)
 	}
-	return w.Service.Apps.Operations.Get(w.AppId, matches[1]).Do()
+	call := w.Service.Apps.Operations.Get(w.AppId, matches[1])
+	if ctx := w.Context(); ctx != nil {
+		call = call.Context(ctx)
+	}
+	return call.Do()
 }
 
 func AppEngineOperationWaitTimeWithResponse(config *transport_tpg.Config, res interface{}, response *map[string]interface{}, appId, activity, userAgent string, timeout time.Duration) error {
+	return AppEngineOperationWaitTimeWithResponseContext(context.Background(), config, res, response, appId, activity, userAgent, timeout)
+}
+
+// AppEngineOperationWaitTimeWithResponseContext waits for the operation as part of the trace
+// of ctx, and unmarshals its response.
+func AppEngineOperationWaitTimeWithResponseContext(ctx context.Context, config *transport_tpg.Config, res interface{}, response *map[string]interface{}, appId, activity, userAgent string, timeout time.Duration) error {
 	op := &appengine.Operation{}
 	err := tpgresource.Convert(res, op)
 	if err != nil {
@@ -49,13 +60,18 @@ func AppEngineOperationWaitTimeWithResponse(config *transport_tpg.Config, res in
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
 }
 
 func AppEngineOperationWaitTime(config *transport_tpg.Config, res interface{}, appId, activity, userAgent string, timeout time.Duration) error {
+	return AppEngineOperationWaitTimeContext(context.Background(), config, res, appId, activity, userAgent, timeout)
+}
+
+// AppEngineOperationWaitTimeContext waits for the operation as part of the trace of ctx.
+func AppEngineOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, res interface{}, appId, activity, userAgent string, timeout time.Duration) error {
 	op := &appengine.Operation{}
 	err := tpgresource.Convert(res, op)
 	if err != nil {
@@ -70,5 +86,5 @@ func AppEngineOperationWaitTime(config *transport_tpg.Config, res interface{}, a
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
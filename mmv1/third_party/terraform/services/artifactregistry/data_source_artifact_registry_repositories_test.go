@@ -0,0 +1,57 @@
+package artifactregistry_test
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-provider-google/google/acctest"
+)
+
+func TestAccDataSourceGoogleArtifactRegistryRepositories_filter(t *testing.T) {
+	t.Parallel()
+
+	context := map[string]interface{}{
+		"random_suffix": acctest.RandString(t, 10),
+	}
+	funcDataName := "data.google_artifact_registry_repositories.my-repos"
+
+	acctest.VcrTest(t, resource.TestCase{
+		PreCheck:                 func() { acctest.AccTestPreCheck(t) },
+		ProtoV5ProviderFactories: acctest.ProtoV5ProviderFactories(t),
+		CheckDestroy:             testAccCheckArtifactRegistryRepositoryDestroyProducer(t),
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceGoogleArtifactRegistryRepositoriesConfig(context),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(funcDataName, "repositories.#", "1"),
+					resource.TestCheckResourceAttrPair(funcDataName, "repositories.0.name",
+						"google_artifact_registry_repository.my-repo", "name"),
+					resource.TestCheckResourceAttrPair(funcDataName, "repositories.0.repository_id",
+						"google_artifact_registry_repository.my-repo", "repository_id"),
+					resource.TestCheckResourceAttr(funcDataName, "repositories.0.location", "us-central1"),
+					resource.TestCheckResourceAttr(funcDataName, "repositories.0.format", "DOCKER"),
+					resource.TestCheckResourceAttr(funcDataName, "repositories.0.labels.my_key", "my_val"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceGoogleArtifactRegistryRepositoriesConfig(context map[string]interface{}) string {
+	return acctest.Nprintf(`
+resource "google_artifact_registry_repository" "my-repo" {
+  location      = "us-central1"
+  repository_id = "tf-test-my-repository%{random_suffix}"
+  description   = "example docker repository%{random_suffix}"
+  format        = "DOCKER"
+  labels = {
+    my_key = "my_val"
+  }
+}
+
+data "google_artifact_registry_repositories" "my-repos" {
+  location = "us-central1"
+  filter   = "name=\"${google_artifact_registry_repository.my-repo.id}\""
+}
+`, context)
+}
@@ -1,58 +0,0 @@
-package artifactregistry
-
-import (
-	"fmt"
-
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
-	"github.com/hashicorp/terraform-provider-google/google/tpgresource"
-	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
-)
-
-func DataSourceArtifactRegistryRepository() *schema.Resource {
-	// Generate datasource schema from resource
-	dsSchema := tpgresource.DatasourceSchemaFromResourceSchema(ResourceArtifactRegistryRepository().Schema)
-
-	// Set 'Required' schema elements
-	tpgresource.AddRequiredFieldsToSchema(dsSchema, "repository_id", "location")
-
-	// Set 'Optional' schema elements
-	tpgresource.AddOptionalFieldsToSchema(dsSchema, "project")
-
-	return &schema.Resource{
-		Read:   dataSourceArtifactRegistryRepositoryRead,
-		Schema: dsSchema,
-	}
-}
-
-func dataSourceArtifactRegistryRepositoryRead(d *schema.ResourceData, meta interface{}) error {
-	config := meta.(*transport_tpg.Config)
-
-	project, err := tpgresource.GetProject(d, config)
-	if err != nil {
-		return err
-	}
-
-	location, err := tpgresource.GetLocation(d, config)
-	if err != nil {
-		return err
-	}
-
-	repository_id := d.Get("repository_id").(string)
-	id := fmt.Sprintf("projects/%s/locations/%s/repositories/%s", project, location, repository_id)
-	d.SetId(id)
-
-	err = resourceArtifactRegistryRepositoryRead(d, meta)
-	if err != nil {
-		return err
-	}
-
-	if err := tpgresource.SetDataSourceLabels(d); err != nil {
-		return err
-	}
-
-	if d.Id() == "" {
-		return fmt.Errorf("%s not found", id)
-	}
-
-	return nil
-}
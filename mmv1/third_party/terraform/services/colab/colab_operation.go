@@ -1,6 +1,7 @@
 package colab
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -28,6 +29,7 @@ func (w *ColabOperationWaiter) QueryOp() (interface{}, error) {
 	url := fmt.Sprintf("https://%s-aiplatform.googleapis.com/v1/%s", region, w.CommonOperationWaiter.Op.Name)
 
 	return transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Context:   w.Context(),
 		Config:    w.Config,
 		Method:    "GET",
 		Project:   w.Project,
@@ -50,11 +52,17 @@ func createColabWaiter(config *transport_tpg.Config, op map[string]interface{},
 
 // nolint: deadcode,unused
 func ColabOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return ColabOperationWaitTimeWithResponseContext(context.Background(), config, op, response, project, activity, userAgent, timeout)
+}
+
+// ColabOperationWaitTimeWithResponseContext waits for the operation as part of the trace
+// of ctx, and unmarshals its response.
+func ColabOperationWaitTimeWithResponseContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	w, err := createColabWaiter(config, op, project, activity, userAgent)
 	if err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	rawResponse := []byte(w.CommonOperationWaiter.Op.Response)
@@ -65,6 +73,11 @@ func ColabOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[str
 }
 
 func ColabOperationWaitTime(config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return ColabOperationWaitTimeContext(context.Background(), config, op, project, activity, userAgent, timeout)
+}
+
+// ColabOperationWaitTimeContext waits for the operation as part of the trace of ctx.
+func ColabOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	if val, ok := op["name"]; !ok || val == "" {
 		// This was a synchronous call - there is no operation to wait for.
 		return nil
@@ -74,5 +87,5 @@ func ColabOperationWaitTime(config *transport_tpg.Config, op map[string]interfac
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
@@ -1,6 +1,7 @@
 package dialogflowcx
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"regexp"
@@ -35,6 +36,7 @@ func (w *DialogflowCXOperationWaiter) QueryOp() (interface{}, error) {
 	url := fmt.Sprintf("https://%s-dialogflow.googleapis.com/v3/%s", location, w.CommonOperationWaiter.Op.Name)
 
 	return transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Context:   w.Context(),
 		Config:    w.Config,
 		Method:    "GET",
 		RawURL:    url,
@@ -55,17 +57,28 @@ func createDialogflowCXWaiter(config *transport_tpg.Config, op map[string]interf
 
 // nolint: deadcode,unused
 func DialogflowCXOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
+	return DialogflowCXOperationWaitTimeWithResponseContext(context.Background(), config, op, response, activity, userAgent, timeout)
+}
+
+// DialogflowCXOperationWaitTimeWithResponseContext waits for the operation as part of the trace
+// of ctx, and unmarshals its response.
+func DialogflowCXOperationWaitTimeWithResponseContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
 	w, err := createDialogflowCXWaiter(config, op, activity, userAgent)
 	if err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	return json.Unmarshal([]byte(w.CommonOperationWaiter.Op.Response), response)
 }
 
 func DialogflowCXOperationWaitTime(config *transport_tpg.Config, op map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
+	return DialogflowCXOperationWaitTimeContext(context.Background(), config, op, activity, userAgent, timeout)
+}
+
+// DialogflowCXOperationWaitTimeContext waits for the operation as part of the trace of ctx.
+func DialogflowCXOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
 	if val, ok := op["name"]; !ok || val == "" {
 		// This was a synchronous call - there is no operation to wait for.
 		return nil
@@ -75,5 +88,5 @@ func DialogflowCXOperationWaitTime(config *transport_tpg.Config, op map[string]i
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
@@ -2,6 +2,7 @@ package sql
 
 import (
 	"bytes"
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -16,6 +17,13 @@ type SqlAdminOperationWaiter struct {
 	Service *sqladmin.Service
 	Op      *sqladmin.Operation
 	Project string
+
+	ctx context.Context
+}
+
+// SetContext sets the context that the operation is queried with.
+func (w *SqlAdminOperationWaiter) SetContext(ctx context.Context) {
+	w.ctx = ctx
 }
 
 func (w *SqlAdminOperationWaiter) State() string {
@@ -73,7 +81,11 @@ func (w *SqlAdminOperationWaiter) QueryOp() (interface{}, error) {
 	var err error
 	err = transport_tpg.Retry(transport_tpg.RetryOptions{
 		RetryFunc: func() error {
-			op, err = w.Service.Operations.Get(w.Project, w.Op.Name).Do()
+			call := w.Service.Operations.Get(w.Project, w.Op.Name)
+			if w.ctx != nil {
+				call = call.Context(w.ctx)
+			}
+			op, err = call.Do()
 			return err
 		},
 		Timeout: transport_tpg.DefaultRequestTimeout,
@@ -103,6 +115,11 @@ func (w *SqlAdminOperationWaiter) TargetStates() []string {
 }
 
 func SqlAdminOperationWaitTime(config *transport_tpg.Config, res interface{}, project, activity, userAgent string, timeout time.Duration) error {
+	return SqlAdminOperationWaitTimeContext(context.Background(), config, res, project, activity, userAgent, timeout)
+}
+
+// SqlAdminOperationWaitTimeContext waits for the operation as part of the trace of ctx.
+func SqlAdminOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, res interface{}, project, activity, userAgent string, timeout time.Duration) error {
 	op := &sqladmin.Operation{}
 	err := tpgresource.Convert(res, op)
 	if err != nil {
@@ -117,7 +134,7 @@ func SqlAdminOperationWaitTime(config *transport_tpg.Config, res interface{}, pr
 	if err := w.SetOp(op); err != nil {
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
 
 // SqlAdminOperationError wraps sqladmin.OperationError and implements the
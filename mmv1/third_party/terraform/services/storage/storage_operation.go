@@ -3,6 +3,7 @@
 package storage
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -29,6 +30,7 @@ func (w *StorageOperationWaiter) QueryOp() (interface{}, error) {
 		case <-w.Config.Context.Done():
 			opCancelUrl := fmt.Sprintf("%s/cancel", w.SelfLink)
 			_, err := transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+				Context:   w.Context(),
 				Config:    w.Config,
 				Method:    "POST",
 				RawURL:    opCancelUrl,
@@ -48,6 +50,7 @@ func (w *StorageOperationWaiter) QueryOp() (interface{}, error) {
 	url := fmt.Sprintf(w.SelfLink)
 
 	return transport_tpg.SendRequest(transport_tpg.SendRequestOptions{
+		Context:   w.Context(),
 		Config:    w.Config,
 		Method:    "GET",
 		RawURL:    url,
@@ -73,11 +76,17 @@ func createStorageWaiter(config *transport_tpg.Config, op map[string]interface{}
 
 // nolint: deadcode,unused
 func StorageOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
+	return StorageOperationWaitTimeWithResponseContext(context.Background(), config, op, response, activity, userAgent, timeout)
+}
+
+// StorageOperationWaitTimeWithResponseContext waits for the operation as part of the trace
+// of ctx, and unmarshals its response.
+func StorageOperationWaitTimeWithResponseContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, response *map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
 	w, err := createStorageWaiter(config, op, activity, userAgent)
 	if err != nil {
 		return err
 	}
-	if err := tpgresource.OperationWait(w, activity, timeout, config.PollInterval); err != nil {
+	if err := tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval); err != nil {
 		return err
 	}
 	rawResponse := []byte(w.CommonOperationWaiter.Op.Response)
@@ -88,6 +97,11 @@ func StorageOperationWaitTimeWithResponse(config *transport_tpg.Config, op map[s
 }
 
 func StorageOperationWaitTime(config *transport_tpg.Config, op map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
+	return StorageOperationWaitTimeContext(context.Background(), config, op, activity, userAgent, timeout)
+}
+
+// StorageOperationWaitTimeContext waits for the operation as part of the trace of ctx.
+func StorageOperationWaitTimeContext(ctx context.Context, config *transport_tpg.Config, op map[string]interface{}, activity, userAgent string, timeout time.Duration) error {
 	if val, ok := op["name"]; !ok || val == "" {
 		// This was a synchronous call - there is no operation to wait for.
 		return nil
@@ -97,5 +111,5 @@ func StorageOperationWaitTime(config *transport_tpg.Config, op map[string]interf
 		// If w is nil, the op was synchronous.
 		return err
 	}
-	return tpgresource.OperationWait(w, activity, timeout, config.PollInterval)
+	return tpgresource.OperationWaitContext(ctx, w, activity, timeout, config.PollInterval)
 }
@@ -1,6 +1,7 @@
 package tpgresource
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"time"
@@ -52,8 +53,32 @@ type Waiter interface {
 	TargetStates() []string
 }
 
+// ContextWaiter is a Waiter that can send its requests as part of the trace of
+// a context, set by OperationWaitContext before the operation is queried.
+type ContextWaiter interface {
+	Waiter
+
+	SetContext(ctx context.Context)
+}
+
 type CommonOperationWaiter struct {
 	Op CommonOperation
+
+	ctx context.Context
+}
+
+// SetContext sets the context that QueryOp implementations send their requests
+// with.
+func (w *CommonOperationWaiter) SetContext(ctx context.Context) {
+	w.ctx = ctx
+}
+
+// Context returns the context set by SetContext, or nil if it isn't set.
+func (w *CommonOperationWaiter) Context() context.Context {
+	if w == nil {
+		return nil
+	}
+	return w.ctx
 }
 
 func (w *CommonOperationWaiter) State() string {
@@ -137,10 +162,25 @@ func CommonRefreshFunc(w Waiter) retry.StateRefreshFunc {
 }
 
 func OperationWait(w Waiter, activity string, timeout time.Duration, pollInterval time.Duration) error {
+	return OperationWaitContext(context.Background(), w, activity, timeout, pollInterval)
+}
+
+// OperationWaitContext waits for an operation as part of the trace of ctx, eg:
+// the context of a ResourceSpan. The requests of a ContextWaiter are part of
+// the trace of the wait.
+func OperationWaitContext(ctx context.Context, w Waiter, activity string, timeout time.Duration, pollInterval time.Duration) (err error) {
 	if OperationDone(w) {
 		return w.Error()
 	}
 
+	ctx, endPollSpan := transport_tpg.StartPollSpan(ctx, transport_tpg.TelemetryPollKindOperation, activity)
+	defer func() {
+		endPollSpan(err)
+	}()
+	if cw, ok := w.(ContextWaiter); ok {
+		cw.SetContext(ctx)
+	}
+
 	c := &retry.StateChangeConf{
 		Pending:      w.PendingStates(),
 		Target:       w.TargetStates(),
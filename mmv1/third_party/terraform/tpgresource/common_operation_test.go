@@ -1,6 +1,7 @@
 package tpgresource
 
 import (
+	"context"
 	"net/url"
 	"testing"
 	"time"
@@ -67,3 +68,27 @@ func TestOperationWait_TimeoutsShouldRetry(t *testing.T) {
 			expectedRunCount, testWaiter.runCount)
 	}
 }
+
+type testContextKey struct{}
+
+type TestContextWaiter struct {
+	CommonOperationWaiter
+	queried context.Context
+}
+
+func (w *TestContextWaiter) QueryOp() (interface{}, error) {
+	w.queried = w.Context()
+	return map[string]interface{}{"name": "my-operation-name", "done": true}, nil
+}
+
+func TestOperationWaitContext_QueriesWithContext(t *testing.T) {
+	ctx := context.WithValue(context.Background(), testContextKey{}, "my-resource")
+	testWaiter := TestContextWaiter{}
+	err := OperationWaitContext(ctx, &testWaiter, "my-activity", 1*time.Minute, 0*time.Second)
+	if err != nil {
+		t.Fatalf("unexpected error waiting for operation: got '%v', want 'nil'", err)
+	}
+	if testWaiter.queried == nil || testWaiter.queried.Value(testContextKey{}) != "my-resource" {
+		t.Errorf("expected the operation to be queried with a context derived from the one waited with")
+	}
+}
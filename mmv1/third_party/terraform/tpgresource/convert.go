@@ -3,8 +3,30 @@ package tpgresource
 import (
 	"encoding/json"
 	"reflect"
+	"strconv"
 )
 
+// Int64 is an integer field of a typed API object. APIs encode int64 fields
+// as JSON strings and int32 fields as JSON numbers, so it unmarshals from
+// either.
+type Int64 int64
+
+func (i *Int64) UnmarshalJSON(b []byte) error {
+	s := string(b)
+	if s == "null" {
+		return nil
+	}
+	if unquoted, err := strconv.Unquote(s); err == nil {
+		s = unquoted
+	}
+	v, err := strconv.ParseInt(s, 10, 64)
+	if err != nil {
+		return err
+	}
+	*i = Int64(v)
+	return nil
+}
+
 // Convert between two types by converting to/from JSON. Intended to switch
 // between multiple API versions, as they are strict supersets of one another.
 // item and out are pointers to structs
@@ -1,6 +1,7 @@
 package tpgresource
 
 import (
+	"encoding/json"
 	"reflect"
 	"testing"
 )
@@ -79,3 +80,47 @@ func TestSetOmittedFields(t *testing.T) {
 		t.Errorf("Structs were not equivalent after conversion:\nInput:%#v\nOutput: %#v", input, output)
 	}
 }
+
+func TestInt64UnmarshalJSON(t *testing.T) {
+	cases := map[string]struct {
+		Json        string
+		Expected    Int64
+		ExpectError bool
+	}{
+		"number": {
+			Json:     `{"size": 42}`,
+			Expected: 42,
+		},
+		"string": {
+			Json:     `{"size": "9007199254740993"}`,
+			Expected: 9007199254740993,
+		},
+		"null": {
+			Json: `{"size": null}`,
+		},
+		"not a number": {
+			Json:        `{"size": "big"}`,
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		var obj struct {
+			Size Int64 `json:"size"`
+		}
+		err := json.Unmarshal([]byte(tc.Json), &obj)
+		if err != nil {
+			if !tc.ExpectError {
+				t.Errorf("bad: %s, Unexpected error: %s", tn, err)
+			}
+			continue
+		}
+		if tc.ExpectError {
+			t.Errorf("bad: %s, expected an error", tn)
+			continue
+		}
+		if obj.Size != tc.Expected {
+			t.Errorf("bad: %s, expected %d, got %d", tn, tc.Expected, obj.Size)
+		}
+	}
+}
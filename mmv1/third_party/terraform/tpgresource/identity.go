@@ -0,0 +1,75 @@
+package tpgresource
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+var identityFieldRegexp = regexp.MustCompile(`{{%?(\w+)}}`)
+
+// SetResourceIdentity sets each attribute of the resource identity to the
+// value of the field of the same name, as it's used in the resource's id.
+// Resources read through a data source don't have an identity and are left
+// unchanged.
+func SetResourceIdentity(d *schema.ResourceData, config *transport_tpg.Config, fields ...string) error {
+	identity, err := d.Identity()
+	if err != nil {
+		// Data sources read through the resource don't have an identity schema
+		return nil
+	}
+
+	for _, field := range fields {
+		value, err := ReplaceVars(d, config, fmt.Sprintf("{{%s}}", field))
+		if err != nil {
+			return err
+		}
+		if err := identity.Set(field, value); err != nil {
+			return fmt.Errorf("Error setting identity attribute %s: %s", field, err)
+		}
+	}
+	return nil
+}
+
+// ImportIdFromIdentity returns the import id of a resource imported by its
+// identity instead of by id, by replacing the fields of the import id format
+// with the attributes of the identity. The project, region and zone default
+// to the provider's when they aren't part of the identity.
+func ImportIdFromIdentity(d *schema.ResourceData, config *transport_tpg.Config, importIdFormat string) (string, error) {
+	identity, err := d.Identity()
+	if err != nil {
+		return "", fmt.Errorf("Error reading identity: %s", err)
+	}
+
+	var errs []error
+	id := identityFieldRegexp.ReplaceAllStringFunc(importIdFormat, func(match string) string {
+		field := identityFieldRegexp.FindStringSubmatch(match)[1]
+		if v, ok := identity.GetOk(field); ok {
+			return fmt.Sprintf("%v", v)
+		}
+
+		var value string
+		var err error
+		switch field {
+		case "project":
+			value, err = GetProject(d, config)
+		case "region":
+			value, err = GetRegion(d, config)
+		case "zone":
+			value, err = GetZone(d, config)
+		default:
+			err = fmt.Errorf("identity is missing required attribute %s", field)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+		return value
+	})
+	if len(errs) > 0 {
+		return "", fmt.Errorf("Error importing by identity: %v", errs)
+	}
+	return id, nil
+}
@@ -0,0 +1,134 @@
+package tpgresource
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+
+	transport_tpg "github.com/hashicorp/terraform-provider-google/google/transport"
+)
+
+var identityTestSchema = map[string]*schema.Schema{
+	"project": {
+		Type:     schema.TypeString,
+		Optional: true,
+	},
+	"region": {
+		Type:     schema.TypeString,
+		Optional: true,
+	},
+	"name": {
+		Type:     schema.TypeString,
+		Required: true,
+	},
+}
+
+var identityTestIdentitySchema = map[string]*schema.Schema{
+	"project": {
+		Type:              schema.TypeString,
+		OptionalForImport: true,
+	},
+	"region": {
+		Type:              schema.TypeString,
+		OptionalForImport: true,
+	},
+	"name": {
+		Type:              schema.TypeString,
+		RequiredForImport: true,
+	},
+}
+
+func TestImportIdFromIdentity(t *testing.T) {
+	importIdFormat := "projects/{{project}}/regions/{{region}}/routers/{{name}}"
+
+	cases := map[string]struct {
+		Identity    map[string]string
+		Config      *transport_tpg.Config
+		ExpectedId  string
+		ExpectError bool
+	}{
+		"every attribute": {
+			Identity: map[string]string{
+				"project": "my-project",
+				"region":  "my-region",
+				"name":    "my-router",
+			},
+			Config:     &transport_tpg.Config{},
+			ExpectedId: "projects/my-project/regions/my-region/routers/my-router",
+		},
+		"default project and region": {
+			Identity: map[string]string{
+				"name": "my-router",
+			},
+			Config: &transport_tpg.Config{
+				Project: "default-project",
+				Region:  "default-region",
+			},
+			ExpectedId: "projects/default-project/regions/default-region/routers/my-router",
+		},
+		"missing required attribute": {
+			Identity: map[string]string{
+				"project": "my-project",
+				"region":  "my-region",
+			},
+			Config:      &transport_tpg.Config{},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		d := schema.TestResourceDataWithIdentityRaw(t, identityTestSchema, identityTestIdentitySchema, tc.Identity)
+		id, err := ImportIdFromIdentity(d, tc.Config, importIdFormat)
+		if err != nil {
+			if !tc.ExpectError {
+				t.Errorf("bad: %s, Unexpected error: %s", tn, err)
+			}
+			continue
+		}
+		if tc.ExpectError {
+			t.Errorf("bad: %s, expected an error, got id %q", tn, id)
+			continue
+		}
+		if id != tc.ExpectedId {
+			t.Errorf("bad: %s, expected id %q, got %q", tn, tc.ExpectedId, id)
+		}
+	}
+}
+
+func TestSetResourceIdentity(t *testing.T) {
+	d := schema.TestResourceDataWithIdentityRaw(t, identityTestSchema, identityTestIdentitySchema, nil)
+	if err := d.Set("name", "my-router"); err != nil {
+		t.Fatal(err)
+	}
+	config := &transport_tpg.Config{
+		Project: "default-project",
+		Region:  "default-region",
+	}
+
+	if err := SetResourceIdentity(d, config, "name", "project", "region"); err != nil {
+		t.Fatalf("Unexpected error: %s", err)
+	}
+
+	identity, err := d.Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := map[string]string{
+		"project": "default-project",
+		"region":  "default-region",
+		"name":    "my-router",
+	}
+	for k, v := range expected {
+		if got := identity.Get(k); got != v {
+			t.Errorf("bad: expected identity attribute %s to be %q, got %q", k, v, got)
+		}
+	}
+}
+
+func TestSetResourceIdentityWithoutIdentitySchema(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, identityTestSchema, map[string]interface{}{"name": "my-router"})
+
+	if err := SetResourceIdentity(d, &transport_tpg.Config{}, "name"); err != nil {
+		t.Errorf("Unexpected error: %s", err)
+	}
+}
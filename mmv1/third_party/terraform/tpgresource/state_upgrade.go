@@ -0,0 +1,230 @@
+package tpgresource
+
+import (
+	"github.com/hashicorp/go-cty/cty"
+)
+
+// Moves the value of a field in the raw state of a resource, for state
+// upgraders of fields that were renamed or moved. Paths are field names from
+// the top level of the state. Lists at the start both paths share, such as
+// a list of nested objects, are moved in element by element. Other lists are
+// nested objects with a single element, which are created on the way to the
+// new path and removed from the old path once they are empty.
+//
+// Nothing is done if the state has no value at the old path.
+func MoveStateValue(rawState map[string]interface{}, from, to []string) {
+	prefix := commonPrefixLen(from, to)
+	for _, m := range stateObjectsAt(rawState, from[:prefix]) {
+		moveStateValue(m, from[prefix:], to[prefix:])
+	}
+}
+
+// Returns the objects at the path, with one entry for every element of the
+// lists on the way
+func stateObjectsAt(rawState map[string]interface{}, path []string) []map[string]interface{} {
+	objects := []map[string]interface{}{rawState}
+	for _, name := range path {
+		var next []map[string]interface{}
+		for _, m := range objects {
+			switch v := m[name].(type) {
+			case map[string]interface{}:
+				next = append(next, v)
+			case []interface{}:
+				for _, e := range v {
+					if em, ok := e.(map[string]interface{}); ok {
+						next = append(next, em)
+					}
+				}
+			}
+		}
+		objects = next
+	}
+	return objects
+}
+
+func moveStateValue(m map[string]interface{}, from, to []string) {
+	if len(from) == 0 || len(to) == 0 {
+		return
+	}
+	v, ok := removeStateValue(m, from)
+	if !ok {
+		return
+	}
+	setStateValue(m, to, v)
+}
+
+// Returns the single object nested in a value, eg: the element of a nested
+// object's list
+func nestedStateObject(v interface{}) (map[string]interface{}, bool) {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return v, true
+	case []interface{}:
+		if len(v) == 1 {
+			m, ok := v[0].(map[string]interface{})
+			return m, ok
+		}
+	}
+	return nil, false
+}
+
+func removeStateValue(m map[string]interface{}, path []string) (interface{}, bool) {
+	name := path[0]
+	if len(path) == 1 {
+		v, ok := m[name]
+		if !ok || v == nil {
+			return nil, false
+		}
+		delete(m, name)
+		return v, true
+	}
+
+	nested, ok := nestedStateObject(m[name])
+	if !ok {
+		return nil, false
+	}
+	v, ok := removeStateValue(nested, path[1:])
+	if ok && len(nested) == 0 {
+		delete(m, name)
+	}
+	return v, ok
+}
+
+func setStateValue(m map[string]interface{}, path []string, v interface{}) {
+	name := path[0]
+	if len(path) == 1 {
+		m[name] = v
+		return
+	}
+
+	nested, ok := nestedStateObject(m[name])
+	if !ok {
+		nested = make(map[string]interface{})
+		m[name] = []interface{}{nested}
+	}
+	setStateValue(nested, path[1:], v)
+}
+
+// Moves the type of a field in the implied type of a resource's state, the
+// same way MoveStateValue moves its value. State upgraders of fields that
+// were renamed or moved use it to derive the type of the previous schema
+// version from the current one, by moving fields back.
+func MoveStateType(t cty.Type, from, to []string) cty.Type {
+	prefix := commonPrefixLen(from, to)
+	return mapStateTypeAt(t, from[:prefix], func(o cty.Type) cty.Type {
+		return moveStateType(o, from[prefix:], to[prefix:])
+	})
+}
+
+// Replaces the object type at the path, through the element types of lists
+// and sets on the way
+func mapStateTypeAt(t cty.Type, path []string, f func(cty.Type) cty.Type) cty.Type {
+	switch {
+	case t.IsListType():
+		return cty.List(mapStateTypeAt(t.ElementType(), path, f))
+	case t.IsSetType():
+		return cty.Set(mapStateTypeAt(t.ElementType(), path, f))
+	case !t.IsObjectType():
+		return t
+	case len(path) == 0:
+		return f(t)
+	case !t.HasAttribute(path[0]):
+		return t
+	}
+
+	attrs := t.AttributeTypes()
+	updated := make(map[string]cty.Type, len(attrs))
+	for name, at := range attrs {
+		updated[name] = at
+	}
+	updated[path[0]] = mapStateTypeAt(attrs[path[0]], path[1:], f)
+	return cty.Object(updated)
+}
+
+func moveStateType(t cty.Type, from, to []string) cty.Type {
+	if len(from) == 0 || len(to) == 0 {
+		return t
+	}
+	t, at, ok := removeStateType(t, from)
+	if !ok {
+		return t
+	}
+	return setStateType(t, to, at)
+}
+
+func removeStateType(t cty.Type, path []string) (cty.Type, cty.Type, bool) {
+	if t.IsListType() || t.IsSetType() {
+		elem, at, ok := removeStateType(t.ElementType(), path)
+		if !ok {
+			return t, cty.NilType, false
+		}
+		if t.IsListType() {
+			return cty.List(elem), at, true
+		}
+		return cty.Set(elem), at, true
+	}
+	if !t.IsObjectType() || !t.HasAttribute(path[0]) {
+		return t, cty.NilType, false
+	}
+
+	attrs := make(map[string]cty.Type)
+	for name, at := range t.AttributeTypes() {
+		attrs[name] = at
+	}
+	at := attrs[path[0]]
+	if len(path) > 1 {
+		nested, nestedAt, ok := removeStateType(at, path[1:])
+		if !ok {
+			return t, cty.NilType, false
+		}
+		attrs[path[0]], at = nested, nestedAt
+		if !isEmptyStateType(nested) {
+			return cty.Object(attrs), at, true
+		}
+	}
+	delete(attrs, path[0])
+	return cty.Object(attrs), at, true
+}
+
+func setStateType(t cty.Type, path []string, at cty.Type) cty.Type {
+	if t.IsListType() {
+		return cty.List(setStateType(t.ElementType(), path, at))
+	}
+	if t.IsSetType() {
+		return cty.Set(setStateType(t.ElementType(), path, at))
+	}
+
+	attrs := make(map[string]cty.Type)
+	if t.IsObjectType() {
+		for name, existing := range t.AttributeTypes() {
+			attrs[name] = existing
+		}
+	}
+	if len(path) == 1 {
+		attrs[path[0]] = at
+		return cty.Object(attrs)
+	}
+
+	nested, ok := attrs[path[0]]
+	if !ok {
+		nested = cty.List(cty.EmptyObject)
+	}
+	attrs[path[0]] = setStateType(nested, path[1:], at)
+	return cty.Object(attrs)
+}
+
+// Returns true for an object type without attributes, or a list or set of them
+func isEmptyStateType(t cty.Type) bool {
+	if t.IsListType() || t.IsSetType() {
+		t = t.ElementType()
+	}
+	return t.IsObjectType() && len(t.AttributeTypes()) == 0
+}
+
+func commonPrefixLen(a, b []string) int {
+	n := 0
+	for n < len(a)-1 && n < len(b)-1 && a[n] == b[n] {
+		n++
+	}
+	return n
+}
@@ -0,0 +1,173 @@
+package tpgresource
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/go-cty/cty"
+)
+
+func TestMoveStateValue(t *testing.T) {
+	cases := map[string]struct {
+		RawState map[string]interface{}
+		From     []string
+		To       []string
+		Expected map[string]interface{}
+	}{
+		"rename": {
+			RawState: map[string]interface{}{"tier": "BASIC", "name": "foo"},
+			From:     []string{"tier"},
+			To:       []string{"machine_tier"},
+			Expected: map[string]interface{}{"machine_tier": "BASIC", "name": "foo"},
+		},
+		"rename in a nested object": {
+			RawState: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{"tier": "BASIC", "size": 1}},
+			},
+			From: []string{"settings", "tier"},
+			To:   []string{"settings", "machine_tier"},
+			Expected: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{"machine_tier": "BASIC", "size": 1}},
+			},
+		},
+		"rename in every element of a list": {
+			RawState: map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{"action": "allow"},
+					map[string]interface{}{"action": "deny"},
+				},
+			},
+			From: []string{"rules", "action"},
+			To:   []string{"rules", "verdict"},
+			Expected: map[string]interface{}{
+				"rules": []interface{}{
+					map[string]interface{}{"verdict": "allow"},
+					map[string]interface{}{"verdict": "deny"},
+				},
+			},
+		},
+		"flatten a nested object": {
+			RawState: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{"tier": "BASIC"}},
+			},
+			From:     []string{"settings", "tier"},
+			To:       []string{"tier"},
+			Expected: map[string]interface{}{"tier": "BASIC"},
+		},
+		"flatten a field of a nested object that keeps other fields": {
+			RawState: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{"tier": "BASIC", "size": 1}},
+			},
+			From: []string{"settings", "tier"},
+			To:   []string{"tier"},
+			Expected: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{"size": 1}},
+				"tier":     "BASIC",
+			},
+		},
+		"nest into a new object": {
+			RawState: map[string]interface{}{"tier": "BASIC"},
+			From:     []string{"tier"},
+			To:       []string{"settings", "tier"},
+			Expected: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{"tier": "BASIC"}},
+			},
+		},
+		"nest into an existing object": {
+			RawState: map[string]interface{}{
+				"tier":     "BASIC",
+				"settings": []interface{}{map[string]interface{}{"size": 1}},
+			},
+			From: []string{"tier"},
+			To:   []string{"settings", "tier"},
+			Expected: map[string]interface{}{
+				"settings": []interface{}{map[string]interface{}{"size": 1, "tier": "BASIC"}},
+			},
+		},
+		"missing value": {
+			RawState: map[string]interface{}{"name": "foo"},
+			From:     []string{"settings", "tier"},
+			To:       []string{"tier"},
+			Expected: map[string]interface{}{"name": "foo"},
+		},
+		"null value": {
+			RawState: map[string]interface{}{"tier": nil},
+			From:     []string{"tier"},
+			To:       []string{"machine_tier"},
+			Expected: map[string]interface{}{"tier": nil},
+		},
+	}
+
+	for tn, tc := range cases {
+		MoveStateValue(tc.RawState, tc.From, tc.To)
+		if !reflect.DeepEqual(tc.RawState, tc.Expected) {
+			t.Errorf("bad: %s, expected %#v, got %#v", tn, tc.Expected, tc.RawState)
+		}
+	}
+}
+
+func TestMoveStateType(t *testing.T) {
+	cases := map[string]struct {
+		Type     cty.Type
+		From     []string
+		To       []string
+		Expected cty.Type
+	}{
+		"rename": {
+			Type:     cty.Object(map[string]cty.Type{"machine_tier": cty.String, "name": cty.String}),
+			From:     []string{"machine_tier"},
+			To:       []string{"tier"},
+			Expected: cty.Object(map[string]cty.Type{"tier": cty.String, "name": cty.String}),
+		},
+		"rename in a list of objects": {
+			Type: cty.Object(map[string]cty.Type{
+				"rules": cty.List(cty.Object(map[string]cty.Type{"verdict": cty.String})),
+			}),
+			From: []string{"rules", "verdict"},
+			To:   []string{"rules", "action"},
+			Expected: cty.Object(map[string]cty.Type{
+				"rules": cty.List(cty.Object(map[string]cty.Type{"action": cty.String})),
+			}),
+		},
+		"nest into a new object": {
+			Type: cty.Object(map[string]cty.Type{"tier": cty.String}),
+			From: []string{"tier"},
+			To:   []string{"settings", "tier"},
+			Expected: cty.Object(map[string]cty.Type{
+				"settings": cty.List(cty.Object(map[string]cty.Type{"tier": cty.String})),
+			}),
+		},
+		"flatten a nested object": {
+			Type: cty.Object(map[string]cty.Type{
+				"settings": cty.List(cty.Object(map[string]cty.Type{"tier": cty.String})),
+			}),
+			From:     []string{"settings", "tier"},
+			To:       []string{"tier"},
+			Expected: cty.Object(map[string]cty.Type{"tier": cty.String}),
+		},
+		"flatten a field of a set of objects that keeps other fields": {
+			Type: cty.Object(map[string]cty.Type{
+				"settings": cty.Set(cty.Object(map[string]cty.Type{"tier": cty.String, "size": cty.Number})),
+			}),
+			From: []string{"settings", "tier"},
+			To:   []string{"tier"},
+			Expected: cty.Object(map[string]cty.Type{
+				"settings": cty.Set(cty.Object(map[string]cty.Type{"size": cty.Number})),
+				"tier":     cty.String,
+			}),
+		},
+		"missing field": {
+			Type:     cty.Object(map[string]cty.Type{"name": cty.String}),
+			From:     []string{"tier"},
+			To:       []string{"machine_tier"},
+			Expected: cty.Object(map[string]cty.Type{"name": cty.String}),
+		},
+	}
+
+	for tn, tc := range cases {
+		got := MoveStateType(tc.Type, tc.From, tc.To)
+		if !got.Equals(tc.Expected) {
+			t.Errorf("bad: %s, expected %#v, got %#v", tn, tc.Expected, got)
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"reflect"
 	"sync"
 	"time"
 
@@ -259,7 +260,13 @@ func (b *RequestBatcher) registerBatchRequest(batchKey string, newRequest *Batch
 
 func (b *RequestBatcher) sendBatchWithSingleRetry(batchKey string, batch *startedBatch) {
 	log.Printf("[DEBUG] Sending batch %q combining %d requests)", batchKey, len(batch.subscribers))
+	ctx, span := StartSpan(b.parentCtx, "RequestBatcher send",
+		telemetryBatcherKey.String(b.debugId),
+		telemetryBatchSizeKey.Int(len(batch.subscribers)),
+	)
+	recordTelemetryBatchSize(ctx, b.debugId, len(batch.subscribers))
 	resp := batch.send()
+	EndSpan(span, resp.err)
 
 	// If the batch failed and combines more than one request, retry each single request.
 	if resp.IsError() && len(batch.subscribers) > 1 {
@@ -334,3 +341,105 @@ func (req *BatchRequest) send() batchResponse {
 	v, err := req.SendF(req.ResourceName, req.Body)
 	return batchResponse{v, err}
 }
+
+// CombineBatchAppendList is a BatcherCombineFunc for request bodies that hold
+// lists of objects, eg: {"networkEndpoints": [...]}. The lists in the
+// top-level fields of both bodies are appended, and the other fields must
+// have the same value in both. Neither body is modified, as they may be sent
+// again as single requests.
+func CombineBatchAppendList(body interface{}, toAdd interface{}) (interface{}, error) {
+	curr, added, err := batchBodyMaps(body, toAdd)
+	if err != nil {
+		return nil, err
+	}
+
+	combined := make(map[string]interface{}, len(curr))
+	for k, v := range curr {
+		combined[k] = v
+	}
+	for k, v := range added {
+		existing, ok := combined[k]
+		if !ok {
+			combined[k] = v
+			continue
+		}
+		currList, currOk := batchBodyList(existing)
+		addedList, addedOk := batchBodyList(v)
+		switch {
+		case currOk && addedOk:
+			combined[k] = append(currList, addedList...)
+		case !reflect.DeepEqual(existing, v):
+			return nil, fmt.Errorf("Unable to combine batch request bodies with different values for field %q", k)
+		}
+	}
+	return combined, nil
+}
+
+// CombineBatchMergeMap is a BatcherCombineFunc for request bodies that are
+// merged into one object, eg: {"labels": {...}}. Objects in both bodies are
+// merged recursively, and other values set in both must be equal. Neither
+// body is modified, as they may be sent again as single requests.
+func CombineBatchMergeMap(body interface{}, toAdd interface{}) (interface{}, error) {
+	curr, added, err := batchBodyMaps(body, toAdd)
+	if err != nil {
+		return nil, err
+	}
+	return mergeBatchBodies(curr, added, "")
+}
+
+func mergeBatchBodies(curr, added map[string]interface{}, path string) (map[string]interface{}, error) {
+	merged := make(map[string]interface{}, len(curr))
+	for k, v := range curr {
+		merged[k] = v
+	}
+	for k, v := range added {
+		field := k
+		if path != "" {
+			field = path + "." + k
+		}
+		existing, ok := merged[k]
+		if !ok {
+			merged[k] = v
+			continue
+		}
+		existingMap, existingOk := existing.(map[string]interface{})
+		addedMap, addedOk := v.(map[string]interface{})
+		if existingOk && addedOk {
+			m, err := mergeBatchBodies(existingMap, addedMap, field)
+			if err != nil {
+				return nil, err
+			}
+			merged[k] = m
+			continue
+		}
+		if !reflect.DeepEqual(existing, v) {
+			return nil, fmt.Errorf("Unable to combine batch request bodies with different values for field %q", field)
+		}
+	}
+	return merged, nil
+}
+
+func batchBodyMaps(body interface{}, toAdd interface{}) (map[string]interface{}, map[string]interface{}, error) {
+	curr, ok := body.(map[string]interface{})
+	if !ok && body != nil {
+		return nil, nil, fmt.Errorf("Expected batch body type to be map[string]interface{}, got %T. This is a provider error.", body)
+	}
+	added, ok := toAdd.(map[string]interface{})
+	if !ok && toAdd != nil {
+		return nil, nil, fmt.Errorf("Expected new request body type to be map[string]interface{}, got %T. This is a provider error.", toAdd)
+	}
+	return curr, added, nil
+}
+
+// Returns a copy of v as a []interface{} if it's a slice of any type
+func batchBodyList(v interface{}) ([]interface{}, bool) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Slice {
+		return nil, false
+	}
+	l := make([]interface{}, 0, rv.Len())
+	for i := 0; i < rv.Len(); i++ {
+		l = append(l, rv.Index(i).Interface())
+	}
+	return l, true
+}
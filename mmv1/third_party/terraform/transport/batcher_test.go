@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
@@ -292,3 +293,73 @@ func testBasicCountBatches(t *testing.T, testName string, numBatches int) {
 		}(i)
 	}
 }
+
+func TestCombineBatchAppendList(t *testing.T) {
+	body := map[string]interface{}{
+		"networkEndpoints": []interface{}{"a"},
+		"kind":             "test",
+	}
+	toAdd := map[string]interface{}{
+		"networkEndpoints": []map[string]interface{}{{"ipAddress": "b"}},
+		"kind":             "test",
+	}
+
+	combined, err := CombineBatchAppendList(body, toAdd)
+	if err != nil {
+		t.Fatalf("got unexpected error %s", err)
+	}
+	expected := map[string]interface{}{
+		"networkEndpoints": []interface{}{"a", map[string]interface{}{"ipAddress": "b"}},
+		"kind":             "test",
+	}
+	if !reflect.DeepEqual(combined, expected) {
+		t.Errorf("expected combined body %v, got %v", expected, combined)
+	}
+	if l := body["networkEndpoints"].([]interface{}); len(l) != 1 {
+		t.Errorf("expected original body to be unmodified, got %v", body)
+	}
+
+	toAdd["kind"] = "other"
+	if _, err := CombineBatchAppendList(body, toAdd); err == nil {
+		t.Errorf("expected error combining bodies with different values, got none")
+	}
+
+	if _, err := CombineBatchAppendList(1, toAdd); err == nil {
+		t.Errorf("expected error combining non-map body, got none")
+	}
+}
+
+func TestCombineBatchMergeMap(t *testing.T) {
+	body := map[string]interface{}{
+		"labels": map[string]interface{}{"a": "1"},
+		"name":   "test",
+	}
+	toAdd := map[string]interface{}{
+		"labels": map[string]interface{}{"b": "2"},
+		"name":   "test",
+	}
+
+	combined, err := CombineBatchMergeMap(body, toAdd)
+	if err != nil {
+		t.Fatalf("got unexpected error %s", err)
+	}
+	expected := map[string]interface{}{
+		"labels": map[string]interface{}{"a": "1", "b": "2"},
+		"name":   "test",
+	}
+	if !reflect.DeepEqual(combined, expected) {
+		t.Errorf("expected combined body %v, got %v", expected, combined)
+	}
+	if l := body["labels"].(map[string]interface{}); len(l) != 1 {
+		t.Errorf("expected original body to be unmodified, got %v", body)
+	}
+
+	toAdd["labels"] = map[string]interface{}{"a": "2"}
+	_, err = CombineBatchMergeMap(body, toAdd)
+	if err == nil {
+		t.Fatalf("expected error merging conflicting values, got none")
+	}
+	if !strings.Contains(err.Error(), "labels.a") {
+		t.Errorf("expected error to reference field labels.a, got %v", err)
+	}
+}
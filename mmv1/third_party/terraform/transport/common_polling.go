@@ -1,6 +1,7 @@
 package transport
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"sync"
@@ -34,8 +35,20 @@ func SuccessPollResult() PollResult {
 
 func PollingWaitTime(pollF PollReadFunc, checkResponse PollCheckResponseFunc, activity string,
 	timeout time.Duration, targetOccurrences int) error {
+	return PollingWaitTimeContext(context.Background(), pollF, checkResponse, activity, timeout, targetOccurrences)
+}
+
+// PollingWaitTimeContext polls as part of the trace of ctx, eg: the context of
+// a ResourceSpan.
+func PollingWaitTimeContext(ctx context.Context, pollF PollReadFunc, checkResponse PollCheckResponseFunc, activity string,
+	timeout time.Duration, targetOccurrences int) (err error) {
 	log.Printf("[DEBUG] %s: Polling until expected state is read", activity)
 	log.Printf("[DEBUG] Target occurrences: %d", targetOccurrences)
+	_, endPollSpan := StartPollSpan(ctx, TelemetryPollKindResource, activity)
+	defer func() {
+		endPollSpan(err)
+	}()
+
 	if targetOccurrences == 1 {
 		return retry.Retry(timeout, func() *retry.RetryError {
 			readResp, readErr := pollF()
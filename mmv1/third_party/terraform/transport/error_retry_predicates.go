@@ -209,6 +209,47 @@ func MetadataRetryWrapper(update func() error) error {
 	return fmt.Errorf("Failed to update metadata after %d retries", attempt)
 }
 
+// Retry the request if it was rejected because the etag or fingerprint it was
+// made with is stale, which APIs report with a 412, or with a 409 ABORTED or
+// a 409 naming the etag or fingerprint. Other 409s, such as ALREADY_EXISTS,
+// aren't fixed by a retry. The request must be built again from a fresh read
+// of the resource to succeed.
+func IsPreconditionFailedError(err error) (bool, string) {
+	gerr, ok := err.(*googleapi.Error)
+	if !ok {
+		return false, ""
+	}
+
+	if gerr.Code == 412 {
+		return true, "etag or fingerprint mismatch"
+	}
+	if gerr.Code == 409 && isEtagConflict(gerr) {
+		return true, "etag or fingerprint mismatch"
+	}
+
+	return false, ""
+}
+
+func isEtagConflict(gerr *googleapi.Error) bool {
+	if strings.Contains(gerr.Body, "ABORTED") {
+		return true
+	}
+	messages := []string{gerr.Message}
+	for _, e := range gerr.Errors {
+		if e.Reason == "aborted" || e.Reason == "conditionNotMet" {
+			return true
+		}
+		messages = append(messages, e.Message)
+	}
+	for _, m := range messages {
+		m = strings.ToLower(m)
+		if strings.Contains(m, "etag") || strings.Contains(m, "fingerprint") {
+			return true
+		}
+	}
+	return false
+}
+
 // If a permission necessary to provision a resource is created in the same config
 // as the resource itself, the permission may not have propagated by the time terraform
 // attempts to create the resource. This allows those errors to be retried until the timeout expires
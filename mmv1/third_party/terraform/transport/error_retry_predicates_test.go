@@ -214,3 +214,41 @@ func TestExternalIpServiceNotActive(t *testing.T) {
 		t.Errorf("Error not detected as retryable")
 	}
 }
+
+func TestIsPreconditionFailedError(t *testing.T) {
+	cases := map[string]struct {
+		Err       *googleapi.Error
+		Retryable bool
+	}{
+		"412": {
+			Err:       &googleapi.Error{Code: 412},
+			Retryable: true,
+		},
+		"409 aborted": {
+			Err:       &googleapi.Error{Code: 409, Body: `{"error": {"code": 409, "status": "ABORTED"}}`},
+			Retryable: true,
+		},
+		"409 aborted reason": {
+			Err:       &googleapi.Error{Code: 409, Errors: []googleapi.ErrorItem{{Reason: "aborted"}}},
+			Retryable: true,
+		},
+		"409 etag message": {
+			Err:       &googleapi.Error{Code: 409, Message: "The etag provided does not match the current etag of the policy"},
+			Retryable: true,
+		},
+		"409 already exists": {
+			Err:       &googleapi.Error{Code: 409, Message: "Resource already exists", Body: `{"error": {"code": 409, "status": "ALREADY_EXISTS"}}`},
+			Retryable: false,
+		},
+		"400": {
+			Err:       &googleapi.Error{Code: 400, Message: "Invalid etag"},
+			Retryable: false,
+		},
+	}
+
+	for tn, tc := range cases {
+		if isRetryable, _ := IsPreconditionFailedError(tc.Err); isRetryable != tc.Retryable {
+			t.Errorf("bad: %s, expected retryable to be %t", tn, tc.Retryable)
+		}
+	}
+}
@@ -0,0 +1,339 @@
+package transport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The value written to the HTTP log in place of a redacted value
+const httpLogRedactedValue = "REDACTED"
+
+// Headers carrying credentials, whose values are never written to the HTTP log
+var httpLogRedactedHeaders = map[string]bool{
+	"Authorization":                     true,
+	"Proxy-Authorization":               true,
+	"Cookie":                            true,
+	"Set-Cookie":                        true,
+	"X-Goog-Api-Key":                    true,
+	"X-Goog-Iam-Authorization-Token":    true,
+	"X-Goog-Encryption-Key":             true,
+	"X-Goog-Copy-Source-Encryption-Key": true,
+}
+
+// Query parameters carrying credentials, whose values are never written to
+// the HTTP log
+var httpLogRedactedQueryParams = map[string]bool{
+	"access_token":     true,
+	"key":              true,
+	"client_secret":    true,
+	"refresh_token":    true,
+	"x-goog-signature": true,
+}
+
+// Fields of request and response bodies carrying credentials for any
+// resource, in addition to the fields registered by each resource. Field
+// names are compared by normalizeHttpLogField.
+var httpLogRedactedBodyFields = map[string]bool{
+	"password":       true,
+	"clientsecret":   true,
+	"privatekey":     true,
+	"privatekeydata": true,
+	"accesstoken":    true,
+	"refreshtoken":   true,
+	"idtoken":        true,
+	"apikey":         true,
+	"keystring":      true,
+}
+
+// The body fields redacted for each resource type, eg: google_sql_user
+var httpLogResourceRedactedFields sync.Map
+
+// RegisterHttpLogRedactedFields makes the HTTP log redact the values of the
+// given API fields from the requests and responses of a resource type, and
+// from requests made without a resource. The fields are redacted wherever
+// they are nested in a body.
+func RegisterHttpLogRedactedFields(resourceType string, fields ...string) {
+	redacted := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		redacted[normalizeHttpLogField(f)] = true
+	}
+	httpLogResourceRedactedFields.Store(resourceType, redacted)
+}
+
+// API fields are sent as lowerCamelCase, but are also accepted as snake_case
+func normalizeHttpLogField(field string) string {
+	return strings.ToLower(strings.ReplaceAll(field, "_", ""))
+}
+
+type httpLogResourceKey struct{}
+
+// httpLogResource is the operation of a resource an API call is made for.
+type httpLogResource struct {
+	resourceType string
+	operation    string
+	id           string
+}
+
+func contextWithHttpLogResource(ctx context.Context, resourceType, operation, id string) context.Context {
+	return context.WithValue(ctx, httpLogResourceKey{}, httpLogResource{
+		resourceType: resourceType,
+		operation:    operation,
+		id:           id,
+	})
+}
+
+type requestAttemptKey struct{}
+
+// contextWithRequestAttempt records which attempt of a request, starting at
+// 0, is sent with ctx.
+func contextWithRequestAttempt(ctx context.Context, attempt int) context.Context {
+	return context.WithValue(ctx, requestAttemptKey{}, attempt)
+}
+
+func requestAttemptFromContext(ctx context.Context) int {
+	attempt, _ := ctx.Value(requestAttemptKey{}).(int)
+	return attempt
+}
+
+// HttpLogSink writes the entries of the HTTP log as JSON lines.
+type HttpLogSink struct {
+	mu sync.Mutex
+	w  io.Writer
+}
+
+var (
+	httpLogSinksMu sync.Mutex
+	httpLogSinks   = make(map[string]*HttpLogSink)
+)
+
+// OpenHttpLogSink opens the HTTP log file at path for appending, creating it
+// readable only by the user if needed. Every configuration of the provider
+// logging to the same path shares its sink. No sink is returned if path is
+// empty.
+func OpenHttpLogSink(path string) (*HttpLogSink, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	httpLogSinksMu.Lock()
+	defer httpLogSinksMu.Unlock()
+	if sink, ok := httpLogSinks[path]; ok {
+		return sink, nil
+	}
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("opening the HTTP log file: %w", err)
+	}
+	sink := &HttpLogSink{w: f}
+	httpLogSinks[path] = sink
+	return sink, nil
+}
+
+func (s *HttpLogSink) write(entry *httpLogEntry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		log.Printf("[WARN] HTTP Log Transport: unable to encode the log entry of %s %s: %s", entry.Method, entry.URL, err)
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, err := s.w.Write(append(line, '\n')); err != nil {
+		log.Printf("[WARN] HTTP Log Transport: unable to write the log entry of %s %s: %s", entry.Method, entry.URL, err)
+	}
+}
+
+type httpLogEntry struct {
+	Time            string            `json:"time"`
+	Method          string            `json:"method"`
+	URL             string            `json:"url"`
+	Status          int               `json:"status,omitempty"`
+	LatencyMs       float64           `json:"latency_ms"`
+	Attempt         int               `json:"attempt"`
+	Resource        string            `json:"resource,omitempty"`
+	Operation       string            `json:"operation,omitempty"`
+	ResourceId      string            `json:"resource_id,omitempty"`
+	RequestHeaders  map[string]string `json:"request_headers,omitempty"`
+	RequestBody     json.RawMessage   `json:"request_body,omitempty"`
+	ResponseHeaders map[string]string `json:"response_headers,omitempty"`
+	ResponseBody    json.RawMessage   `json:"response_body,omitempty"`
+	Error           string            `json:"error,omitempty"`
+}
+
+// A http.RoundTripper that writes each request and its response to the HTTP
+// log as a JSON line, with the values of credential headers, query
+// parameters and sensitive body fields redacted. Unlike the debug log, it is
+// safe to collect in CI. Only JSON bodies are written.
+type httpLogTransport struct {
+	sink     *HttpLogSink
+	internal http.RoundTripper
+}
+
+// NewTransportWithHttpLog constructs a httpLogTransport writing to sink.
+// Requests are sent without being logged if sink is nil.
+func NewTransportWithHttpLog(t http.RoundTripper, sink *HttpLogSink) *httpLogTransport {
+	return &httpLogTransport{
+		sink:     sink,
+		internal: t,
+	}
+}
+
+// RoundTrip implements the RoundTripper interface method.
+func (t *httpLogTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.sink == nil {
+		return t.internal.RoundTrip(req)
+	}
+
+	resource, _ := req.Context().Value(httpLogResourceKey{}).(httpLogResource)
+	redact := httpLogRedactor(resource.resourceType)
+
+	entry := &httpLogEntry{
+		Method:         req.Method,
+		URL:            redactHttpLogURL(req.URL),
+		Attempt:        requestAttemptFromContext(req.Context()) + 1,
+		Resource:       resource.resourceType,
+		Operation:      resource.operation,
+		ResourceId:     resource.id,
+		RequestHeaders: redactHttpLogHeaders(req.Header),
+	}
+	if isJsonHttpLogBody(req.Header) && req.Body != nil && req.Body != http.NoBody {
+		body, err := io.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+		entry.RequestBody = redactHttpLogBody(body, redact)
+	}
+
+	start := time.Now()
+	resp, err := t.internal.RoundTrip(req)
+	entry.Time = start.UTC().Format(time.RFC3339Nano)
+	entry.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+
+	if err != nil {
+		entry.Error = err.Error()
+	}
+	if resp != nil {
+		entry.Status = resp.StatusCode
+		entry.ResponseHeaders = redactHttpLogHeaders(resp.Header)
+		if isJsonHttpLogBody(resp.Header) && resp.Body != nil {
+			body, readErr := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = io.NopCloser(bytes.NewReader(body))
+			if readErr != nil {
+				entry.Error = fmt.Sprintf("reading the response body: %s", readErr)
+			} else {
+				entry.ResponseBody = redactHttpLogBody(body, redact)
+			}
+		}
+	}
+
+	t.sink.write(entry)
+	return resp, err
+}
+
+// httpLogRedactor returns whether a body field of a request made for a
+// resource type is redacted. Requests made without a resource, eg: the polls
+// of an operation outside of a resource span, can carry the fields of any
+// resource, so the fields registered by every resource are redacted from them.
+func httpLogRedactor(resourceType string) func(string) bool {
+	return func(field string) bool {
+		f := normalizeHttpLogField(field)
+		if httpLogRedactedBodyFields[f] {
+			return true
+		}
+		if resourceType == "" {
+			redacted := false
+			httpLogResourceRedactedFields.Range(func(_, fields interface{}) bool {
+				redacted = fields.(map[string]bool)[f]
+				return !redacted
+			})
+			return redacted
+		}
+		fields, _ := httpLogResourceRedactedFields.Load(resourceType)
+		resourceFields, _ := fields.(map[string]bool)
+		return resourceFields[f]
+	}
+}
+
+func isJsonHttpLogBody(h http.Header) bool {
+	return strings.Contains(h.Get("Content-Type"), "json")
+}
+
+func redactHttpLogURL(u *url.URL) string {
+	redacted := *u
+	redacted.User = nil
+	q := redacted.Query()
+	for k := range q {
+		if httpLogRedactedQueryParams[strings.ToLower(k)] {
+			q.Set(k, httpLogRedactedValue)
+		}
+	}
+	if len(q) > 0 {
+		redacted.RawQuery = q.Encode()
+	}
+	return redacted.String()
+}
+
+func redactHttpLogHeaders(h http.Header) map[string]string {
+	if len(h) == 0 {
+		return nil
+	}
+	headers := make(map[string]string, len(h))
+	for k, v := range h {
+		if httpLogRedactedHeaders[http.CanonicalHeaderKey(k)] {
+			headers[k] = httpLogRedactedValue
+			continue
+		}
+		headers[k] = strings.Join(v, ", ")
+	}
+	return headers
+}
+
+// redactHttpLogBody returns the JSON body with the values of the redacted
+// fields replaced, or nothing if it can't be parsed, as its fields can't be
+// redacted then.
+func redactHttpLogBody(body []byte, redact func(string) bool) json.RawMessage {
+	if len(bytes.TrimSpace(body)) == 0 {
+		return nil
+	}
+	var v interface{}
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil
+	}
+	redacted, err := json.Marshal(redactHttpLogValue(v, redact))
+	if err != nil {
+		return nil
+	}
+	return redacted
+}
+
+func redactHttpLogValue(v interface{}, redact func(string) bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, fv := range v {
+			if fv != nil && redact(k) {
+				v[k] = httpLogRedactedValue
+				continue
+			}
+			v[k] = redactHttpLogValue(fv, redact)
+		}
+	case []interface{}:
+		for i, iv := range v {
+			v[i] = redactHttpLogValue(iv, redact)
+		}
+	}
+	return v
+}
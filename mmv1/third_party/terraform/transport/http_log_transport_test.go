@@ -0,0 +1,238 @@
+package transport
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func testHttpLogTransport_entries(t *testing.T, buf *bytes.Buffer) []httpLogEntry {
+	var entries []httpLogEntry
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry httpLogEntry
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("expected the log line to be JSON, got %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// Check that credentials and the sensitive fields of a resource are redacted
+// from the log, but are still sent and received
+func TestHttpLogTransport_Redacts(t *testing.T) {
+	RegisterHttpLogRedactedFields("google_test_widget", "adminPassword", "sharedSecret")
+
+	const requestBody = `{"name": "widget", "adminPassword": "hunter2", "nodes": [{"shared_secret": "s3cr3t", "privateKey": "pk"}], "password": null}`
+	const responseBody = `{"name": "widget", "sharedSecret": "s3cr3t"}`
+	var received string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("[ERROR] unable to read request body: %v", err)
+		}
+		received = string(body)
+		w.Header().Set("Content-Type", "application/json; charset=UTF-8")
+		if _, err := w.Write([]byte(responseBody)); err != nil {
+			t.Errorf("[ERROR] unable to write to response writer: %v", err)
+		}
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	client := ts.Client()
+	client.Transport = NewTransportWithHttpLog(http.DefaultTransport, &HttpLogSink{w: &buf})
+
+	span := StartResourceSpan("google_test_widget", "create", "")
+	defer span.End()
+	req, err := http.NewRequestWithContext(span.Context(), "POST", ts.URL+"/v1/widgets?alt=json&key=abc123", strings.NewReader(requestBody))
+	if err != nil {
+		t.Fatalf("unable to construct request: %v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer ya29.token")
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	defer resp.Body.Close()
+
+	if received != requestBody {
+		t.Errorf("expected the request body to be sent unchanged, got %q", received)
+	}
+	if body, _ := io.ReadAll(resp.Body); string(body) != responseBody {
+		t.Errorf("expected the response body to be received unchanged, got %q", string(body))
+	}
+
+	for _, secret := range []string{"hunter2", "s3cr3t", "\"pk\"", "abc123", "ya29.token"} {
+		if strings.Contains(buf.String(), secret) {
+			t.Errorf("expected %q to be redacted from the log, got %s", secret, buf.String())
+		}
+	}
+
+	entries := testHttpLogTransport_entries(t, &buf)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	entry := entries[0]
+	if entry.Method != "POST" || entry.Status != http.StatusOK || entry.Attempt != 1 {
+		t.Errorf("expected the entry of the first attempt of a successful POST, got %#v", entry)
+	}
+	if entry.Resource != "google_test_widget" || entry.Operation != "create" {
+		t.Errorf("expected the entry to have the resource of the request, got %q %q", entry.Resource, entry.Operation)
+	}
+	if !strings.Contains(entry.URL, "alt=json") {
+		t.Errorf("expected other query parameters to be logged, got %q", entry.URL)
+	}
+	if !strings.Contains(string(entry.RequestBody), `"name":"widget"`) || !strings.Contains(string(entry.RequestBody), `"password":null`) {
+		t.Errorf("expected other fields of the request body to be logged, got %s", entry.RequestBody)
+	}
+	if !strings.Contains(string(entry.ResponseBody), `"sharedSecret":"REDACTED"`) {
+		t.Errorf("expected the sensitive field of the response body to be redacted, got %s", entry.ResponseBody)
+	}
+}
+
+// Check that the fields of a resource aren't redacted from the requests of
+// other resources
+func TestHttpLogTransport_RedactsOnlyResourceFields(t *testing.T) {
+	RegisterHttpLogRedactedFields("google_test_gadget", "displayName")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(`{"displayName": "gadget"}`)); err != nil {
+			t.Errorf("[ERROR] unable to write to response writer: %v", err)
+		}
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	client := ts.Client()
+	client.Transport = NewTransportWithHttpLog(http.DefaultTransport, &HttpLogSink{w: &buf})
+
+	span := StartResourceSpan("google_test_widget", "read", "widgets/widget")
+	defer span.End()
+	req, err := http.NewRequestWithContext(span.Context(), "GET", ts.URL, nil)
+	if err != nil {
+		t.Fatalf("unable to construct request: %v", err)
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	resp.Body.Close()
+
+	entries := testHttpLogTransport_entries(t, &buf)
+	if len(entries) != 1 || !strings.Contains(string(entries[0].ResponseBody), `"displayName":"gadget"`) {
+		t.Errorf("expected the response body to be logged unredacted, got %s", buf.String())
+	}
+}
+
+// Check that the fields of every resource are redacted from requests made
+// without a resource, eg: the polls of an operation whose response holds the
+// resource
+func TestHttpLogTransport_RedactsOperationPolls(t *testing.T) {
+	RegisterHttpLogRedactedFields("google_test_widget", "adminPassword", "sharedSecret")
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(`{"name": "operations/op-1", "done": true, "response": {"name": "widget", "sharedSecret": "s3cr3t"}}`)); err != nil {
+			t.Errorf("[ERROR] unable to write to response writer: %v", err)
+		}
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	client := ts.Client()
+	client.Transport = NewTransportWithHttpLog(http.DefaultTransport, &HttpLogSink{w: &buf})
+
+	res, err := SendRequest(SendRequestOptions{
+		Config: &Config{Client: client},
+		Method: "GET",
+		RawURL: ts.URL + "/v1/operations/op-1",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if response, _ := res["response"].(map[string]interface{}); response["sharedSecret"] != "s3cr3t" {
+		t.Errorf("expected the operation to be received unchanged, got %v", res)
+	}
+
+	if strings.Contains(buf.String(), "s3cr3t") {
+		t.Errorf("expected %q to be redacted from the log, got %s", "s3cr3t", buf.String())
+	}
+	entries := testHttpLogTransport_entries(t, &buf)
+	if len(entries) != 1 {
+		t.Fatalf("expected 1 log entry, got %d", len(entries))
+	}
+	if entries[0].Resource != "" || !strings.Contains(string(entries[0].ResponseBody), `"name":"widget"`) {
+		t.Errorf("expected the operation poll to be logged without a resource, got %#v", entries[0])
+	}
+}
+
+// Check that each attempt of a retried request is logged
+func TestHttpLogTransport_RetryAttempts(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(testRetryTransportCodeRetry)
+			return
+		}
+		w.WriteHeader(testRetryTransportCodeSuccess)
+	}))
+	defer ts.Close()
+
+	var buf bytes.Buffer
+	client := ts.Client()
+	client.Transport = &retryTransport{
+		internal:        NewTransportWithHttpLog(http.DefaultTransport, &HttpLogSink{w: &buf}),
+		retryPredicates: []RetryErrorPredicateFunc{testRetryTransportRetryPredicate},
+		baseBackoff:     time.Millisecond,
+	}
+
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkSuccess(t, resp, err)
+
+	entries := testHttpLogTransport_entries(t, &buf)
+	if len(entries) != 2 {
+		t.Fatalf("expected 2 log entries, got %d", len(entries))
+	}
+	for i, entry := range entries {
+		if entry.Attempt != i+1 {
+			t.Errorf("expected entry %d to be attempt %d, got %d", i, i+1, entry.Attempt)
+		}
+	}
+	if entries[0].Status != testRetryTransportCodeRetry || entries[1].Status != testRetryTransportCodeSuccess {
+		t.Errorf("expected the statuses of both attempts to be logged, got %d and %d", entries[0].Status, entries[1].Status)
+	}
+}
+
+func TestOpenHttpLogSink(t *testing.T) {
+	if sink, err := OpenHttpLogSink(""); sink != nil || err != nil {
+		t.Errorf("expected no sink for an empty path, got %v, %v", sink, err)
+	}
+
+	path := filepath.Join(t.TempDir(), "http.log")
+	sink, err := OpenHttpLogSink(path)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	if again, _ := OpenHttpLogSink(path); again != sink {
+		t.Errorf("expected the sink of a path to be shared")
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		t.Fatalf("expected the log file to be created: %v", err)
+	}
+	if perm := info.Mode().Perm(); perm != 0600 {
+		t.Errorf("expected the log file to only be readable by the user, got %v", perm)
+	}
+}
@@ -0,0 +1,128 @@
+package transport
+
+import (
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strings"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// RateLimit limits the rate of requests the provider sends to an API host,
+// or to one HTTP method of an API host, eg: compute.googleapis.com
+type RateLimit struct {
+	Host              string
+	Method            string
+	RequestsPerSecond float64
+	// The number of requests that can be sent at once before the rate
+	// applies. Defaults to the requests per second, rounded up.
+	Burst int
+}
+
+type rateLimitKey struct {
+	host   string
+	method string
+}
+
+// A http.RoundTripper that waits for a token of a token bucket per API host
+// before each request. The buckets are shared by every resource using the
+// transport, so a large apply doesn't exhaust the per-minute quota of an API.
+type rateLimitTransport struct {
+	limiters map[rateLimitKey]*rate.Limiter
+	internal http.RoundTripper
+}
+
+// NewTransportWithRateLimits constructs a rateLimitTransport with a token
+// bucket for each of the rate limits. Requests to hosts without a rate limit
+// are sent immediately.
+func NewTransportWithRateLimits(t http.RoundTripper, limits []RateLimit) *rateLimitTransport {
+	limiters := make(map[rateLimitKey]*rate.Limiter)
+	for _, l := range limits {
+		burst := l.Burst
+		if burst <= 0 {
+			burst = int(math.Max(1, math.Ceil(l.RequestsPerSecond)))
+		}
+		limiters[rateLimitKeyFor(l.Host, l.Method)] = rate.NewLimiter(rate.Limit(l.RequestsPerSecond), burst)
+	}
+
+	return &rateLimitTransport{
+		limiters: limiters,
+		internal: t,
+	}
+}
+
+func rateLimitKeyFor(host, method string) rateLimitKey {
+	return rateLimitKey{host: strings.ToLower(host), method: strings.ToUpper(method)}
+}
+
+// RoundTrip implements the RoundTripper interface method. A rate limit for
+// the host and method of the request takes precedence over one for the host.
+func (t *rateLimitTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	host := req.URL.Hostname()
+	limiter, ok := t.limiters[rateLimitKeyFor(host, req.Method)]
+	if !ok {
+		limiter, ok = t.limiters[rateLimitKeyFor(host, "")]
+	}
+	if !ok {
+		return t.internal.RoundTrip(req)
+	}
+
+	start := time.Now()
+	if err := limiter.Wait(req.Context()); err != nil {
+		return nil, fmt.Errorf("waiting for the rate limit of %s: %w", host, err)
+	}
+	if waited := time.Since(start); waited >= time.Millisecond {
+		log.Printf("[DEBUG] Rate Limit Transport: waited %s for the rate limit of %s before %s %s", waited, host, req.Method, req.URL.Path)
+	}
+	return t.internal.RoundTrip(req)
+}
+
+func ExpandProviderRateLimits(v interface{}) ([]RateLimit, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	var limits []RateLimit
+	seen := make(map[rateLimitKey]bool)
+	for _, raw := range v.([]interface{}) {
+		if raw == nil {
+			continue
+		}
+		cfgV := raw.(map[string]interface{})
+
+		var l RateLimit
+		if host, ok := cfgV["host"]; ok {
+			l.Host = host.(string)
+		}
+		if method, ok := cfgV["method"]; ok {
+			l.Method = method.(string)
+		}
+		if rps, ok := cfgV["requests_per_second"]; ok {
+			l.RequestsPerSecond = rps.(float64)
+		}
+		if burst, ok := cfgV["burst"]; ok {
+			l.Burst = burst.(int)
+		}
+
+		if l.Host == "" {
+			return nil, fmt.Errorf("'host' must be set in each 'rate_limits' block")
+		}
+		if l.RequestsPerSecond <= 0 {
+			return nil, fmt.Errorf("'requests_per_second' of the rate limit of %q must be greater than 0, got %v", l.Host, l.RequestsPerSecond)
+		}
+		if l.Burst < 0 {
+			return nil, fmt.Errorf("'burst' of the rate limit of %q must not be negative, got %d", l.Host, l.Burst)
+		}
+		key := rateLimitKeyFor(l.Host, l.Method)
+		if seen[key] {
+			return nil, fmt.Errorf("duplicate rate limit for host %q and method %q", l.Host, l.Method)
+		}
+		seen[key] = true
+
+		limits = append(limits, l)
+	}
+	return limits, nil
+}
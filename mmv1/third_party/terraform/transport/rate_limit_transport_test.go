@@ -0,0 +1,194 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func setUpRateLimitTransportServerClient(limits []RateLimit) (*httptest.Server, *http.Client, *int32) {
+	var requests int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	client := ts.Client()
+	client.Transport = NewTransportWithRateLimits(http.DefaultTransport, limits)
+	return ts, client, &requests
+}
+
+func testRateLimitTransport_send(t *testing.T, client *http.Client, method, url string) time.Duration {
+	req, err := http.NewRequest(method, url, nil)
+	if err != nil {
+		t.Fatalf("unable to construct request: %v", err)
+	}
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	resp.Body.Close()
+	return time.Since(start)
+}
+
+// Check that requests beyond the burst wait for the rate of their host
+func TestRateLimitTransport_LimitsHost(t *testing.T) {
+	ts, client, requests := setUpRateLimitTransportServerClient([]RateLimit{
+		{Host: "127.0.0.1", RequestsPerSecond: 5, Burst: 1},
+	})
+	defer ts.Close()
+
+	testRateLimitTransport_send(t, client, "GET", ts.URL)
+	if waited := testRateLimitTransport_send(t, client, "GET", ts.URL); waited < 150*time.Millisecond {
+		t.Errorf("expected the second request to wait for the rate limit, waited %s", waited)
+	}
+	if got := atomic.LoadInt32(requests); got != 2 {
+		t.Errorf("expected 2 requests, got %d", got)
+	}
+}
+
+// Check that requests to hosts without a rate limit aren't delayed
+func TestRateLimitTransport_OtherHostUnlimited(t *testing.T) {
+	ts, client, _ := setUpRateLimitTransportServerClient([]RateLimit{
+		{Host: "compute.googleapis.com", RequestsPerSecond: 0.1, Burst: 1},
+	})
+	defer ts.Close()
+
+	for i := 0; i < 3; i++ {
+		if waited := testRateLimitTransport_send(t, client, "GET", ts.URL); waited > time.Second {
+			t.Errorf("expected request %d to be sent immediately, waited %s", i, waited)
+		}
+	}
+}
+
+// Check that the rate limit of a method takes precedence over the one of
+// the host
+func TestRateLimitTransport_LimitsMethod(t *testing.T) {
+	ts, client, _ := setUpRateLimitTransportServerClient([]RateLimit{
+		{Host: "127.0.0.1", RequestsPerSecond: 1000},
+		{Host: "127.0.0.1", Method: "post", RequestsPerSecond: 5, Burst: 1},
+	})
+	defer ts.Close()
+
+	testRateLimitTransport_send(t, client, "POST", ts.URL)
+	if waited := testRateLimitTransport_send(t, client, "GET", ts.URL); waited > 100*time.Millisecond {
+		t.Errorf("expected the GET request to use the rate limit of the host, waited %s", waited)
+	}
+	if waited := testRateLimitTransport_send(t, client, "POST", ts.URL); waited < 150*time.Millisecond {
+		t.Errorf("expected the second POST request to wait for the rate limit of the method, waited %s", waited)
+	}
+}
+
+// Check that waiting for the rate limit stops with the request's context
+func TestRateLimitTransport_ContextDone(t *testing.T) {
+	ts, client, requests := setUpRateLimitTransportServerClient([]RateLimit{
+		{Host: "127.0.0.1", RequestsPerSecond: 0.1, Burst: 1},
+	})
+	defer ts.Close()
+
+	testRateLimitTransport_send(t, client, "GET", ts.URL)
+
+	ctx, cc := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cc()
+	req, err := http.NewRequestWithContext(ctx, "GET", ts.URL, nil)
+	if err != nil {
+		t.Fatalf("unable to construct request: %v", err)
+	}
+	if _, err := client.Do(req); err == nil || !strings.Contains(err.Error(), "rate limit") {
+		t.Errorf("expected a rate limit error, got: %v", err)
+	}
+	if got := atomic.LoadInt32(requests); got != 1 {
+		t.Errorf("expected 1 request, got %d", got)
+	}
+}
+
+func TestExpandProviderRateLimits(t *testing.T) {
+	cases := map[string]struct {
+		Config      interface{}
+		Expected    []RateLimit
+		ExpectError bool
+	}{
+		"unset": {
+			Config: nil,
+		},
+		"host and method limits": {
+			Config: []interface{}{
+				map[string]interface{}{
+					"host":                "compute.googleapis.com",
+					"method":              "",
+					"requests_per_second": 20.0,
+					"burst":               0,
+				},
+				map[string]interface{}{
+					"host":                "compute.googleapis.com",
+					"method":              "POST",
+					"requests_per_second": 0.5,
+					"burst":               2,
+				},
+			},
+			Expected: []RateLimit{
+				{Host: "compute.googleapis.com", RequestsPerSecond: 20},
+				{Host: "compute.googleapis.com", Method: "POST", RequestsPerSecond: 0.5, Burst: 2},
+			},
+		},
+		"missing host": {
+			Config: []interface{}{
+				map[string]interface{}{
+					"host":                "",
+					"requests_per_second": 1.0,
+				},
+			},
+			ExpectError: true,
+		},
+		"zero rate": {
+			Config: []interface{}{
+				map[string]interface{}{
+					"host":                "iam.googleapis.com",
+					"requests_per_second": 0.0,
+				},
+			},
+			ExpectError: true,
+		},
+		"duplicate host": {
+			Config: []interface{}{
+				map[string]interface{}{
+					"host":                "iam.googleapis.com",
+					"requests_per_second": 1.0,
+				},
+				map[string]interface{}{
+					"host":                "IAM.googleapis.com",
+					"requests_per_second": 2.0,
+				},
+			},
+			ExpectError: true,
+		},
+	}
+
+	for tn, tc := range cases {
+		limits, err := ExpandProviderRateLimits(tc.Config)
+		if tc.ExpectError {
+			if err == nil {
+				t.Errorf("bad: %s, expected an error", tn)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("bad: %s, unexpected error: %s", tn, err)
+			continue
+		}
+		if len(limits) != len(tc.Expected) {
+			t.Errorf("bad: %s, expected %#v, got %#v", tn, tc.Expected, limits)
+			continue
+		}
+		for i := range limits {
+			if limits[i] != tc.Expected[i] {
+				t.Errorf("bad: %s, expected %#v, got %#v", tn, tc.Expected[i], limits[i])
+			}
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package transport
 
 import (
+	"net/http"
+	"net/http/httptest"
 	"net/url"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -210,3 +213,146 @@ func TestRetryWithPolling_retriedAndFailed(t *testing.T) {
 		t.Errorf("expected error function to be called exactly twice, but was called %d times", retryCount)
 	}
 }
+
+func TestRetryOnPreconditionFailure(t *testing.T) {
+	defer func(interval time.Duration) { preconditionRetryInterval = interval }(preconditionRetryInterval)
+	preconditionRetryInterval = 10 * time.Millisecond
+
+	i := 0
+	f := func() error {
+		i++
+		switch i {
+		case 1:
+			return &googleapi.Error{Code: 412}
+		case 2:
+			return errwrap.Wrapf("nested error: {{err}}", &googleapi.Error{Code: 409, Body: `{"error": {"code": 409, "status": "ABORTED"}}`})
+		}
+		return nil
+	}
+	if err := RetryOnPreconditionFailure(1*time.Minute, f); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if i != 3 {
+		t.Errorf("expected error function to be called 3 times, but was called %d times", i)
+	}
+}
+
+func TestRetryOnPreconditionFailure_noretry(t *testing.T) {
+	defer func(interval time.Duration) { preconditionRetryInterval = interval }(preconditionRetryInterval)
+	preconditionRetryInterval = 10 * time.Millisecond
+
+	i := 0
+	f := func() error {
+		i++
+		return &googleapi.Error{
+			Code: 500,
+		}
+	}
+	if err := RetryOnPreconditionFailure(1*time.Minute, f); err == nil || err.(*googleapi.Error).Code != 500 {
+		t.Errorf("unexpected error retrying: %v", err)
+	}
+	if i != 1 {
+		t.Errorf("expected error function to be called exactly once, but was called %d times", i)
+	}
+}
+
+func TestRetryOnPreconditionFailure_alreadyExists(t *testing.T) {
+	defer func(interval time.Duration) { preconditionRetryInterval = interval }(preconditionRetryInterval)
+	preconditionRetryInterval = 10 * time.Millisecond
+
+	i := 0
+	f := func() error {
+		i++
+		return &googleapi.Error{
+			Code:    409,
+			Message: "Resource already exists",
+			Body:    `{"error": {"code": 409, "message": "Resource already exists", "status": "ALREADY_EXISTS"}}`,
+		}
+	}
+	if err := RetryOnPreconditionFailure(1*time.Minute, f); err == nil || err.(*googleapi.Error).Code != 409 {
+		t.Errorf("unexpected error retrying: %v", err)
+	}
+	if i != 1 {
+		t.Errorf("expected error function to be called exactly once, but was called %d times", i)
+	}
+}
+
+func TestRetryOnPreconditionFailure_timeout(t *testing.T) {
+	defer func(interval time.Duration) { preconditionRetryInterval = interval }(preconditionRetryInterval)
+	preconditionRetryInterval = 10 * time.Millisecond
+
+	i := 0
+	f := func() error {
+		i++
+		return &googleapi.Error{
+			Code: 412,
+		}
+	}
+	if err := RetryOnPreconditionFailure(200*time.Millisecond, f); err == nil || err.(*googleapi.Error).Code != 412 {
+		t.Errorf("unexpected error retrying: %v", err)
+	}
+	if i < 2 {
+		t.Errorf("expected error function to be called at least twice, but was called %d times", i)
+	}
+}
+
+// Check that SendRequest waits for the delay asked for by Retry-After before
+// retrying
+func TestSendRequest_RetryAfter(t *testing.T) {
+	var attempts int32
+	var firstReqTime, retryReqTime time.Time
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			firstReqTime = time.Now()
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		retryReqTime = time.Now()
+		w.Write([]byte(`{"name": "widget"}`))
+	}))
+	defer ts.Close()
+
+	res, err := SendRequest(SendRequestOptions{
+		Config:  &Config{Client: ts.Client()},
+		Method:  "GET",
+		RawURL:  ts.URL,
+		Timeout: 10 * time.Second,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if res["name"] != "widget" {
+		t.Errorf("unexpected response %v", res)
+	}
+	if wait := retryReqTime.Sub(firstReqTime); wait < time.Second {
+		t.Errorf("expected to wait at least 1s before retrying, waited %s", wait)
+	}
+}
+
+// Check that SendRequest stops retrying once the retry budget of the host is
+// spent
+func TestSendRequest_RetryBudgetExhausted(t *testing.T) {
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&attempts, 1)
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer ts.Close()
+
+	// Retries are allowed while more than 2 tokens are left, so the first
+	// failure is retried and the second isn't
+	_, err := SendRequest(SendRequestOptions{
+		Config:  &Config{Client: ts.Client(), RetryBudgets: newRetryBudgets(4, 0.1)},
+		Method:  "GET",
+		RawURL:  ts.URL,
+		Timeout: 30 * time.Second,
+	})
+	if err == nil {
+		t.Fatalf("expected an error")
+	}
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("expected 2 attempts within the retry budget, got %d", got)
+	}
+}
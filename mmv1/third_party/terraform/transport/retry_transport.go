@@ -21,7 +21,7 @@
 //	c.clientCompute, err = compute.NewService(ctx, option.WithHTTPClient(client))
 //	...
 //	// If API needs custom additional retry predicates:
-//	sqlAdminHttpClient := ClientWithAdditionalRetries(client, retryTransport,
+//	sqlAdminHttpClient := ClientWithAdditionalRetries(client, config.RetryBudgets,
 //			isTemporarySqlError1,
 //			isTemporarySqlError2)
 //	c.clientSqlAdmin, err = compute.NewService(ctx, option.WithHTTPClient(sqlAdminHttpClient))
@@ -36,8 +36,12 @@ import (
 	"fmt"
 	"io/ioutil"
 	"log"
+	"math/rand"
 	"net/http"
 	"net/http/httputil"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/retry"
@@ -46,25 +50,43 @@ import (
 
 const defaultRetryTransportTimeoutSec = 90
 
+// The wait before a retry is a random duration up to the base backoff
+// doubled for each previous retry, capped at the max backoff.
+const defaultRetryTransportBaseBackoff = 500 * time.Millisecond
+const defaultRetryTransportMaxBackoff = 30 * time.Second
+
+// The retry budget of each API host of a provider. Each retryable failure
+// spends a token and each success earns back a fraction of one; retries stop
+// while half or fewer of the tokens are left, so that a failing API isn't
+// retried by every request at once.
+const defaultRetryBudgetMaxTokens = 100
+const defaultRetryBudgetTokenRatio = 0.1
+
 // NewTransportWithDefaultRetries constructs a default retryTransport that will retry common temporary errors
 func NewTransportWithDefaultRetries(t http.RoundTripper) *retryTransport {
 	return &retryTransport{
 		retryPredicates: defaultErrorRetryPredicates,
 		internal:        t,
+		baseBackoff:     defaultRetryTransportBaseBackoff,
+		maxBackoff:      defaultRetryTransportMaxBackoff,
+		budgets:         newRetryBudgets(defaultRetryBudgetMaxTokens, defaultRetryBudgetTokenRatio),
 	}
 }
 
 // Helper method to create a shallow copy of an HTTP client with a shallow-copied retryTransport
 // s.t. the base HTTP transport is the same (i.e. client connection pools are shared, retryPredicates are different)
-func ClientWithAdditionalRetries(baseClient *http.Client, predicates ...RetryErrorPredicateFunc) *http.Client {
+// The retries spend the given retry budgets, eg: the RetryBudgets of a Config,
+// and aren't budgeted if they're nil.
+func ClientWithAdditionalRetries(baseClient *http.Client, budgets *RetryBudgets, predicates ...RetryErrorPredicateFunc) *http.Client {
 	copied := *baseClient
 	baseRetryTransport := NewTransportWithDefaultRetries(baseClient.Transport)
+	baseRetryTransport.budgets = budgets
 	copied.Transport = baseRetryTransport.WithAddedPredicates(predicates...)
 	return &copied
 }
 
 // Returns a shallow copy of the retry transport with additional retry
-// predicates but same wrapped http.RoundTripper
+// predicates but same wrapped http.RoundTripper and retry budgets
 func (t *retryTransport) WithAddedPredicates(predicates ...RetryErrorPredicateFunc) *retryTransport {
 	copyT := *t
 	copyT.retryPredicates = append(t.retryPredicates, predicates...)
@@ -74,6 +96,14 @@ func (t *retryTransport) WithAddedPredicates(predicates ...RetryErrorPredicateFu
 type retryTransport struct {
 	retryPredicates []RetryErrorPredicateFunc
 	internal        http.RoundTripper
+
+	// Zero values use the default backoff
+	baseBackoff time.Duration
+	maxBackoff  time.Duration
+
+	// Shared by the shallow copies of the transport. If nil, retries are
+	// not budgeted.
+	budgets *RetryBudgets
 }
 
 // RoundTrip implements the RoundTripper interface method.
@@ -94,8 +124,7 @@ func (t *retryTransport) RoundTrip(req *http.Request) (resp *http.Response, resp
 	}
 
 	attempts := 0
-	backoff := time.Millisecond * 500
-	nextBackoff := time.Millisecond * 500
+	budget := t.budgets.forHost(req.URL.Hostname())
 
 	// VCR depends on the original request body being consumed, so
 	// consume here. Since this won't affect the request itself,
@@ -120,32 +149,52 @@ Retry:
 		}
 
 		log.Printf("[DEBUG] Retry Transport: request attempt %d", attempts)
+		attemptCtx, attemptSpan := StartSpan(req.Context(), req.Method,
+			telemetryHttpMethodKey.String(req.Method),
+			telemetryServerAddressKey.String(req.URL.Hostname()),
+			telemetryUrlPathKey.String(req.URL.Path),
+			telemetryHttpResendCountKey.Int(attempts),
+		)
 		// Do the wrapped Roundtrip. This is one request in the retry loop.
-		resp, respErr = t.internal.RoundTrip(newRequest)
+		resp, respErr = t.internal.RoundTrip(newRequest.WithContext(contextWithRequestAttempt(attemptCtx, attempts)))
 		attempts++
 
 		retryErr := t.checkForRetryableError(resp, respErr)
+		if resp != nil {
+			attemptSpan.SetAttributes(telemetryHttpStatusCodeKey.Int(resp.StatusCode))
+		}
+		if retryErr != nil {
+			EndSpan(attemptSpan, retryErr.Err)
+		} else {
+			EndSpan(attemptSpan, nil)
+		}
 		if retryErr == nil {
 			log.Printf("[DEBUG] Retry Transport: Stopping retries, last request was successful")
+			budget.recordSuccess()
 			break Retry
 		}
 		if !retryErr.Retryable {
 			log.Printf("[DEBUG] Retry Transport: Stopping retries, last request failed with non-retryable error: %s", retryErr.Err)
 			break Retry
 		}
+		if !budget.allowRetry() {
+			log.Printf("[DEBUG] Retry Transport: Stopping retries, retry budget exhausted: %s", retryErr.Err)
+			break Retry
+		}
+
+		delay, source := t.retryDelay(attempts, resp, retryErr.Err)
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			log.Printf("[DEBUG] Retry Transport: Stopping retries, waiting %s (%s) would pass the deadline", delay, source)
+			break Retry
+		}
 
-		log.Printf("[DEBUG] Retry Transport: Waiting %s before trying request again", backoff)
+		recordTelemetryRetry(ctx, req.URL.Hostname(), source)
+		log.Printf("[DEBUG] Retry Transport: retrying request method=%s url=%q attempt=%d delay=%s delay_source=%s reason=%q", req.Method, req.URL, attempts, delay, source, retryErr.Err)
 		select {
 		case <-ctx.Done():
 			log.Printf("[DEBUG] Retry Transport: Stopping retries, context done: %v", ctx.Err())
 			break Retry
-		case <-time.After(backoff):
-			log.Printf("[DEBUG] Retry Transport: Finished waiting %s before next retry", backoff)
-
-			// Fibonnaci backoff - 0.5, 1, 1.5, 2.5, 4, 6.5, 10.5, ...
-			lastBackoff := backoff
-			backoff = backoff + nextBackoff
-			nextBackoff = lastBackoff
+		case <-time.After(delay):
 			continue
 		}
 	}
@@ -153,6 +202,187 @@ Retry:
 	return resp, respErr
 }
 
+// The source of a retry delay, logged with each retry
+const (
+	retryDelaySourceBackoff    = "backoff"
+	retryDelaySourceRetryAfter = "retry-after"
+	retryDelaySourceRetryInfo  = "retry-info"
+)
+
+// retryDelay returns how long to wait before retrying after the given
+// number of attempts, with the backoff of the transport.
+func (t *retryTransport) retryDelay(attempts int, resp *http.Response, err error) (time.Duration, string) {
+	var header http.Header
+	if resp != nil {
+		header = resp.Header
+	}
+	return retryDelay(attempts, header, err, t.baseBackoff, t.maxBackoff)
+}
+
+// retryDelay returns how long to wait before retrying after the given
+// number of attempts. A delay asked for by the server, in a Retry-After
+// header or a google.rpc.RetryInfo error detail, is honoured; otherwise the
+// delay is a full jitter backoff. Without a response header, the header of a
+// Google API error is used. Zero backoffs use the default ones.
+func retryDelay(attempts int, header http.Header, err error, base, max time.Duration) (time.Duration, string) {
+	var gerr *googleapi.Error
+	isGoogleApiError := errors.As(err, &gerr)
+	if header == nil && isGoogleApiError {
+		header = gerr.Header
+	}
+	if d, ok := parseRetryAfter(header.Get("Retry-After")); ok {
+		return d, retryDelaySourceRetryAfter
+	}
+	if isGoogleApiError {
+		if d, ok := parseRetryInfo(gerr.Details); ok {
+			return d, retryDelaySourceRetryInfo
+		}
+	}
+
+	if base <= 0 {
+		base = defaultRetryTransportBaseBackoff
+	}
+	if max <= 0 {
+		max = defaultRetryTransportMaxBackoff
+	}
+	ceiling := base
+	for i := 1; i < attempts && ceiling < max; i++ {
+		ceiling *= 2
+	}
+	if ceiling > max {
+		ceiling = max
+	}
+	return time.Duration(rand.Int63n(int64(ceiling) + 1)), retryDelaySourceBackoff
+}
+
+// parseRetryAfter parses a Retry-After header, which is either a number of
+// seconds or an HTTP date.
+func parseRetryAfter(v string) (time.Duration, bool) {
+	v = strings.TrimSpace(v)
+	if v == "" {
+		return 0, false
+	}
+	if secs, err := strconv.Atoi(v); err == nil {
+		if secs < 0 {
+			return 0, false
+		}
+		return time.Duration(secs) * time.Second, true
+	}
+	if at, err := http.ParseTime(v); err == nil {
+		d := time.Until(at)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+	return 0, false
+}
+
+// parseRetryInfo finds the retry delay of a google.rpc.RetryInfo in the
+// details of a Google API error, eg: {"@type": "type.googleapis.com/google.rpc.RetryInfo", "retryDelay": "1.5s"}
+func parseRetryInfo(details []interface{}) (time.Duration, bool) {
+	for _, d := range details {
+		data, ok := d.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if dType, ok := data["@type"].(string); !ok || !strings.HasSuffix(dType, "google.rpc.RetryInfo") {
+			continue
+		}
+		v, ok := data["retryDelay"].(string)
+		if !ok {
+			continue
+		}
+		if delay, err := time.ParseDuration(v); err == nil && delay >= 0 {
+			return delay, true
+		}
+	}
+	return 0, false
+}
+
+// RetryBudgets holds the retry budget of each API host, so that the retries
+// of a failing API don't use up the budget of the others. The budgets of a
+// Config are shared by its retry transport, the copies of it with additional
+// retry predicates and the retries of SendRequest.
+type RetryBudgets struct {
+	mu         sync.Mutex
+	maxTokens  float64
+	tokenRatio float64
+	hosts      map[string]*retryBudget
+}
+
+func newRetryBudgets(maxTokens, tokenRatio float64) *RetryBudgets {
+	return &RetryBudgets{
+		maxTokens:  maxTokens,
+		tokenRatio: tokenRatio,
+		hosts:      make(map[string]*retryBudget),
+	}
+}
+
+// forHost returns the retry budget of an API host, creating it on first use.
+// Nil budgets return a nil budget, which doesn't limit retries.
+func (b *RetryBudgets) forHost(host string) *retryBudget {
+	if b == nil {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	budget, ok := b.hosts[host]
+	if !ok {
+		budget = newRetryBudget(b.maxTokens, b.tokenRatio)
+		b.hosts[host] = budget
+	}
+	return budget
+}
+
+// retryBudget limits the retries to an API host, in the way of gRPC retry
+// throttling.
+type retryBudget struct {
+	mu         sync.Mutex
+	maxTokens  float64
+	tokenRatio float64
+	tokens     float64
+}
+
+func newRetryBudget(maxTokens, tokenRatio float64) *retryBudget {
+	return &retryBudget{
+		maxTokens:  maxTokens,
+		tokenRatio: tokenRatio,
+		tokens:     maxTokens,
+	}
+}
+
+// allowRetry spends a token for a retryable failure, and reports whether
+// enough tokens are left to retry it. A nil budget allows every retry.
+func (b *retryBudget) allowRetry() bool {
+	if b == nil {
+		return true
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens--
+	if b.tokens < 0 {
+		b.tokens = 0
+	}
+	return b.tokens > b.maxTokens/2
+}
+
+// recordSuccess earns back a fraction of a token for a successful request.
+func (b *retryBudget) recordSuccess() {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.tokens += b.tokenRatio
+	if b.tokens > b.maxTokens {
+		b.tokens = b.maxTokens
+	}
+}
+
 // copyHttpRequest provides an copy of the given HTTP request for one RoundTrip.
 // If the request has a non-empty body (io.ReadCloser), the body is deep copied
 // so it can be consumed.
@@ -190,13 +420,15 @@ func (t *retryTransport) checkForRetryableError(resp *http.Response, respErr err
 		// returned cannot be edited. We need to consume the Body to check for
 		// errors, so we need to create a copy if the Response has a body.
 		if resp.Body != nil && resp.Body != http.NoBody {
-			// Use httputil.DumpResponse since the only important info is
-			// error code and messages in the response body.
-			dumpBytes, err := httputil.DumpResponse(resp, true)
+			// Only the body is copied, so that the error details in it can be
+			// parsed, eg: the retry delay of a google.rpc.RetryInfo
+			bodyBytes, err := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
 			if err != nil {
 				return retry.NonRetryableError(fmt.Errorf("unable to check response for error: %v", err))
 			}
-			respToCheck.Body = ioutil.NopCloser(bytes.NewReader(dumpBytes))
+			resp.Body = ioutil.NopCloser(bytes.NewReader(bodyBytes))
+			respToCheck.Body = ioutil.NopCloser(bytes.NewReader(bodyBytes))
 		}
 		errToCheck = googleapi.CheckResponse(&respToCheck)
 	}
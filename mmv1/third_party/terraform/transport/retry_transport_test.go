@@ -9,6 +9,7 @@ import (
 	"net/http/httptest"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -26,6 +27,8 @@ func setUpRetryTransportServerClient(hf http.Handler) (*httptest.Server, *http.C
 	client.Transport = &retryTransport{
 		internal:        http.DefaultTransport,
 		retryPredicates: []RetryErrorPredicateFunc{testRetryTransportRetryPredicate},
+		baseBackoff:     100 * time.Millisecond,
+		maxBackoff:      200 * time.Millisecond,
 	}
 	return ts, client
 }
@@ -169,6 +172,220 @@ func TestRetryTransport_DoesNotRetryEmptyGetBody(t *testing.T) {
 	testRetryTransport_checkFailedWhileRetrying(t, resp, err)
 }
 
+// Check that the wait before a retry is the one asked for by Retry-After
+func TestRetryTransport_RetryAfterHeader(t *testing.T) {
+	var attempts int32
+	var firstReqTime, retryReqTime time.Time
+	ts, client := setUpRetryTransportServerClient(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				firstReqTime = time.Now()
+				w.Header().Set("Retry-After", "1")
+				w.WriteHeader(testRetryTransportCodeRetry)
+				return
+			}
+			retryReqTime = time.Now()
+			w.WriteHeader(testRetryTransportCodeSuccess)
+		}))
+	defer ts.Close()
+
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkSuccess(t, resp, err)
+	if wait := retryReqTime.Sub(firstReqTime); wait < time.Second {
+		t.Errorf("expected to wait at least 1s before retrying, waited %s", wait)
+	}
+}
+
+// Check that the wait before a retry is the one asked for by a
+// google.rpc.RetryInfo error detail
+func TestRetryTransport_RetryInfoDetail(t *testing.T) {
+	var attempts int32
+	var firstReqTime, retryReqTime time.Time
+	ts, client := setUpRetryTransportServerClient(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			if atomic.AddInt32(&attempts, 1) == 1 {
+				firstReqTime = time.Now()
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(testRetryTransportCodeRetry)
+				body := `{"error": {"code": 500, "message": "try later", "details": [{"@type": "type.googleapis.com/google.rpc.RetryInfo", "retryDelay": "0.7s"}]}}`
+				if _, err := w.Write([]byte(body)); err != nil {
+					t.Errorf("[ERROR] unable to write to response writer: %v", err)
+				}
+				return
+			}
+			retryReqTime = time.Now()
+			w.WriteHeader(testRetryTransportCodeSuccess)
+		}))
+	defer ts.Close()
+
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkSuccess(t, resp, err)
+	if wait := retryReqTime.Sub(firstReqTime); wait < 700*time.Millisecond {
+		t.Errorf("expected to wait at least 700ms before retrying, waited %s", wait)
+	}
+}
+
+// Check that retries stop once the retry budget is spent
+func TestRetryTransport_RetryBudgetExhausted(t *testing.T) {
+	var attempts int32
+	ts, client := setUpRetryTransportServerClient(http.HandlerFunc(
+		func(w http.ResponseWriter, r *http.Request) {
+			atomic.AddInt32(&attempts, 1)
+			w.WriteHeader(testRetryTransportCodeRetry)
+		}))
+	defer ts.Close()
+
+	transport := client.Transport.(*retryTransport)
+	transport.baseBackoff = time.Millisecond
+	// Retries are allowed while more than 2 tokens are left, so the first
+	// failure is retried and the second isn't
+	transport.budgets = newRetryBudgets(4, 0.1)
+
+	resp, err := client.Get(ts.URL)
+	testRetryTransport_checkFailedWhileRetrying(t, resp, err)
+	if got := atomic.LoadInt32(&attempts); got != 2 {
+		t.Errorf("expected 2 attempts within the retry budget, got %d", got)
+	}
+}
+
+func TestRetryBudget(t *testing.T) {
+	b := newRetryBudget(10, 0.5)
+	for i := 0; i < 4; i++ {
+		if !b.allowRetry() {
+			t.Fatalf("expected retry %d to be allowed with %v tokens left", i, b.tokens)
+		}
+	}
+	if b.allowRetry() {
+		t.Fatalf("expected retry to be refused with %v tokens left", b.tokens)
+	}
+	b.recordSuccess()
+	b.recordSuccess()
+	b.recordSuccess()
+	if !b.allowRetry() {
+		t.Fatalf("expected retry to be allowed again after successes with %v tokens left", b.tokens)
+	}
+
+	var unbudgeted *retryBudget
+	if !unbudgeted.allowRetry() {
+		t.Fatalf("expected a nil budget to allow every retry")
+	}
+}
+
+// Check that a host out of retry budget doesn't stop the retries to others
+func TestRetryBudgets_PerHost(t *testing.T) {
+	budgets := newRetryBudgets(4, 0.1)
+	if budgets.forHost("compute.googleapis.com") != budgets.forHost("compute.googleapis.com") {
+		t.Fatalf("expected one budget per host")
+	}
+	budgets.forHost("compute.googleapis.com").allowRetry()
+	if budgets.forHost("compute.googleapis.com").allowRetry() {
+		t.Fatalf("expected the retry budget of compute.googleapis.com to be exhausted")
+	}
+	if !budgets.forHost("storage.googleapis.com").allowRetry() {
+		t.Fatalf("expected the retry budget of storage.googleapis.com to allow retries")
+	}
+
+	var unbudgeted *RetryBudgets
+	if unbudgeted.forHost("compute.googleapis.com") != nil {
+		t.Fatalf("expected nil budgets to return a nil budget")
+	}
+}
+
+// Check that the copies of a client with additional retry predicates spend
+// the given retry budgets instead of their own
+func TestClientWithAdditionalRetries_SharesRetryBudgets(t *testing.T) {
+	budgets := newRetryBudgets(defaultRetryBudgetMaxTokens, defaultRetryBudgetTokenRatio)
+	client := ClientWithAdditionalRetries(&http.Client{Transport: http.DefaultTransport}, budgets, testRetryTransportRetryPredicate)
+	if got := client.Transport.(*retryTransport).budgets; got != budgets {
+		t.Errorf("expected the client to share the retry budgets %p, got %p", budgets, got)
+	}
+}
+
+func TestRetryTransport_RetryDelay(t *testing.T) {
+	transport := &retryTransport{
+		baseBackoff: 100 * time.Millisecond,
+		maxBackoff:  time.Second,
+	}
+
+	cases := map[string]struct {
+		attempts       int
+		header         string
+		err            error
+		expectedSource string
+		expectedMin    time.Duration
+		expectedMax    time.Duration
+	}{
+		"first backoff": {
+			attempts:       1,
+			expectedSource: retryDelaySourceBackoff,
+			expectedMax:    100 * time.Millisecond,
+		},
+		"doubled backoff": {
+			attempts:       3,
+			expectedSource: retryDelaySourceBackoff,
+			expectedMax:    400 * time.Millisecond,
+		},
+		"capped backoff": {
+			attempts:       20,
+			expectedSource: retryDelaySourceBackoff,
+			expectedMax:    time.Second,
+		},
+		"retry after seconds": {
+			attempts:       1,
+			header:         "5",
+			expectedSource: retryDelaySourceRetryAfter,
+			expectedMin:    5 * time.Second,
+			expectedMax:    5 * time.Second,
+		},
+		"retry after date": {
+			attempts:       1,
+			header:         time.Now().Add(time.Minute).UTC().Format(http.TimeFormat),
+			expectedSource: retryDelaySourceRetryAfter,
+			expectedMin:    58 * time.Second,
+			expectedMax:    time.Minute,
+		},
+		"invalid retry after": {
+			attempts:       1,
+			header:         "soon",
+			expectedSource: retryDelaySourceBackoff,
+			expectedMax:    100 * time.Millisecond,
+		},
+		"retry info": {
+			attempts: 1,
+			err: &googleapi.Error{
+				Code: 429,
+				Details: []interface{}{
+					map[string]interface{}{
+						"@type":  "type.googleapis.com/google.rpc.ErrorInfo",
+						"reason": "RATE_LIMIT_EXCEEDED",
+					},
+					map[string]interface{}{
+						"@type":      "type.googleapis.com/google.rpc.RetryInfo",
+						"retryDelay": "2.5s",
+					},
+				},
+			},
+			expectedSource: retryDelaySourceRetryInfo,
+			expectedMin:    2500 * time.Millisecond,
+			expectedMax:    2500 * time.Millisecond,
+		},
+	}
+
+	for tn, tc := range cases {
+		resp := &http.Response{Header: http.Header{}}
+		if tc.header != "" {
+			resp.Header.Set("Retry-After", tc.header)
+		}
+		delay, source := transport.retryDelay(tc.attempts, resp, tc.err)
+		if source != tc.expectedSource {
+			t.Errorf("bad: %s, expected delay from %s, got %s", tn, tc.expectedSource, source)
+		}
+		if delay < tc.expectedMin || delay > tc.expectedMax {
+			t.Errorf("bad: %s, expected delay between %s and %s, got %s", tn, tc.expectedMin, tc.expectedMax, delay)
+		}
+	}
+}
+
 // handlers
 func testRetryTransportHandler_noRetries(t *testing.T, code int) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -1,7 +1,9 @@
 package transport
 
 import (
+	"context"
 	"log"
+	"math/rand"
 	"time"
 
 	"github.com/hashicorp/errwrap"
@@ -64,6 +66,46 @@ func Retry(opt RetryOptions) error {
 	})
 }
 
+// retryRequest calls opt.RetryFunc like Retry, but waits between retries
+// like the retry transport: for the delay asked for by the server in a
+// Retry-After header or a google.rpc.RetryInfo error detail, or else a full
+// jitter backoff. Each retry spends a token of the retry budget of the host,
+// which successes earn back in the retry transport.
+func retryRequest(ctx context.Context, host string, budget *retryBudget, opt RetryOptions) error {
+	if opt.Timeout == 0 {
+		opt.Timeout = 1 * time.Minute
+	}
+	deadline := time.Now().Add(opt.Timeout)
+
+	for attempts := 1; ; attempts++ {
+		err := opt.RetryFunc()
+		if err == nil {
+			return nil
+		}
+		if !IsRetryableError(err, opt.ErrorRetryPredicates, opt.ErrorAbortPredicates) {
+			return err
+		}
+		if !budget.allowRetry() {
+			log.Printf("[DEBUG] Stopping retries, retry budget exhausted: %s", err)
+			return err
+		}
+
+		delay, source := retryDelay(attempts, nil, err, 0, 0)
+		if time.Until(deadline) < delay {
+			log.Printf("[DEBUG] Stopping retries, waiting %s (%s) would pass the timeout: %s", delay, source, err)
+			return err
+		}
+
+		recordTelemetryRetry(ctx, host, source)
+		log.Printf("[DEBUG] Retrying request after %s (%s): %s", delay, source, err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+	}
+}
+
 func IsRetryableError(topErr error, retryPredicates, abortPredicates []RetryErrorPredicateFunc) bool {
 	if topErr == nil {
 		return false
@@ -102,3 +144,52 @@ func IsRetryableError(topErr error, retryPredicates, abortPredicates []RetryErro
 	})
 	return isRetryable
 }
+
+// The interval before the first retry of RetryOnPreconditionFailure, which
+// doubles on each retry up to preconditionRetryMaxInterval
+var preconditionRetryInterval = 1 * time.Second
+
+const preconditionRetryMaxInterval = 30 * time.Second
+
+// RetryOnPreconditionFailure calls f until it doesn't fail because of a stale
+// etag or fingerprint, or the timeout expires. f is expected to read the
+// resource it modifies again on each call. Retries are spaced by an
+// exponentially growing interval with jitter, so that concurrent writers of
+// the same resource don't keep colliding.
+func RetryOnPreconditionFailure(timeout time.Duration, f func() error) error {
+	if timeout == 0 {
+		timeout = 1 * time.Minute
+	}
+	deadline := time.Now().Add(timeout)
+
+	interval := preconditionRetryInterval
+	for {
+		err := f()
+		if err == nil {
+			return nil
+		}
+
+		isRetryable := false
+		errwrap.Walk(err, func(werr error) {
+			if ok, _ := IsPreconditionFailedError(werr); ok {
+				isRetryable = true
+			}
+		})
+		if !isRetryable {
+			return err
+		}
+
+		// Wait between half and all of the interval
+		wait := interval/2 + time.Duration(rand.Int63n(int64(interval/2)+1))
+		if time.Now().Add(wait).After(deadline) {
+			return err
+		}
+		log.Printf("[DEBUG] Retrying in %s after an etag or fingerprint mismatch: %s", wait, err)
+		time.Sleep(wait)
+
+		interval *= 2
+		if interval > preconditionRetryMaxInterval {
+			interval = preconditionRetryMaxInterval
+		}
+	}
+}
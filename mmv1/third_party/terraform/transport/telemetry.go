@@ -0,0 +1,259 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetrichttp"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// The name of the OpenTelemetry tracer and meter of the provider
+const telemetryInstrumentationName = "github.com/hashicorp/terraform-provider-google"
+
+const defaultTelemetryServiceName = "terraform-provider-google"
+
+// Attribute keys of the provider's spans and metrics. The HTTP ones follow
+// the OpenTelemetry semantic conventions.
+const (
+	telemetryResourceTypeKey      = attribute.Key("terraform.resource.type")
+	telemetryResourceOperationKey = attribute.Key("terraform.resource.operation")
+	telemetryResourceIdKey        = attribute.Key("terraform.resource.id")
+	telemetryHttpMethodKey        = attribute.Key("http.request.method")
+	telemetryHttpStatusCodeKey    = attribute.Key("http.response.status_code")
+	telemetryHttpResendCountKey   = attribute.Key("http.request.resend_count")
+	telemetryServerAddressKey     = attribute.Key("server.address")
+	telemetryUrlPathKey           = attribute.Key("url.path")
+	telemetryRetryDelaySourceKey  = attribute.Key("retry.delay_source")
+	telemetryPollKindKey          = attribute.Key("poll.kind")
+	telemetryPollActivityKey      = attribute.Key("poll.activity")
+	telemetryBatcherKey           = attribute.Key("batcher")
+	telemetryBatchSizeKey         = attribute.Key("batch.size")
+	telemetryErrorKey             = attribute.Key("error")
+)
+
+// The kinds of polling recorded in the poll duration metric
+const (
+	TelemetryPollKindOperation = "operation"
+	TelemetryPollKindResource  = "resource"
+)
+
+// telemetry holds the tracer and metric instruments of the provider, which
+// are no-ops unless telemetry is enabled.
+type telemetry struct {
+	tracer       trace.Tracer
+	retries      metric.Int64Counter
+	pollDuration metric.Float64Histogram
+	batchSize    metric.Int64Histogram
+}
+
+var currentTelemetry atomic.Pointer[telemetry]
+
+func newTelemetry(tp trace.TracerProvider, mp metric.MeterProvider) *telemetry {
+	meter := mp.Meter(telemetryInstrumentationName)
+
+	// Instrument creation only fails for invalid names, which are constant
+	retries, err := meter.Int64Counter("google.provider.http.retries",
+		metric.WithDescription("The number of HTTP requests retried by the provider"),
+		metric.WithUnit("{retry}"))
+	if err != nil {
+		log.Printf("[WARN] Telemetry: unable to create the retries counter: %s", err)
+	}
+	pollDuration, err := meter.Float64Histogram("google.provider.poll.duration",
+		metric.WithDescription("The time spent polling operations and resources until they reach their target state"),
+		metric.WithUnit("s"))
+	if err != nil {
+		log.Printf("[WARN] Telemetry: unable to create the poll duration histogram: %s", err)
+	}
+	batchSize, err := meter.Int64Histogram("google.provider.batch.size",
+		metric.WithDescription("The number of requests combined in each batch sent by a request batcher"),
+		metric.WithUnit("{request}"))
+	if err != nil {
+		log.Printf("[WARN] Telemetry: unable to create the batch size histogram: %s", err)
+	}
+
+	return &telemetry{
+		tracer:       tp.Tracer(telemetryInstrumentationName),
+		retries:      retries,
+		pollDuration: pollDuration,
+		batchSize:    batchSize,
+	}
+}
+
+// setTelemetryProviders makes the provider send its spans and metrics to the
+// given providers, eg: in-memory exporters in tests.
+func setTelemetryProviders(tp trace.TracerProvider, mp metric.MeterProvider) {
+	currentTelemetry.Store(newTelemetry(tp, mp))
+}
+
+func getTelemetry() *telemetry {
+	if t := currentTelemetry.Load(); t != nil {
+		return t
+	}
+	// The global providers are no-ops until InitTelemetry sets them
+	t := newTelemetry(otel.GetTracerProvider(), otel.GetMeterProvider())
+	currentTelemetry.CompareAndSwap(nil, t)
+	return currentTelemetry.Load()
+}
+
+// InitTelemetry enables the export of the provider's spans and metrics over
+// OTLP/HTTP when an endpoint is set by the standard environment variables:
+// OTEL_EXPORTER_OTLP_ENDPOINT for both, or OTEL_EXPORTER_OTLP_TRACES_ENDPOINT
+// and OTEL_EXPORTER_OTLP_METRICS_ENDPOINT for either. The exporters read the
+// other OTEL_EXPORTER_OTLP_* variables, such as headers, and
+// OTEL_SERVICE_NAME and OTEL_RESOURCE_ATTRIBUTES describe the provider.
+// Setting OTEL_SDK_DISABLED to true disables the export. The returned
+// function flushes and stops the export, and must be called before exiting.
+func InitTelemetry(ctx context.Context) (func(context.Context) error, error) {
+	noop := func(context.Context) error { return nil }
+	if strings.EqualFold(os.Getenv("OTEL_SDK_DISABLED"), "true") {
+		return noop, nil
+	}
+	endpoint := os.Getenv("OTEL_EXPORTER_OTLP_ENDPOINT")
+	tracesEnabled := endpoint != "" || os.Getenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT") != ""
+	metricsEnabled := endpoint != "" || os.Getenv("OTEL_EXPORTER_OTLP_METRICS_ENDPOINT") != ""
+	if !tracesEnabled && !metricsEnabled {
+		return noop, nil
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(attribute.String("service.name", defaultTelemetryServiceName)),
+		resource.WithFromEnv(),
+		resource.WithTelemetrySDK(),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	var shutdowns []func(context.Context) error
+	var tp trace.TracerProvider = otel.GetTracerProvider()
+	if tracesEnabled {
+		exporter, err := otlptracehttp.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sdkTp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(exporter), sdktrace.WithResource(res))
+		otel.SetTracerProvider(sdkTp)
+		tp = sdkTp
+		shutdowns = append(shutdowns, sdkTp.Shutdown)
+	}
+
+	var mp metric.MeterProvider = otel.GetMeterProvider()
+	if metricsEnabled {
+		exporter, err := otlpmetrichttp.New(ctx)
+		if err != nil {
+			return nil, err
+		}
+		sdkMp := sdkmetric.NewMeterProvider(sdkmetric.WithReader(sdkmetric.NewPeriodicReader(exporter)), sdkmetric.WithResource(res))
+		otel.SetMeterProvider(sdkMp)
+		mp = sdkMp
+		shutdowns = append(shutdowns, sdkMp.Shutdown)
+	}
+
+	setTelemetryProviders(tp, mp)
+	log.Printf("[INFO] Telemetry: exporting traces: %t, exporting metrics: %t", tracesEnabled, metricsEnabled)
+
+	return func(ctx context.Context) error {
+		var errs []error
+		for _, shutdown := range shutdowns {
+			errs = append(errs, shutdown(ctx))
+		}
+		return errors.Join(errs...)
+	}, nil
+}
+
+// ResourceSpan is the span of a create, read, update or delete of a
+// resource. The API calls made for it should be sent with its context, so
+// that their spans are nested under it.
+type ResourceSpan struct {
+	ctx  context.Context
+	span trace.Span
+}
+
+// StartResourceSpan starts the span of an operation of a resource, eg:
+// StartResourceSpan("google_compute_network", "create", d.Id())
+func StartResourceSpan(resourceType, operation, id string) *ResourceSpan {
+	return StartResourceSpanContext(context.Background(), resourceType, operation, id)
+}
+
+// StartResourceSpanContext starts the span of an operation of a resource
+// from ctx, eg: the context a plugin framework resource is called with.
+func StartResourceSpanContext(ctx context.Context, resourceType, operation, id string) *ResourceSpan {
+	ctx = contextWithHttpLogResource(ctx, resourceType, operation, id)
+	ctx, span := getTelemetry().tracer.Start(ctx, resourceType+" "+operation,
+		trace.WithAttributes(
+			telemetryResourceTypeKey.String(resourceType),
+			telemetryResourceOperationKey.String(operation),
+			telemetryResourceIdKey.String(id),
+		))
+	return &ResourceSpan{ctx: ctx, span: span}
+}
+
+func (s *ResourceSpan) Context() context.Context {
+	return s.ctx
+}
+
+func (s *ResourceSpan) End() {
+	s.span.End()
+}
+
+// StartSpan starts a span of the provider as a child of any span in ctx.
+func StartSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	return getTelemetry().tracer.Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// EndSpan ends a span, with an error status if err is set.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
+
+// StartPollSpan starts the span of polling an operation or resource until it
+// reaches its target state. The returned function ends it and records the
+// poll duration.
+func StartPollSpan(ctx context.Context, kind, activity string) (context.Context, func(error)) {
+	start := time.Now()
+	ctx, span := StartSpan(ctx, "poll "+kind,
+		telemetryPollKindKey.String(kind),
+		telemetryPollActivityKey.String(activity),
+	)
+	return ctx, func(err error) {
+		getTelemetry().pollDuration.Record(ctx, time.Since(start).Seconds(), metric.WithAttributes(
+			telemetryPollKindKey.String(kind),
+			telemetryErrorKey.Bool(err != nil),
+		))
+		EndSpan(span, err)
+	}
+}
+
+func recordTelemetryRetry(ctx context.Context, host, delaySource string) {
+	getTelemetry().retries.Add(ctx, 1, metric.WithAttributes(
+		telemetryServerAddressKey.String(host),
+		telemetryRetryDelaySourceKey.String(delaySource),
+	))
+}
+
+func recordTelemetryBatchSize(ctx context.Context, batcher string, size int) {
+	getTelemetry().batchSize.Record(ctx, int64(size), metric.WithAttributes(
+		telemetryBatcherKey.String(batcher),
+	))
+}
@@ -0,0 +1,262 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+// setUpTelemetryTest sends the provider's spans and metrics to in-memory
+// exporters for the duration of the test.
+func setUpTelemetryTest(t *testing.T) (*tracetest.InMemoryExporter, *sdkmetric.ManualReader) {
+	exporter := tracetest.NewInMemoryExporter()
+	reader := sdkmetric.NewManualReader()
+
+	previous := currentTelemetry.Load()
+	setTelemetryProviders(
+		sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)),
+	)
+	t.Cleanup(func() {
+		currentTelemetry.Store(previous)
+	})
+	return exporter, reader
+}
+
+func testTelemetry_collect(t *testing.T, reader *sdkmetric.ManualReader, name string) *metricdata.Metrics {
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatalf("unable to collect metrics: %v", err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for i := range sm.Metrics {
+			if sm.Metrics[i].Name == name {
+				return &sm.Metrics[i]
+			}
+		}
+	}
+	t.Fatalf("expected metric %q to be recorded", name)
+	return nil
+}
+
+func testTelemetry_attribute(attrs []attribute.KeyValue, key attribute.Key) (attribute.Value, bool) {
+	for _, kv := range attrs {
+		if kv.Key == key {
+			return kv.Value, true
+		}
+	}
+	return attribute.Value{}, false
+}
+
+// Check that the HTTP attempts of a request are spans nested under the span
+// of its resource, and that retries are counted
+func TestTelemetry_RetryTransportAttempts(t *testing.T) {
+	exporter, reader := setUpTelemetryTest(t)
+
+	var attempts int32
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if atomic.AddInt32(&attempts, 1) == 1 {
+			w.WriteHeader(testRetryTransportCodeRetry)
+			return
+		}
+		w.WriteHeader(testRetryTransportCodeSuccess)
+	}))
+	defer ts.Close()
+
+	client := ts.Client()
+	client.Transport = &retryTransport{
+		internal:        http.DefaultTransport,
+		retryPredicates: []RetryErrorPredicateFunc{testRetryTransportRetryPredicate},
+		baseBackoff:     time.Millisecond,
+	}
+
+	span := StartResourceSpan("google_test_widget", "create", "")
+	req, err := http.NewRequestWithContext(span.Context(), "GET", ts.URL+"/v1/widgets", nil)
+	if err != nil {
+		t.Fatalf("unable to construct request: %v", err)
+	}
+	resp, err := client.Do(req)
+	testRetryTransport_checkSuccess(t, resp, err)
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("expected 2 attempt spans and 1 resource span, got %d: %v", len(spans), spans)
+	}
+	resourceSpan := spans[2]
+	if resourceSpan.Name != "google_test_widget create" {
+		t.Errorf("expected the resource span to end last, got %q", resourceSpan.Name)
+	}
+	for i, attempt := range spans[:2] {
+		if attempt.Name != "GET" {
+			t.Errorf("expected attempt %d span to be named GET, got %q", i, attempt.Name)
+		}
+		if attempt.Parent.SpanID() != resourceSpan.SpanContext.SpanID() {
+			t.Errorf("expected attempt %d span to be nested under the resource span", i)
+		}
+		if v, _ := testTelemetry_attribute(attempt.Attributes, telemetryHttpResendCountKey); v.AsInt64() != int64(i) {
+			t.Errorf("expected attempt %d span to have resend count %d, got %d", i, i, v.AsInt64())
+		}
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("expected the failed attempt span to have an error status, got %v", spans[0].Status)
+	}
+	if v, _ := testTelemetry_attribute(spans[1].Attributes, telemetryHttpStatusCodeKey); v.AsInt64() != testRetryTransportCodeSuccess {
+		t.Errorf("expected the last attempt span to have status code %d, got %d", testRetryTransportCodeSuccess, v.AsInt64())
+	}
+
+	retries := testTelemetry_collect(t, reader, "google.provider.http.retries")
+	sum, ok := retries.Data.(metricdata.Sum[int64])
+	if !ok || len(sum.DataPoints) != 1 || sum.DataPoints[0].Value != 1 {
+		t.Errorf("expected 1 retry to be counted, got %#v", retries.Data)
+	}
+}
+
+// Check that the span of SendRequest is nested under the span of its
+// resource, with the HTTP attempts nested under it
+func TestTelemetry_SendRequestSpan(t *testing.T) {
+	exporter, _ := setUpTelemetryTest(t)
+
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if _, err := w.Write([]byte(`{"name": "widget"}`)); err != nil {
+			t.Errorf("[ERROR] unable to write to response writer: %v", err)
+		}
+	}))
+	defer ts.Close()
+
+	client := ts.Client()
+	client.Transport = NewTransportWithDefaultRetries(http.DefaultTransport)
+
+	span := StartResourceSpan("google_test_widget", "read", "widgets/widget")
+	_, err := SendRequest(SendRequestOptions{
+		Context: span.Context(),
+		Config:  &Config{Client: client},
+		Method:  "GET",
+		RawURL:  ts.URL + "/v1/widgets/widget",
+	})
+	if err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 3 {
+		t.Fatalf("expected 3 spans, got %d: %v", len(spans), spans)
+	}
+	attempt, request, resource := spans[0], spans[1], spans[2]
+	if request.Name != "SendRequest GET" {
+		t.Errorf("expected the request span to be named %q, got %q", "SendRequest GET", request.Name)
+	}
+	if request.Parent.SpanID() != resource.SpanContext.SpanID() {
+		t.Errorf("expected the request span to be nested under the resource span")
+	}
+	if attempt.Parent.SpanID() != request.SpanContext.SpanID() {
+		t.Errorf("expected the attempt span to be nested under the request span")
+	}
+	if v, _ := testTelemetry_attribute(request.Attributes, telemetryUrlPathKey); v.AsString() != "/v1/widgets/widget" {
+		t.Errorf("expected the request span to have the path of the request, got %q", v.AsString())
+	}
+}
+
+func TestTelemetry_PollSpan(t *testing.T) {
+	exporter, reader := setUpTelemetryTest(t)
+
+	_, endPollSpan := StartPollSpan(context.Background(), TelemetryPollKindOperation, "creating widget")
+	endPollSpan(errors.New("operation failed"))
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 {
+		t.Fatalf("expected 1 span, got %d", len(spans))
+	}
+	if spans[0].Name != "poll operation" {
+		t.Errorf("expected span to be named %q, got %q", "poll operation", spans[0].Name)
+	}
+	if spans[0].Status.Code != codes.Error {
+		t.Errorf("expected span to have an error status, got %v", spans[0].Status)
+	}
+
+	durations := testTelemetry_collect(t, reader, "google.provider.poll.duration")
+	hist, ok := durations.Data.(metricdata.Histogram[float64])
+	if !ok || len(hist.DataPoints) != 1 || hist.DataPoints[0].Count != 1 {
+		t.Fatalf("expected 1 poll duration to be recorded, got %#v", durations.Data)
+	}
+	if v, _ := hist.DataPoints[0].Attributes.Value(telemetryErrorKey); !v.AsBool() {
+		t.Errorf("expected the poll duration to be recorded as failed")
+	}
+}
+
+func TestTelemetry_PollSpanInResourceSpan(t *testing.T) {
+	exporter, _ := setUpTelemetryTest(t)
+
+	span := StartResourceSpan("google_test_widget", "create", "widgets/widget")
+	pollF := func() (map[string]interface{}, error) {
+		return map[string]interface{}{"name": "widget"}, nil
+	}
+	if err := PollingWaitTimeContext(span.Context(), pollF, PollCheckForExistence, "creating widget", time.Minute, 1); err != nil {
+		t.Fatalf("expected no error, got: %v", err)
+	}
+	span.End()
+
+	spans := exporter.GetSpans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d: %v", len(spans), spans)
+	}
+	poll, resource := spans[0], spans[1]
+	if poll.Name != "poll resource" {
+		t.Errorf("expected the poll span to be named %q, got %q", "poll resource", poll.Name)
+	}
+	if poll.Parent.SpanID() != resource.SpanContext.SpanID() {
+		t.Errorf("expected the poll span to be nested under the resource span")
+	}
+}
+
+func TestTelemetry_BatchSize(t *testing.T) {
+	exporter, reader := setUpTelemetryTest(t)
+
+	testBatcher := NewRequestBatcher(
+		"testBatcher",
+		context.Background(),
+		&BatchingConfig{
+			SendAfter:      time.Second,
+			EnableBatching: true,
+		})
+	defer testBatcher.stop()
+
+	send := func(name string, body interface{}) (interface{}, error) {
+		return body, nil
+	}
+	batch := &startedBatch{
+		batchKey:     "testBatch",
+		BatchRequest: &BatchRequest{ResourceName: "widgets", Body: 3, SendF: send},
+	}
+	for i := 0; i < 3; i++ {
+		batch.subscribers = append(batch.subscribers, batchSubscriber{
+			singleRequest: &BatchRequest{ResourceName: "widgets", Body: 1, SendF: send},
+			respCh:        make(chan batchResponse, 1),
+		})
+	}
+	testBatcher.sendBatchWithSingleRetry("testBatch", batch)
+
+	spans := exporter.GetSpans()
+	if len(spans) != 1 || spans[0].Name != "RequestBatcher send" {
+		t.Fatalf("expected 1 batch span, got %v", spans)
+	}
+
+	sizes := testTelemetry_collect(t, reader, "google.provider.batch.size")
+	hist, ok := sizes.Data.(metricdata.Histogram[int64])
+	if !ok || len(hist.DataPoints) != 1 || hist.DataPoints[0].Sum != 3 {
+		t.Errorf("expected a batch of 3 requests to be recorded, got %#v", sizes.Data)
+	}
+}
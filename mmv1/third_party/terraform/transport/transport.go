@@ -2,6 +2,7 @@ package transport
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"log"
@@ -17,6 +18,10 @@ import (
 var DefaultRequestTimeout = 5 * time.Minute
 
 type SendRequestOptions struct {
+	// The context of the request, eg: the context of a ResourceSpan so that
+	// the spans of the request are nested under it. Defaults to
+	// context.Background().
+	Context              context.Context
 	Config               *Config
 	Method               string
 	Project              string
@@ -27,9 +32,33 @@ type SendRequestOptions struct {
 	Headers              http.Header
 	ErrorRetryPredicates []RetryErrorPredicateFunc
 	ErrorAbortPredicates []RetryErrorPredicateFunc
+	// A typed request body, such as the API object of a resource with
+	// generated API types. It's sent instead of Body when set.
+	TypedBody any
 }
 
 func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
+	var result map[string]interface{}
+	if err := SendTypedRequest(opt, &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// SendTypedRequest sends a request like SendRequest and decodes the response
+// into out, a pointer to eg: the typed API object of a resource. out is left
+// unchanged when the response has no body.
+func SendTypedRequest(opt SendRequestOptions, out any) (err error) {
+	ctx, span := StartSpan(opt.Context, "SendRequest "+opt.Method, telemetryHttpMethodKey.String(opt.Method))
+	var host string
+	if u, err := url.Parse(opt.RawURL); err == nil {
+		host = u.Hostname()
+		span.SetAttributes(telemetryServerAddressKey.String(host), telemetryUrlPathKey.String(u.Path))
+	}
+	defer func() {
+		EndSpan(span, err)
+	}()
+
 	reqHeaders := opt.Headers
 	if reqHeaders == nil {
 		reqHeaders = make(http.Header)
@@ -54,10 +83,14 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 	}
 
 	var res *http.Response
-	err := Retry(RetryOptions{
+	err = retryRequest(ctx, host, opt.Config.RetryBudgets.forHost(host), RetryOptions{
 		RetryFunc: func() error {
 			var buf bytes.Buffer
-			if opt.Body != nil {
+			if opt.TypedBody != nil {
+				if err := json.NewEncoder(&buf).Encode(opt.TypedBody); err != nil {
+					return err
+				}
+			} else if opt.Body != nil {
 				err := json.NewEncoder(&buf).Encode(opt.Body)
 				if err != nil {
 					return err
@@ -68,7 +101,7 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 			if err != nil {
 				return err
 			}
-			req, err := http.NewRequest(opt.Method, u, &buf)
+			req, err := http.NewRequestWithContext(ctx, opt.Method, u, &buf)
 			if err != nil {
 				return err
 			}
@@ -91,11 +124,11 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 		ErrorAbortPredicates: opt.ErrorAbortPredicates,
 	})
 	if err != nil {
-		return nil, err
+		return err
 	}
 
 	if res == nil {
-		return nil, fmt.Errorf("Unable to parse server response. This is most likely a terraform problem, please file a bug at https://github.com/hashicorp/terraform-provider-google/issues.")
+		return fmt.Errorf("Unable to parse server response. This is most likely a terraform problem, please file a bug at https://github.com/hashicorp/terraform-provider-google/issues.")
 	}
 
 	// The defer call must be made outside of the retryFunc otherwise it's closed too soon.
@@ -104,14 +137,9 @@ func SendRequest(opt SendRequestOptions) (map[string]interface{}, error) {
 	// 204 responses will have no body, so we're going to error with "EOF" if we
 	// try to parse it. Instead, we can just return nil.
 	if res.StatusCode == 204 {
-		return nil, nil
-	}
-	result := make(map[string]interface{})
-	if err := json.NewDecoder(res.Body).Decode(&result); err != nil {
-		return nil, err
+		return nil
 	}
-
-	return result, nil
+	return json.NewDecoder(res.Body).Decode(out)
 }
 
 func AddQueryParams(rawurl string, params map[string]string) (string, error) {
@@ -12,6 +12,15 @@ type BreakingChange struct {
 	Message                string
 	DocumentationReference string
 	RuleName               string
+	// Location is the source that defines the resource or field, if known
+	Location *SourceLocation `json:",omitempty"`
+}
+
+// SourceLocation is a line of the file that defines a resource or field, eg:
+// the product YAML of an MMv1 resource
+type SourceLocation struct {
+	File string
+	Line int
 }
 
 const breakingChangesPath = "breaking-changes/breaking-changes"
@@ -20,15 +29,23 @@ func NewBreakingChange(message, identifier string) BreakingChange {
 	return BreakingChange{
 		Message:                message,
 		DocumentationReference: fmt.Sprintf("https://googlecloudplatform.github.io/magic-modules/%s#%s", breakingChangesPath, identifier),
+		RuleName:               identifier,
 	}
 }
 
+func newResourceBreakingChange(resource, field, message, identifier string) BreakingChange {
+	breakingChange := NewBreakingChange(message, identifier)
+	breakingChange.Resource = resource
+	breakingChange.Field = field
+	return breakingChange
+}
+
 func ComputeBreakingChanges(schemaDiff diff.SchemaDiff) []BreakingChange {
 	var breakingChanges []BreakingChange
 	for resource, resourceDiff := range schemaDiff {
 		for _, rule := range ResourceConfigDiffRules {
 			for _, message := range rule.Messages(resource, resourceDiff.ResourceConfig) {
-				breakingChanges = append(breakingChanges, NewBreakingChange(message, rule.Identifier))
+				breakingChanges = append(breakingChanges, newResourceBreakingChange(resource, "", message, rule.Identifier))
 			}
 		}
 
@@ -39,14 +56,14 @@ func ComputeBreakingChanges(schemaDiff diff.SchemaDiff) []BreakingChange {
 
 		for _, rule := range ResourceDiffRules {
 			for _, message := range rule.Messages(resource, resourceDiff) {
-				breakingChanges = append(breakingChanges, NewBreakingChange(message, rule.Identifier))
+				breakingChanges = append(breakingChanges, newResourceBreakingChange(resource, "", message, rule.Identifier))
 			}
 		}
 
 		for field, fieldDiff := range resourceDiff.Fields {
 			for _, rule := range FieldDiffRules {
 				for _, message := range rule.Messages(resource, field, fieldDiff) {
-					breakingChanges = append(breakingChanges, NewBreakingChange(message, rule.Identifier))
+					breakingChanges = append(breakingChanges, newResourceBreakingChange(resource, field, message, rule.Identifier))
 				}
 			}
 		}
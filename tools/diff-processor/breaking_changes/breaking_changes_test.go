@@ -95,6 +95,8 @@ func TestComputeBreakingChanges(t *testing.T) {
 				{
 					Message:                "Resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-map-resource-removal-or-rename",
+					Resource:               "google-x",
+					RuleName:               "resource-map-resource-removal-or-rename",
 				},
 			},
 		},
@@ -119,6 +121,8 @@ func TestComputeBreakingChanges(t *testing.T) {
 				{
 					Message:                "Field `field-b` within resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-schema-field-removal-or-rename",
+					Resource:               "google-x",
+					RuleName:               "resource-schema-field-removal-or-rename",
 				},
 			},
 		},
@@ -144,6 +148,9 @@ func TestComputeBreakingChanges(t *testing.T) {
 				{
 					Message:                "Field `field-a` changed from optional to required on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-optional-to-required",
+					Resource:               "google-x",
+					Field:                  "field-a",
+					RuleName:               "field-optional-to-required",
 				},
 			},
 		},
@@ -168,10 +175,15 @@ func TestComputeBreakingChanges(t *testing.T) {
 				{
 					Message:                "Field `field-a` changed from optional to required on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-optional-to-required",
+					Resource:               "google-x",
+					Field:                  "field-a",
+					RuleName:               "field-optional-to-required",
 				},
 				{
 					Message:                "Field `field-b` within resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-schema-field-removal-or-rename",
+					Resource:               "google-x",
+					RuleName:               "resource-schema-field-removal-or-rename",
 				},
 			},
 		},
@@ -201,14 +213,21 @@ func TestComputeBreakingChanges(t *testing.T) {
 				{
 					Message:                "Field `field-a` changed from optional to required on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-optional-to-required",
+					Resource:               "google-x",
+					Field:                  "field-a",
+					RuleName:               "field-optional-to-required",
 				},
 				{
 					Message:                "Field `field-b` within resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-schema-field-removal-or-rename",
+					Resource:               "google-x",
+					RuleName:               "resource-schema-field-removal-or-rename",
 				},
 				{
 					Message:                "Resource `google-y` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-map-resource-removal-or-rename",
+					Resource:               "google-y",
+					RuleName:               "resource-map-resource-removal-or-rename",
 				},
 			},
 		},
@@ -255,6 +274,8 @@ func TestComputeBreakingChanges(t *testing.T) {
 				{
 					Message:                "Field `field-a.sub-field-2` within resource `google-x` was either removed or renamed",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#resource-schema-field-removal-or-rename",
+					Resource:               "google-x",
+					RuleName:               "resource-schema-field-removal-or-rename",
 				},
 			},
 		},
@@ -298,8 +319,11 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
-					Message:                "Field `field-a.sub-field-1` MinItems went from 100 to 25 on `google-x`",
+					Message:                "Field `field-a.sub-field-1` MaxItems went from 100 to 25 on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-shrinking-max",
+					Resource:               "google-x",
+					Field:                  "field-a.sub-field-1",
+					RuleName:               "field-shrinking-max",
 				},
 			},
 		},
@@ -343,8 +367,11 @@ func TestComputeBreakingChanges(t *testing.T) {
 			},
 			wantViolations: []BreakingChange{
 				{
-					Message:                "Field `field-a.sub-field-1` MinItems went from 100 to 25 on `google-x`",
+					Message:                "Field `field-a.sub-field-1` MaxItems went from 100 to 25 on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-shrinking-max",
+					Resource:               "google-x",
+					Field:                  "field-a.sub-field-1",
+					RuleName:               "field-shrinking-max",
 				},
 			},
 		},
@@ -376,6 +403,101 @@ func TestComputeBreakingChanges(t *testing.T) {
 				{
 					Message:                "Field `field-a` MinItems went from 1 to 4 on `google-x`",
 					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-growing-min",
+					Resource:               "google-x",
+					Field:                  "field-a",
+					RuleName:               "field-growing-min",
+				},
+			},
+		},
+		{
+			name: "subfield losing optional computed",
+			oldResourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {
+							Description: "beep",
+							Optional:    true,
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"sub-field-1": {Description: "beep", Optional: true, Computed: true},
+								},
+							},
+						},
+					},
+				},
+			},
+			newResourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {
+							Description: "beep",
+							Optional:    true,
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"sub-field-1": {Description: "beep", Optional: true},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantViolations: []BreakingChange{
+				{
+					Message:                "Field `field-a.sub-field-1` transitioned from optional+computed to optional on `google-x`",
+					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-oc-to-c",
+					Resource:               "google-x",
+					Field:                  "field-a.sub-field-1",
+					RuleName:               "field-oc-to-c",
+				},
+			},
+		},
+		{
+			name: "subfield becoming force new",
+			oldResourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {
+							Description: "beep",
+							Optional:    true,
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"sub-field-1": {Description: "beep", Optional: true},
+								},
+							},
+						},
+					},
+				},
+			},
+			newResourceMap: map[string]*schema.Resource{
+				"google-x": {
+					Schema: map[string]*schema.Schema{
+						"field-a": {
+							Description: "beep",
+							Optional:    true,
+							Type:        schema.TypeList,
+							MaxItems:    1,
+							Elem: &schema.Resource{
+								Schema: map[string]*schema.Schema{
+									"sub-field-1": {Description: "beep", Optional: true, ForceNew: true},
+								},
+							},
+						},
+					},
+				},
+			},
+			wantViolations: []BreakingChange{
+				{
+					Message:                "Field `field-a.sub-field-1` became ForceNew on `google-x`",
+					DocumentationReference: "https://googlecloudplatform.github.io/magic-modules/breaking-changes/breaking-changes#field-becoming-force-new",
+					Resource:               "google-x",
+					Field:                  "field-a.sub-field-1",
+					RuleName:               "field-becoming-force-new",
 				},
 			},
 		},
@@ -389,7 +511,7 @@ func TestComputeBreakingChanges(t *testing.T) {
 			violations := ComputeBreakingChanges(schemaDiff)
 			for _, v := range violations {
 				if strings.Contains(v.Message, "{{") || strings.Contains(v.Message, "}}") {
-					t.Errorf("Test `%s` failed: found unreplaced characters in string - %s", tc.name, v)
+					t.Errorf("Test `%s` failed: found unreplaced characters in string - %v", tc.name, v)
 				}
 			}
 			sort.Slice(violations, func(i, j int) bool {
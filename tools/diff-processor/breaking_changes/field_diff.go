@@ -2,6 +2,7 @@ package breaking_changes
 
 import (
 	"fmt"
+	"slices"
 	"strconv"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -27,6 +28,11 @@ var FieldDiffRules = []FieldDiffRule{
 	FieldGrowingMin,
 	FieldShrinkingMax,
 	FieldRemovingDiffSuppress,
+	FieldBecomingForceNew,
+	FieldRemovingEnumValue,
+	FieldMaxOneToList,
+	FieldGrowingConflictsWith,
+	FieldShrinkingExactlyOneOf,
 }
 
 var FieldChangingType = FieldDiffRule{
@@ -108,7 +114,7 @@ func FieldOptionalComputedToOptionalMessages(resource, field string, fieldDiff d
 	if fieldDiff.Old == nil || fieldDiff.New == nil {
 		return nil
 	}
-	tmpl := "Field `%s` transitioned from optional+computed to optional `%s`"
+	tmpl := "Field `%s` transitioned from optional+computed to optional on `%s`"
 	if (fieldDiff.Old.Computed && fieldDiff.Old.Optional) && (fieldDiff.New.Optional && !fieldDiff.New.Computed) {
 		return []string{fmt.Sprintf(tmpl, field, resource)}
 	}
@@ -167,8 +173,9 @@ func FieldShrinkingMaxMessages(resource, field string, fieldDiff diff.FieldDiff)
 	if fieldDiff.Old == nil || fieldDiff.New == nil {
 		return nil
 	}
-	tmpl := "Field `%s` MinItems went from %s to %s on `%s`"
-	if fieldDiff.Old.MaxItems > fieldDiff.New.MaxItems || fieldDiff.Old.MaxItems == 0 && fieldDiff.New.MaxItems > 0 {
+	tmpl := "Field `%s` MaxItems went from %s to %s on `%s`"
+	// A MaxItems of 0 is unlimited, so only a defined max can shrink
+	if fieldDiff.New.MaxItems > 0 && (fieldDiff.Old.MaxItems > fieldDiff.New.MaxItems || fieldDiff.Old.MaxItems == 0) {
 		oldMax := strconv.Itoa(fieldDiff.Old.MaxItems)
 		if fieldDiff.Old.MaxItems == 0 {
 			oldMax = "unset"
@@ -196,3 +203,134 @@ func FieldRemovingDiffSuppressMessages(resource, field string, fieldDiff diff.Fi
 	}
 	return nil
 }
+
+var FieldBecomingForceNew = FieldDiffRule{
+	Identifier: "field-becoming-force-new",
+	Messages:   FieldBecomingForceNewMessages,
+}
+
+func FieldBecomingForceNewMessages(resource, field string, fieldDiff diff.FieldDiff) []string {
+	// ignore for added / removed fields
+	if fieldDiff.Old == nil || fieldDiff.New == nil {
+		return nil
+	}
+	// computed only fields can't be configured, so they can't cause a recreate
+	if !fieldDiff.New.Optional && !fieldDiff.New.Required {
+		return nil
+	}
+	tmpl := "Field `%s` became ForceNew on `%s`"
+	if !fieldDiff.Old.ForceNew && fieldDiff.New.ForceNew {
+		return []string{fmt.Sprintf(tmpl, field, resource)}
+	}
+	return nil
+}
+
+var FieldRemovingEnumValue = FieldDiffRule{
+	Identifier: "field-removing-enum-value",
+	Messages:   FieldRemovingEnumValueMessages,
+}
+
+// FieldRemovingEnumValueMessages checks that every value the old field
+// documents as accepted is still accepted by the new field. The values of
+// generated enum fields are only available from their description, as the
+// ValidateFunc itself can't be inspected.
+func FieldRemovingEnumValueMessages(resource, field string, fieldDiff diff.FieldDiff) []string {
+	// ignore for added / removed fields
+	if fieldDiff.Old == nil || fieldDiff.New == nil {
+		return nil
+	}
+	oldValues := enumValues(fieldDiff.Old.Description)
+	if len(oldValues) == 0 {
+		return nil
+	}
+	validateFunc := fieldDiff.New.ValidateFunc
+	if elem, ok := fieldDiff.New.Elem.(*schema.Schema); ok && validateFunc == nil {
+		validateFunc = elem.ValidateFunc
+	}
+	newValues := enumValues(fieldDiff.New.Description)
+
+	tmpl := "Field `%s` no longer accepts value %q on `%s`"
+	var messages []string
+	for _, value := range oldValues {
+		removed := false
+		if validateFunc != nil {
+			_, errs := validateFunc(value, field)
+			removed = len(errs) > 0
+		} else if newValues != nil {
+			removed = !slices.Contains(newValues, value)
+		}
+		if removed {
+			messages = append(messages, fmt.Sprintf(tmpl, field, value, resource))
+		}
+	}
+	return messages
+}
+
+var FieldMaxOneToList = FieldDiffRule{
+	Identifier: "field-max-one-to-list",
+	Messages:   FieldMaxOneToListMessages,
+}
+
+func FieldMaxOneToListMessages(resource, field string, fieldDiff diff.FieldDiff) []string {
+	// ignore for added / removed fields
+	if fieldDiff.Old == nil || fieldDiff.New == nil {
+		return nil
+	}
+	// type changes are covered by FieldChangingType
+	if fieldDiff.Old.Type != fieldDiff.New.Type {
+		return nil
+	}
+	tmpl := "Field `%s` changed from a single block to a list of up to %s on `%s`"
+	if fieldDiff.Old.MaxItems == 1 && fieldDiff.New.MaxItems != 1 {
+		newMax := strconv.Itoa(fieldDiff.New.MaxItems)
+		if fieldDiff.New.MaxItems == 0 {
+			newMax = "unlimited"
+		}
+		return []string{fmt.Sprintf(tmpl, field, newMax, resource)}
+	}
+	return nil
+}
+
+var FieldGrowingConflictsWith = FieldDiffRule{
+	Identifier: "field-growing-conflicts-with",
+	Messages:   FieldGrowingConflictsWithMessages,
+}
+
+func FieldGrowingConflictsWithMessages(resource, field string, fieldDiff diff.FieldDiff) []string {
+	// ignore for added / removed fields
+	if fieldDiff.Old == nil || fieldDiff.New == nil {
+		return nil
+	}
+	tmpl := "Field `%s` now conflicts with `%s` on `%s`"
+	var messages []string
+	for _, conflict := range fieldDiff.New.ConflictsWith {
+		if !slices.Contains(fieldDiff.Old.ConflictsWith, conflict) {
+			messages = append(messages, fmt.Sprintf(tmpl, field, conflict, resource))
+		}
+	}
+	return messages
+}
+
+var FieldShrinkingExactlyOneOf = FieldDiffRule{
+	Identifier: "field-shrinking-exactly-one-of",
+	Messages:   FieldShrinkingExactlyOneOfMessages,
+}
+
+func FieldShrinkingExactlyOneOfMessages(resource, field string, fieldDiff diff.FieldDiff) []string {
+	// ignore for added / removed fields
+	if fieldDiff.Old == nil || fieldDiff.New == nil {
+		return nil
+	}
+	// dropping the constraint entirely only relaxes validation
+	if len(fieldDiff.New.ExactlyOneOf) == 0 {
+		return nil
+	}
+	tmpl := "Field `%s` was removed from the exactly one of constraint of `%s` on `%s`"
+	var messages []string
+	for _, option := range fieldDiff.Old.ExactlyOneOf {
+		if !slices.Contains(fieldDiff.New.ExactlyOneOf, option) {
+			messages = append(messages, fmt.Sprintf(tmpl, option, field, resource))
+		}
+	}
+	return messages
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 type fieldTestCase struct {
@@ -558,6 +559,348 @@ var FieldShrinkingMaxTestCases = []fieldTestCase{
 		},
 		expectedViolation: true,
 	},
+	{
+		name: "max defined to unset",
+		oldField: &schema.Schema{
+			MaxItems: 2,
+		},
+		newField:          &schema.Schema{},
+		expectedViolation: false,
+	},
+}
+
+func TestFieldBecomingForceNew(t *testing.T) {
+	for _, tc := range FieldBecomingForceNewTestCases {
+		tc.check(FieldBecomingForceNew, t)
+	}
+}
+
+var FieldBecomingForceNewTestCases = []fieldTestCase{
+	{
+		name: "control",
+		oldField: &schema.Schema{
+			Optional: true,
+			ForceNew: true,
+		},
+		newField: &schema.Schema{
+			Optional: true,
+			ForceNew: true,
+		},
+		expectedViolation: false,
+	},
+	{
+		name: "optional becoming force new",
+		oldField: &schema.Schema{
+			Optional: true,
+		},
+		newField: &schema.Schema{
+			Optional: true,
+			ForceNew: true,
+		},
+		expectedViolation: true,
+	},
+	{
+		name: "required becoming force new",
+		oldField: &schema.Schema{
+			Required: true,
+		},
+		newField: &schema.Schema{
+			Required: true,
+			ForceNew: true,
+		},
+		expectedViolation: true,
+	},
+	{
+		name: "computed only becoming force new",
+		oldField: &schema.Schema{
+			Computed: true,
+		},
+		newField: &schema.Schema{
+			Computed: true,
+			ForceNew: true,
+		},
+		expectedViolation: false,
+	},
+	{
+		name: "force new removed",
+		oldField: &schema.Schema{
+			Optional: true,
+			ForceNew: true,
+		},
+		newField: &schema.Schema{
+			Optional: true,
+		},
+		expectedViolation: false,
+	},
+	{
+		name:     "field added",
+		oldField: nil,
+		newField: &schema.Schema{
+			Optional: true,
+			ForceNew: true,
+		},
+		expectedViolation: false,
+	},
+}
+
+func TestFieldRemovingEnumValue(t *testing.T) {
+	for _, tc := range FieldRemovingEnumValueTestCases {
+		tc.check(FieldRemovingEnumValue, t)
+	}
+}
+
+var FieldRemovingEnumValueTestCases = []fieldTestCase{
+	{
+		name: "control",
+		oldField: &schema.Schema{
+			Description:  `The mode. Possible values: ["A", "B"]`,
+			ValidateFunc: validation.StringInSlice([]string{"A", "B", ""}, false),
+		},
+		newField: &schema.Schema{
+			Description:  `The mode. Possible values: ["A", "B"]`,
+			ValidateFunc: validation.StringInSlice([]string{"A", "B", ""}, false),
+		},
+		expectedViolation: false,
+	},
+	{
+		name: "value added",
+		oldField: &schema.Schema{
+			Description:  `The mode. Possible values: ["A", "B"]`,
+			ValidateFunc: validation.StringInSlice([]string{"A", "B", ""}, false),
+		},
+		newField: &schema.Schema{
+			Description:  `The mode. Possible values: ["A", "B", "C"]`,
+			ValidateFunc: validation.StringInSlice([]string{"A", "B", "C", ""}, false),
+		},
+		expectedViolation: false,
+	},
+	{
+		name: "value removed",
+		oldField: &schema.Schema{
+			Description:  `The mode. Possible values: ["A", "B"]`,
+			ValidateFunc: validation.StringInSlice([]string{"A", "B", ""}, false),
+		},
+		newField: &schema.Schema{
+			Description:  `The mode. Possible values: ["A"]`,
+			ValidateFunc: validation.StringInSlice([]string{"A", ""}, false),
+		},
+		expectedViolation: true,
+	},
+	{
+		name: "value rejected by validation only",
+		oldField: &schema.Schema{
+			Description: `The mode. Possible values: ["A", "B"]`,
+		},
+		newField: &schema.Schema{
+			Description:  `The mode. Possible values: ["A", "B"]`,
+			ValidateFunc: validation.StringInSlice([]string{"A", ""}, false),
+		},
+		expectedViolation: true,
+	},
+	{
+		name: "value removed from list elements",
+		oldField: &schema.Schema{
+			Description: `The modes. Possible values: ["A", "B"]`,
+			Elem: &schema.Schema{
+				ValidateFunc: validation.StringInSlice([]string{"A", "B"}, false),
+			},
+		},
+		newField: &schema.Schema{
+			Description: `The modes. Possible values: ["A"]`,
+			Elem: &schema.Schema{
+				ValidateFunc: validation.StringInSlice([]string{"A"}, false),
+			},
+		},
+		expectedViolation: true,
+	},
+	{
+		name: "validation removed",
+		oldField: &schema.Schema{
+			Description:  `The mode. Possible values: ["A", "B"]`,
+			ValidateFunc: validation.StringInSlice([]string{"A", "B", ""}, false),
+		},
+		newField: &schema.Schema{
+			Description: "The mode.",
+		},
+		expectedViolation: false,
+	},
+	{
+		name:     "field added",
+		oldField: nil,
+		newField: &schema.Schema{
+			Description:  `The mode. Possible values: ["A"]`,
+			ValidateFunc: validation.StringInSlice([]string{"A", ""}, false),
+		},
+		expectedViolation: false,
+	},
+}
+
+func TestFieldMaxOneToList(t *testing.T) {
+	for _, tc := range FieldMaxOneToListTestCases {
+		tc.check(FieldMaxOneToList, t)
+	}
+}
+
+var FieldMaxOneToListTestCases = []fieldTestCase{
+	{
+		name: "control",
+		oldField: &schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+		},
+		newField: &schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+		},
+		expectedViolation: false,
+	},
+	{
+		name: "max one to unlimited",
+		oldField: &schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+		},
+		newField: &schema.Schema{
+			Type: schema.TypeList,
+		},
+		expectedViolation: true,
+	},
+	{
+		name: "max one to max two",
+		oldField: &schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+		},
+		newField: &schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 2,
+		},
+		expectedViolation: true,
+	},
+	{
+		name: "max two to unlimited",
+		oldField: &schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 2,
+		},
+		newField: &schema.Schema{
+			Type: schema.TypeList,
+		},
+		expectedViolation: false,
+	},
+	{
+		name: "type changed",
+		oldField: &schema.Schema{
+			Type:     schema.TypeList,
+			MaxItems: 1,
+		},
+		newField: &schema.Schema{
+			Type: schema.TypeSet,
+		},
+		expectedViolation: false,
+	},
+}
+
+func TestFieldGrowingConflictsWith(t *testing.T) {
+	for _, tc := range FieldGrowingConflictsWithTestCases {
+		tc.check(FieldGrowingConflictsWith, t)
+	}
+}
+
+var FieldGrowingConflictsWithTestCases = []fieldTestCase{
+	{
+		name: "control",
+		oldField: &schema.Schema{
+			ConflictsWith: []string{"a"},
+		},
+		newField: &schema.Schema{
+			ConflictsWith: []string{"a"},
+		},
+		expectedViolation: false,
+	},
+	{
+		name: "conflict added",
+		oldField: &schema.Schema{
+			ConflictsWith: []string{"a"},
+		},
+		newField: &schema.Schema{
+			ConflictsWith: []string{"a", "b"},
+		},
+		expectedViolation: true,
+	},
+	{
+		name:     "conflicts added",
+		oldField: &schema.Schema{},
+		newField: &schema.Schema{
+			ConflictsWith: []string{"a"},
+		},
+		expectedViolation: true,
+	},
+	{
+		name: "conflict removed",
+		oldField: &schema.Schema{
+			ConflictsWith: []string{"a", "b"},
+		},
+		newField: &schema.Schema{
+			ConflictsWith: []string{"a"},
+		},
+		expectedViolation: false,
+	},
+	{
+		name:     "field added",
+		oldField: nil,
+		newField: &schema.Schema{
+			ConflictsWith: []string{"a"},
+		},
+		expectedViolation: false,
+	},
+}
+
+func TestFieldShrinkingExactlyOneOf(t *testing.T) {
+	for _, tc := range FieldShrinkingExactlyOneOfTestCases {
+		tc.check(FieldShrinkingExactlyOneOf, t)
+	}
+}
+
+var FieldShrinkingExactlyOneOfTestCases = []fieldTestCase{
+	{
+		name: "control",
+		oldField: &schema.Schema{
+			ExactlyOneOf: []string{"field", "a", "b"},
+		},
+		newField: &schema.Schema{
+			ExactlyOneOf: []string{"field", "a", "b"},
+		},
+		expectedViolation: false,
+	},
+	{
+		name: "option removed",
+		oldField: &schema.Schema{
+			ExactlyOneOf: []string{"field", "a", "b"},
+		},
+		newField: &schema.Schema{
+			ExactlyOneOf: []string{"field", "a"},
+		},
+		expectedViolation: true,
+	},
+	{
+		name: "option added",
+		oldField: &schema.Schema{
+			ExactlyOneOf: []string{"field", "a"},
+		},
+		newField: &schema.Schema{
+			ExactlyOneOf: []string{"field", "a", "b"},
+		},
+		expectedViolation: false,
+	},
+	{
+		name: "constraint removed",
+		oldField: &schema.Schema{
+			ExactlyOneOf: []string{"field", "a"},
+		},
+		newField:          &schema.Schema{},
+		expectedViolation: false,
+	},
 }
 
 func (tc *fieldTestCase) check(rule FieldDiffRule, t *testing.T) {
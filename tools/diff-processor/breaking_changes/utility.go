@@ -1,6 +1,8 @@
 package breaking_changes
 
 import (
+	"regexp"
+
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
 
@@ -23,3 +25,20 @@ func getValueType(valueType schema.ValueType) string {
 	}
 	return "TypeUndefined"
 }
+
+var enumValuesRegexp = regexp.MustCompile(`Possible values: \[([^\]]*)\]`)
+var quotedValueRegexp = regexp.MustCompile(`"([^"]*)"`)
+
+// enumValues returns the values listed in the "Possible values" sentence that
+// MMv1 appends to the description of enum fields, or nil if there is none.
+func enumValues(description string) []string {
+	m := enumValuesRegexp.FindStringSubmatch(description)
+	if m == nil {
+		return nil
+	}
+	values := []string{}
+	for _, v := range quotedValueRegexp.FindAllStringSubmatch(m[1], -1) {
+		values = append(values, v[1])
+	}
+	return values
+}
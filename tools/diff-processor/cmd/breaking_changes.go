@@ -1,15 +1,14 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
-
 	"io"
 	"os"
 	"sort"
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/mmv1schema"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/sarif"
 	"github.com/spf13/cobra"
 )
 
@@ -19,6 +18,8 @@ type breakingChangesOptions struct {
 	rootOptions       *rootOptions
 	computeSchemaDiff func() diff.SchemaDiff
 	stdout            io.Writer
+	format            string
+	mmv1Dir           string
 }
 
 func newBreakingChangesCmd(rootOptions *rootOptions) *cobra.Command {
@@ -37,16 +38,35 @@ func newBreakingChangesCmd(rootOptions *rootOptions) *cobra.Command {
 			return o.run()
 		},
 	}
+	cmd.Flags().StringVar(&o.format, "format", formatJSON, formatFlagUsage)
+	cmd.Flags().StringVar(&o.mmv1Dir, "mmv1", "", "path to the mmv1 directory the new provider was generated from, used to locate breaking changes in the product YAML")
 	return cmd
 }
 func (o *breakingChangesOptions) run() error {
+	if o.format == "" {
+		o.format = formatJSON
+	}
+	if err := validateFormat(o.format); err != nil {
+		return err
+	}
+
 	schemaDiff := o.computeSchemaDiff()
 	breakingChanges := breaking_changes.ComputeBreakingChanges(schemaDiff)
 	sort.Slice(breakingChanges, func(i, j int) bool {
 		return breakingChanges[i].Message < breakingChanges[j].Message
 	})
-	if err := json.NewEncoder(o.stdout).Encode(breakingChanges); err != nil {
-		return fmt.Errorf("error encoding json: %w", err)
+
+	if o.mmv1Dir != "" {
+		// beta includes every ga resource and field
+		locator, err := mmv1schema.NewLocator(o.mmv1Dir, "beta")
+		if err != nil {
+			return err
+		}
+		locator.AddLocations(breakingChanges)
+	}
+
+	if o.format == formatSARIF {
+		return encodeJSON(o.stdout, sarif.FromBreakingChanges(breakingChanges))
 	}
-	return nil
+	return encodeJSON(o.stdout, breakingChanges)
 }
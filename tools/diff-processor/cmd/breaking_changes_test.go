@@ -7,6 +7,7 @@ import (
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/sarif"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 )
@@ -105,3 +106,58 @@ func TestBreakingChangesCmd(t *testing.T) {
 		})
 	}
 }
+
+func TestBreakingChangesCmdSARIF(t *testing.T) {
+	var buf bytes.Buffer
+	o := breakingChangesOptions{
+		computeSchemaDiff: func() diff.SchemaDiff {
+			return diff.ComputeSchemaDiff(
+				map[string]*schema.Resource{
+					"google-x": {
+						Schema: map[string]*schema.Schema{
+							"field-a": {Description: "beep", Optional: true},
+						},
+					},
+				},
+				map[string]*schema.Resource{
+					"google-x": {
+						Schema: map[string]*schema.Schema{
+							"field-a": {Description: "beep", Required: true},
+						},
+					},
+				},
+			)
+		},
+		stdout: &buf,
+		format: formatSARIF,
+	}
+
+	if err := o.run(); err != nil {
+		t.Fatalf("Error running command: %s", err)
+	}
+
+	var got sarif.Log
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Failed to unmarshall output: %s", err)
+	}
+	if len(got.Runs) != 1 || len(got.Runs[0].Results) != 1 {
+		t.Fatalf("Unexpected results. Output: %s", buf.String())
+	}
+	if ruleID := got.Runs[0].Results[0].RuleID; ruleID != "field-optional-to-required" {
+		t.Errorf("Unexpected rule. Want field-optional-to-required, got %s", ruleID)
+	}
+}
+
+func TestBreakingChangesCmdUnknownFormat(t *testing.T) {
+	o := breakingChangesOptions{
+		computeSchemaDiff: func() diff.SchemaDiff {
+			return diff.SchemaDiff{}
+		},
+		stdout: &bytes.Buffer{},
+		format: "text",
+	}
+
+	if err := o.run(); err == nil {
+		t.Errorf("Expected an error for an unknown format")
+	}
+}
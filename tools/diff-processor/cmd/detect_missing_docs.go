@@ -3,13 +3,13 @@ package cmd
 import (
 	"slices"
 
-	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/detector"
 	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/sarif"
 	"github.com/spf13/cobra"
 	"golang.org/x/exp/maps"
 
@@ -30,6 +30,7 @@ type detectMissingDocsOptions struct {
 	computeSchemaDiff           func() diff.SchemaDiff
 	computeDatasourceSchemaDiff func() diff.SchemaDiff
 	stdout                      io.Writer
+	format                      string
 }
 
 type MissingDocsSummary struct {
@@ -57,9 +58,17 @@ func newDetectMissingDocsCmd(rootOptions *rootOptions) *cobra.Command {
 			return o.run(args)
 		},
 	}
+	cmd.Flags().StringVar(&o.format, "format", formatJSON, formatFlagUsage)
 	return cmd
 }
 func (o *detectMissingDocsOptions) run(args []string) error {
+	if o.format == "" {
+		o.format = formatJSON
+	}
+	if err := validateFormat(o.format); err != nil {
+		return err
+	}
+
 	schemaDiff := o.computeSchemaDiff()
 	detectedResources, err := detector.DetectMissingDocs(schemaDiff, args[0])
 	if err != nil {
@@ -77,11 +86,27 @@ func (o *detectMissingDocsOptions) run(args []string) error {
 		DataSource: sortMissingDocDetails(detectedDataSources),
 	}
 
-	if err := json.NewEncoder(o.stdout).Encode(sum); err != nil {
-		return fmt.Errorf("error encoding json: %w", err)
+	if o.format == formatSARIF {
+		return encodeJSON(o.stdout, missingDocsSARIF(sum))
 	}
+	return encodeJSON(o.stdout, sum)
+}
 
-	return nil
+// missingDocsSARIF reports each field missing from the documentation as a
+// result located in the documentation file.
+func missingDocsSARIF(sum MissingDocsSummary) *sarif.Log {
+	l := sarif.NewLog()
+	for _, details := range sum.Resource {
+		for _, field := range details.Fields {
+			l.AddResult("missing-docs", "", "warning", fmt.Sprintf("Field `%s` of resource `%s` is missing from its documentation", field, details.Name), details.FilePath, 0)
+		}
+	}
+	for _, details := range sum.DataSource {
+		for _, field := range details.Fields {
+			l.AddResult("missing-docs", "", "warning", fmt.Sprintf("Field `%s` of data source `%s` is missing from its documentation", field, details.Name), details.FilePath, 0)
+		}
+	}
+	return l
 }
 
 func sortMissingDocDetails(m map[string]detector.MissingDocDetails) []detector.MissingDocDetails {
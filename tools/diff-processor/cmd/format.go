@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	formatJSON  = "json"
+	formatSARIF = "sarif"
+)
+
+const formatFlagUsage = "output format, one of: json, sarif"
+
+func validateFormat(format string) error {
+	if format != formatJSON && format != formatSARIF {
+		return fmt.Errorf("unknown format %q, must be one of: %s, %s", format, formatJSON, formatSARIF)
+	}
+	return nil
+}
+
+func encodeJSON(w io.Writer, v any) error {
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		return fmt.Errorf("error encoding json: %w", err)
+	}
+	return nil
+}
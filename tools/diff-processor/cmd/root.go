@@ -25,6 +25,7 @@ func newRootCmd() (*cobra.Command, *rootOptions, error) {
 	cmd.AddCommand(newDetectMissingTestsCmd(o))
 	cmd.AddCommand(newSchemaDiffCmd(o))
 	cmd.AddCommand(newDetectMissingDocsCmd(o))
+	cmd.AddCommand(newYamlDiffCmd(o))
 	return cmd, o, nil
 }
 
@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/mmv1schema"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/sarif"
+	"github.com/spf13/cobra"
+)
+
+const yamlDiffDesc = `Check for breaking changes between the old / new mmv1 directories, without generating the providers.`
+
+type yamlDiffOptions struct {
+	rootOptions *rootOptions
+	stdout      io.Writer
+	oldMmv1     string
+	newMmv1     string
+	version     string
+	format      string
+}
+
+func newYamlDiffCmd(rootOptions *rootOptions) *cobra.Command {
+	o := &yamlDiffOptions{
+		rootOptions: rootOptions,
+		stdout:      os.Stdout,
+	}
+	cmd := &cobra.Command{
+		Use:   "yaml-diff",
+		Short: yamlDiffDesc,
+		Long:  yamlDiffDesc,
+		Args:  cobra.NoArgs,
+		RunE: func(c *cobra.Command, args []string) error {
+			return o.run()
+		},
+	}
+	cmd.Flags().StringVar(&o.oldMmv1, "old", "", "path to the old mmv1 directory")
+	cmd.Flags().StringVar(&o.newMmv1, "new", "", "path to the new mmv1 directory")
+	cmd.Flags().StringVar(&o.version, "version", "ga", "provider version to compare, eg: ga or beta")
+	cmd.Flags().StringVar(&o.format, "format", formatJSON, formatFlagUsage)
+	cmd.MarkFlagRequired("old")
+	cmd.MarkFlagRequired("new")
+	return cmd
+}
+
+func (o *yamlDiffOptions) run() error {
+	if o.format == "" {
+		o.format = formatJSON
+	}
+	if err := validateFormat(o.format); err != nil {
+		return err
+	}
+
+	oldResourceMap, oldLocator, err := mmv1schema.Load(o.oldMmv1, o.version)
+	if err != nil {
+		return fmt.Errorf("error loading %s: %w", o.oldMmv1, err)
+	}
+	newResourceMap, newLocator, err := mmv1schema.Load(o.newMmv1, o.version)
+	if err != nil {
+		return fmt.Errorf("error loading %s: %w", o.newMmv1, err)
+	}
+
+	schemaDiff := diff.ComputeSchemaDiff(oldResourceMap, newResourceMap)
+	breakingChanges := breaking_changes.ComputeBreakingChanges(schemaDiff)
+	sort.Slice(breakingChanges, func(i, j int) bool {
+		return breakingChanges[i].Message < breakingChanges[j].Message
+	})
+
+	// Removed resources and fields are only found in the old products
+	newLocator.AddLocations(breakingChanges)
+	oldLocator.AddLocations(breakingChanges)
+
+	if o.format == formatSARIF {
+		return encodeJSON(o.stdout, sarif.FromBreakingChanges(breakingChanges))
+	}
+	return encodeJSON(o.stdout, breakingChanges)
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
+)
+
+const yamlDiffProductYaml = `
+name: 'Widgets'
+versions:
+  - name: 'ga'
+    base_url: 'https://widgets.googleapis.com/v1/'
+scopes:
+  - 'https://www.googleapis.com/auth/cloud-platform'
+`
+
+const yamlDiffResourceYaml = `
+name: 'Widget'
+description: 'A widget.'
+base_url: 'projects/{{project}}/widgets'
+self_link: 'projects/{{project}}/widgets/{{name}}'
+examples:
+  - name: 'widget_basic'
+    primary_resource_id: 'example'
+parameters:
+  - name: 'name'
+    type: String
+    description: 'The name of the widget.'
+    url_param_only: true
+    required: true
+properties:
+  - name: 'description'
+    type: String
+    description: 'The description.'
+`
+
+func writeYamlDiffMmv1Dir(t *testing.T, resourceYaml string) string {
+	dir := t.TempDir()
+	productDir := filepath.Join(dir, "products", "widgets")
+	if err := os.MkdirAll(productDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(productDir, "product.yaml"), []byte(yamlDiffProductYaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(productDir, "Widget.yaml"), []byte(resourceYaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestYamlDiffCmd(t *testing.T) {
+	cases := map[string]struct {
+		newResourceYaml    string
+		format             string
+		expectedViolations int
+		expectError        bool
+	}{
+		"no breaking changes": {
+			newResourceYaml: yamlDiffResourceYaml,
+		},
+		"field becoming required": {
+			newResourceYaml:    yamlDiffResourceYaml + "    required: true\n",
+			expectedViolations: 1,
+		},
+		"unknown format": {
+			newResourceYaml: yamlDiffResourceYaml,
+			format:          "text",
+			expectError:     true,
+		},
+	}
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			var buf bytes.Buffer
+			o := yamlDiffOptions{
+				stdout:  &buf,
+				oldMmv1: writeYamlDiffMmv1Dir(t, yamlDiffResourceYaml),
+				newMmv1: writeYamlDiffMmv1Dir(t, tc.newResourceYaml),
+				version: "ga",
+				format:  tc.format,
+			}
+
+			err := o.run()
+			if tc.expectError {
+				if err == nil {
+					t.Errorf("Expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Error running command: %s", err)
+			}
+
+			var got []breaking_changes.BreakingChange
+			if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+				t.Fatalf("Failed to unmarshall output: %s", err)
+			}
+			if len(got) != tc.expectedViolations {
+				t.Errorf("Unexpected number of violations. Want %d, got %d. Output: %s", tc.expectedViolations, len(got), buf.String())
+			}
+			for _, bc := range got {
+				if bc.Location == nil || bc.Location.File != "mmv1/products/widgets/Widget.yaml" {
+					t.Errorf("Unexpected location of %q: %+v", bc.Message, bc.Location)
+				}
+			}
+		})
+	}
+}
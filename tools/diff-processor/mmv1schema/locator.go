@@ -0,0 +1,127 @@
+package mmv1schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
+)
+
+// Locator maps resources and fields of the schema back to the lines of the
+// MMv1 YAML that define them.
+type Locator struct {
+	resources map[string]resourceSource
+}
+
+type resourceSource struct {
+	file string
+	// fields maps the flattened schema key of each field, as used by
+	// diff.SchemaDiff, to the line that starts its definition
+	fields map[string]int
+}
+
+// NewLocator indexes the resources of the mmv1 directory at the given
+// provider version. Files are reported relative to the root of the
+// magic-modules repository, eg: mmv1/products/pubsub/Topic.yaml
+func NewLocator(mmv1Dir, version string) (*Locator, error) {
+	resources, err := loadResources(mmv1Dir, version)
+	if err != nil {
+		return nil, err
+	}
+	return newLocator(mmv1Dir, resources)
+}
+
+func newLocator(mmv1Dir string, resources []*api.Resource) (*Locator, error) {
+	l := &Locator{resources: make(map[string]resourceSource)}
+	for _, resource := range resources {
+		content, err := os.ReadFile(filepath.Join(mmv1Dir, resource.SourceYamlFile))
+		if err != nil {
+			return nil, err
+		}
+		var doc yaml.Node
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return nil, fmt.Errorf("error parsing %s: %w", resource.SourceYamlFile, err)
+		}
+
+		source := resourceSource{
+			file:   filepath.Join("mmv1", resource.SourceYamlFile),
+			fields: make(map[string]int),
+		}
+		if len(doc.Content) > 0 {
+			for _, key := range []string{"parameters", "properties", "virtual_fields"} {
+				indexFields(source.fields, "", mappingValue(doc.Content[0], key))
+			}
+		}
+		l.resources[resource.TerraformName()] = source
+	}
+	return l, nil
+}
+
+// Locate returns the location of the field of a resource. If the field isn't
+// found, the location of the resource is returned instead. It returns nil if
+// the resource isn't defined in MMv1.
+func (l *Locator) Locate(resource, field string) *breaking_changes.SourceLocation {
+	source, ok := l.resources[resource]
+	if !ok {
+		return nil
+	}
+	line, ok := source.fields[field]
+	if !ok {
+		line = 1
+	}
+	return &breaking_changes.SourceLocation{
+		File: source.file,
+		Line: line,
+	}
+}
+
+// AddLocations sets the location of every breaking change that doesn't have
+// one and that can be located.
+func (l *Locator) AddLocations(breakingChanges []breaking_changes.BreakingChange) {
+	for i, bc := range breakingChanges {
+		if bc.Location == nil {
+			breakingChanges[i].Location = l.Locate(bc.Resource, bc.Field)
+		}
+	}
+}
+
+// indexFields records the line of each field in a list of fields, following
+// nested objects, arrays and maps the same way flattenSchema does.
+func indexFields(fields map[string]int, prefix string, list *yaml.Node) {
+	if list == nil || list.Kind != yaml.SequenceNode {
+		return
+	}
+	for _, item := range list.Content {
+		name := mappingValue(item, "name")
+		if name == nil {
+			continue
+		}
+		// Flattened objects add their fields to their parent
+		if flatten := mappingValue(item, "flatten_object"); flatten != nil && flatten.Value == "true" {
+			indexFields(fields, prefix, mappingValue(item, "properties"))
+			continue
+		}
+		key := prefix + google.Underscore(name.Value)
+		fields[key] = item.Line
+		indexFields(fields, key+".", mappingValue(item, "properties"))
+		indexFields(fields, key+".", mappingValue(mappingValue(item, "item_type"), "properties"))
+		indexFields(fields, key+".", mappingValue(mappingValue(item, "value_type"), "properties"))
+	}
+}
+
+func mappingValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
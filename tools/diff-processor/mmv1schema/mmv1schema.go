@@ -0,0 +1,338 @@
+// Package mmv1schema builds Terraform resource schemas directly from MMv1
+// product YAML, so schema diffs can be computed without generating and
+// building the providers. Fields added by custom code, such as
+// custom_code.extra_schema_entry, aren't part of the schemas.
+package mmv1schema
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"gopkg.in/yaml.v3"
+
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/api"
+	"github.com/GoogleCloudPlatform/magic-modules/mmv1/google"
+)
+
+// LoadResourceMap loads every product of the mmv1 directory at the given
+// provider version and returns the schema of each resource, keyed by the
+// resource's Terraform name like provider.ResourceMap().
+func LoadResourceMap(mmv1Dir, version string) (map[string]*schema.Resource, error) {
+	resources, err := loadResources(mmv1Dir, version)
+	if err != nil {
+		return nil, err
+	}
+	return resourceMap(resources), nil
+}
+
+// Load returns both the schema of each resource, like LoadResourceMap, and a
+// Locator for them, loading the mmv1 directory only once.
+func Load(mmv1Dir, version string) (map[string]*schema.Resource, *Locator, error) {
+	resources, err := loadResources(mmv1Dir, version)
+	if err != nil {
+		return nil, nil, err
+	}
+	locator, err := newLocator(mmv1Dir, resources)
+	if err != nil {
+		return nil, nil, err
+	}
+	return resourceMap(resources), locator, nil
+}
+
+func resourceMap(resources []*api.Resource) map[string]*schema.Resource {
+	m := make(map[string]*schema.Resource)
+	for _, resource := range resources {
+		m[resource.TerraformName()] = &schema.Resource{
+			Schema: resourceSchema(resource),
+		}
+	}
+	return m
+}
+
+// loadResources loads the resources of every product of the mmv1 directory
+// that exist at the given provider version. The SourceYamlFile of each
+// resource is relative to mmv1Dir.
+func loadResources(mmv1Dir, version string) ([]*api.Resource, error) {
+	productFiles, err := filepath.Glob(filepath.Join(mmv1Dir, "products", "*", "product.yaml"))
+	if err != nil {
+		return nil, fmt.Errorf("error listing products in %s: %w", mmv1Dir, err)
+	}
+
+	var resources []*api.Resource
+	for _, productFile := range productFiles {
+		product := &api.Product{}
+		if errs := api.Compile(productFile, product, ""); len(errs) > 0 {
+			return nil, errs
+		}
+		if !product.ExistsAtVersionOrLower(version) {
+			continue
+		}
+		productVersion := product.VersionObjOrClosest(version)
+		product.SetPropertiesBasedOnVersion(productVersion)
+
+		resourceFiles, err := filepath.Glob(filepath.Join(filepath.Dir(productFile), "*.yaml"))
+		if err != nil {
+			return nil, fmt.Errorf("error listing resources in %s: %w", filepath.Dir(productFile), err)
+		}
+		for _, resourceFile := range resourceFiles {
+			if filepath.Base(resourceFile) == "product.yaml" {
+				continue
+			}
+			relPath, err := filepath.Rel(mmv1Dir, resourceFile)
+			if err != nil {
+				return nil, err
+			}
+			resource := &api.Resource{}
+			if errs := compileResource(resourceFile, relPath, resource); len(errs) > 0 {
+				return nil, errs
+			}
+			resource.SourceYamlFile = relPath
+			resource.TargetVersionName = version
+			resource.Properties = resource.AddLabelsRelatedFields(resource.PropertiesWithExcluded(), nil)
+			resource.SetDefault(product)
+			resource.ExcludeIfNotInVersion(productVersion)
+			if resource.IsExcluded() {
+				continue
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+// compileResource decodes the resource YAML at path like api.Compile, reporting
+// errors in relPath. Examples don't affect the schema, and decoding them
+// renders their templates relative to the working directory, so they are
+// blanked out first. The other lines are kept so errors point at the right
+// line.
+func compileResource(path, relPath string, resource *api.Resource) google.ValidationErrors {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return google.ValidationErrors{{File: relPath, Message: fmt.Sprintf("Cannot open the file: %v", err)}}
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(content, &doc); err == nil && len(doc.Content) > 0 {
+		content = blankMappingKey(content, doc.Content[0], "examples")
+	}
+
+	yamlValidator := google.YamlValidator{}
+	return yamlValidator.Parse(content, resource, relPath)
+}
+
+// blankMappingKey replaces the lines of a top level key of the mapping and of
+// its value with empty lines.
+func blankMappingKey(content []byte, mapping *yaml.Node, key string) []byte {
+	if mapping.Kind != yaml.MappingNode {
+		return content
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value != key {
+			continue
+		}
+		lines := strings.Split(string(content), "\n")
+		// The value ends where the next key starts
+		end := len(lines) + 1
+		if i+2 < len(mapping.Content) {
+			end = mapping.Content[i+2].Line
+		}
+		for l := mapping.Content[i].Line; l < end && l <= len(lines); l++ {
+			lines[l-1] = ""
+		}
+		return []byte(strings.Join(lines, "\n"))
+	}
+	return content
+}
+
+// resourceSchema mirrors the schema built by resource.go.tmpl
+func resourceSchema(r *api.Resource) map[string]*schema.Schema {
+	s := make(map[string]*schema.Schema)
+	addFields(s, r.AllUserProperties())
+	addFields(s, r.UserVirtualFields())
+	if r.HasProject() {
+		s["project"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Optional: true,
+			Computed: true,
+			ForceNew: true,
+		}
+	}
+	if r.HasSelfLink {
+		s["self_link"] = &schema.Schema{
+			Type:     schema.TypeString,
+			Computed: true,
+		}
+	}
+	return s
+}
+
+func addFields(s map[string]*schema.Schema, props []*api.Type) {
+	for _, p := range props {
+		if p.FlattenObject {
+			addFields(s, p.UserProperties())
+			continue
+		}
+		s[google.Underscore(p.Name)] = fieldSchema(p)
+	}
+}
+
+// fieldSchema mirrors the schema built by schema_property.go.tmpl. Functions
+// that can't be resolved from YAML, such as custom validation and diff
+// suppression, are replaced by placeholders that only record their presence.
+func fieldSchema(p *api.Type) *schema.Schema {
+	s := &schema.Schema{
+		Type:        valueType(p.TFType(p.Type)),
+		Description: p.GetDescription(),
+		Deprecated:  p.DeprecationMessage,
+		ForceNew:    p.IsForceNew(),
+		Sensitive:   p.Sensitive,
+		Default:     p.DefaultValue,
+	}
+	if p.IsSet {
+		s.Type = schema.TypeSet
+	}
+
+	switch {
+	case p.DefaultFromApi:
+		s.Optional = true
+		s.Computed = true
+	case p.Required:
+		s.Required = true
+	case p.Output:
+		s.Computed = true
+	default:
+		s.Optional = true
+	}
+
+	if !p.Output {
+		s.ValidateFunc = validateFunc(p.Validation.Regex, p.Validation.Function)
+		if p.IsA("Enum") {
+			s.ValidateFunc = validation.StringInSlice(enumValues(p, true), false)
+			s.Description += fmt.Sprintf(" Possible values: [%s]", p.EnumValuesToString("\"", false))
+		}
+		if p.IsA("Array") && p.ItemType.IsA("Enum") && !p.ItemType.ExcludeDocsValues {
+			s.Description += fmt.Sprintf(" Possible values: [%s]", p.ItemType.EnumValuesToString("\"", false))
+		}
+	}
+	if p.DiffSuppressFunc != "" || p.IsA("ResourceRef") {
+		s.DiffSuppressFunc = placeholderDiffSuppress
+	}
+
+	switch {
+	case p.IsA("NestedObject"):
+		if !p.Output {
+			s.MaxItems = 1
+		}
+		s.Elem = &schema.Resource{Schema: nestedSchema(p.UserProperties())}
+	case p.IsA("Array"):
+		s.MinItems, _ = strconv.Atoi(p.MinSize)
+		s.MaxItems, _ = strconv.Atoi(p.MaxSize)
+		s.Elem = itemSchema(p)
+	case strings.HasPrefix(p.Type, "KeyValue"):
+		s.Elem = &schema.Schema{Type: schema.TypeString}
+	case p.IsA("Map"):
+		elem := nestedSchema(p.ValueType.UserProperties())
+		elem[p.KeyName] = &schema.Schema{
+			Type:     schema.TypeString,
+			Required: true,
+			ForceNew: p.IsForceNew(),
+		}
+		s.Elem = &schema.Resource{Schema: elem}
+	}
+
+	s.ConflictsWith = p.GetPropertySchemaPathList(p.Conflicting())
+	s.AtLeastOneOf = p.GetPropertySchemaPathList(p.AtLeastOneOfList())
+	s.ExactlyOneOf = p.GetPropertySchemaPathList(p.ExactlyOneOfList())
+	s.RequiredWith = p.GetPropertySchemaPathList(p.RequiredWithList())
+	return s
+}
+
+func nestedSchema(props []*api.Type) map[string]*schema.Schema {
+	s := make(map[string]*schema.Schema)
+	addFields(s, props)
+	return s
+}
+
+func itemSchema(p *api.Type) interface{} {
+	item := p.ItemType
+	if item.IsA("NestedObject") {
+		return &schema.Resource{Schema: nestedSchema(item.UserProperties())}
+	}
+	s := &schema.Schema{
+		Type: valueType(p.TFType(item.Type)),
+	}
+	if p.Output {
+		return s
+	}
+	if item.IsA("Enum") {
+		s.ValidateFunc = validation.StringInSlice(enumValues(item, false), false)
+	} else {
+		s.ValidateFunc = validateFunc(p.ItemValidation.Regex, p.ItemValidation.Function)
+	}
+	if item.IsA("ResourceRef") {
+		s.DiffSuppressFunc = placeholderDiffSuppress
+	}
+	return s
+}
+
+// enumValues mirrors EnumValuesToString, which builds the values accepted by
+// verify.ValidateEnum in the generated schema.
+func enumValues(p *api.Type, addEmpty bool) []string {
+	values := slices.Clone(p.EnumValues)
+	if addEmpty && !slices.Contains(values, "") && !p.Required {
+		values = append(values, "")
+	}
+	return values
+}
+
+func validateFunc(regex, function string) schema.SchemaValidateFunc {
+	if regex != "" {
+		if re, err := regexp.Compile(regex); err == nil {
+			return validation.StringMatch(re, "")
+		}
+		return placeholderValidate
+	}
+	if function != "" {
+		return placeholderValidate
+	}
+	return nil
+}
+
+// placeholderValidate stands in for a validation function defined in the
+// provider. It accepts every value, as the function itself isn't available.
+func placeholderValidate(interface{}, string) ([]string, []error) {
+	return nil, nil
+}
+
+// placeholderDiffSuppress stands in for a diff suppress function defined in
+// the provider.
+func placeholderDiffSuppress(string, string, string, *schema.ResourceData) bool {
+	return false
+}
+
+func valueType(tfType string) schema.ValueType {
+	switch tfType {
+	case "schema.TypeBool":
+		return schema.TypeBool
+	case "schema.TypeFloat":
+		return schema.TypeFloat
+	case "schema.TypeInt":
+		return schema.TypeInt
+	case "schema.TypeString":
+		return schema.TypeString
+	case "schema.TypeList":
+		return schema.TypeList
+	case "schema.TypeMap":
+		return schema.TypeMap
+	case "schema.TypeSet":
+		return schema.TypeSet
+	}
+	return schema.TypeInvalid
+}
@@ -0,0 +1,219 @@
+package mmv1schema
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/diff"
+)
+
+const testProductYaml = `
+name: 'Widgets'
+versions:
+  - name: 'ga'
+    base_url: 'https://widgets.googleapis.com/v1/'
+scopes:
+  - 'https://www.googleapis.com/auth/cloud-platform'
+`
+
+const testResourceYaml = `
+name: 'Widget'
+description: 'A widget.'
+base_url: 'projects/{{project}}/widgets'
+self_link: 'projects/{{project}}/widgets/{{name}}'
+create_url: 'projects/{{project}}/widgets?widgetId={{name}}'
+id_format: 'projects/{{project}}/widgets/{{name}}'
+import_format:
+  - 'projects/{{project}}/widgets/{{name}}'
+parameters:
+  - name: 'name'
+    type: String
+    description: 'The name of the widget.'
+    url_param_only: true
+    required: true
+    immutable: true
+properties:
+`
+
+func TestLoadResourceMap(t *testing.T) {
+	cases := []struct {
+		name           string
+		oldProperties  string
+		newProperties  string
+		wantViolations []string
+	}{
+		{
+			name: "control",
+			oldProperties: `
+  - name: 'description'
+    type: String
+    description: 'The description.'
+`,
+			newProperties: `
+  - name: 'description'
+    type: String
+    description: 'The description.'
+`,
+		},
+		{
+			name: "enum value removed",
+			oldProperties: `
+  - name: 'mode'
+    type: Enum
+    description: 'The mode.'
+    enum_values:
+      - 'FAST'
+      - 'SLOW'
+`,
+			newProperties: `
+  - name: 'mode'
+    type: Enum
+    description: 'The mode.'
+    enum_values:
+      - 'FAST'
+`,
+			wantViolations: []string{
+				"Field `mode` no longer accepts value \"SLOW\" on `google_widgets_widget`",
+			},
+		},
+		{
+			name: "nested field becoming immutable and required",
+			oldProperties: `
+  - name: 'settings'
+    type: NestedObject
+    description: 'The settings.'
+    properties:
+      - name: 'size'
+        type: Integer
+        description: 'The size.'
+`,
+			newProperties: `
+  - name: 'settings'
+    type: NestedObject
+    description: 'The settings.'
+    properties:
+      - name: 'size'
+        type: Integer
+        description: 'The size.'
+        required: true
+        immutable: true
+`,
+			wantViolations: []string{
+				"Field `settings.size` became ForceNew on `google_widgets_widget`",
+				"Field `settings.size` changed from optional to required on `google_widgets_widget`",
+			},
+		},
+		{
+			name: "field removed",
+			oldProperties: `
+  - name: 'description'
+    type: String
+    description: 'The description.'
+`,
+			newProperties: `
+  - name: 'labels'
+    type: KeyValueLabels
+    description: 'The labels.'
+`,
+			wantViolations: []string{
+				"Field `description` within resource `google_widgets_widget` was either removed or renamed",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			oldResourceMap, err := LoadResourceMap(writeMmv1Dir(t, tc.oldProperties), "ga")
+			if err != nil {
+				t.Fatalf("error loading old resources: %v", err)
+			}
+			newResourceMap, err := LoadResourceMap(writeMmv1Dir(t, tc.newProperties), "ga")
+			if err != nil {
+				t.Fatalf("error loading new resources: %v", err)
+			}
+
+			var violations []string
+			for _, v := range breaking_changes.ComputeBreakingChanges(diff.ComputeSchemaDiff(oldResourceMap, newResourceMap)) {
+				violations = append(violations, v.Message)
+			}
+			sort.Strings(violations)
+			if diff := cmp.Diff(tc.wantViolations, violations); diff != "" {
+				t.Errorf("Test `%s` failed: violation diff(-want, +got) = %s", tc.name, diff)
+			}
+		})
+	}
+}
+
+func writeMmv1Dir(t *testing.T, properties string) string {
+	dir := t.TempDir()
+	productDir := filepath.Join(dir, "products", "widgets")
+	if err := os.MkdirAll(productDir, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(productDir, "product.yaml"), []byte(testProductYaml), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(productDir, "Widget.yaml"), []byte(testResourceYaml+properties), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestLocator(t *testing.T) {
+	properties := `
+  - name: 'settings'
+    type: NestedObject
+    description: 'The settings.'
+    properties:
+      - name: 'diskSize'
+        type: Integer
+        description: 'The size.'
+`
+	_, locator, err := Load(writeMmv1Dir(t, properties), "ga")
+	if err != nil {
+		t.Fatalf("error loading resources: %v", err)
+	}
+
+	cases := []struct {
+		name     string
+		resource string
+		field    string
+		want     *breaking_changes.SourceLocation
+	}{
+		{
+			name:     "parameter",
+			resource: "google_widgets_widget",
+			field:    "name",
+			want:     &breaking_changes.SourceLocation{File: "mmv1/products/widgets/Widget.yaml", Line: 11},
+		},
+		{
+			name:     "nested field",
+			resource: "google_widgets_widget",
+			field:    "settings.disk_size",
+			want:     &breaking_changes.SourceLocation{File: "mmv1/products/widgets/Widget.yaml", Line: 23},
+		},
+		{
+			name:     "unknown field",
+			resource: "google_widgets_widget",
+			field:    "unknown",
+			want:     &breaking_changes.SourceLocation{File: "mmv1/products/widgets/Widget.yaml", Line: 1},
+		},
+		{
+			name:     "unknown resource",
+			resource: "google_widgets_gadget",
+			field:    "name",
+		},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, locator.Locate(tc.resource, tc.field)); diff != "" {
+				t.Errorf("Test `%s` failed: location diff(-want, +got) = %s", tc.name, diff)
+			}
+		})
+	}
+}
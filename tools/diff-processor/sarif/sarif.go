@@ -0,0 +1,137 @@
+// Package sarif provides the subset of the SARIF 2.1.0 format needed to report
+// diff-processor findings, eg: as GitHub code scanning annotations.
+package sarif
+
+import (
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
+)
+
+const (
+	schemaURI = "https://json.schemastore.org/sarif-2.1.0.json"
+	version   = "2.1.0"
+	toolName  = "diff-processor"
+	toolURI   = "https://googlecloudplatform.github.io/magic-modules/"
+)
+
+type Log struct {
+	Schema  string `json:"$schema"`
+	Version string `json:"version"`
+	Runs    []Run  `json:"runs"`
+}
+
+type Run struct {
+	Tool    Tool     `json:"tool"`
+	Results []Result `json:"results"`
+}
+
+type Tool struct {
+	Driver Driver `json:"driver"`
+}
+
+type Driver struct {
+	Name           string `json:"name"`
+	InformationURI string `json:"informationUri"`
+	Rules          []Rule `json:"rules"`
+}
+
+type Rule struct {
+	ID      string `json:"id"`
+	HelpURI string `json:"helpUri,omitempty"`
+}
+
+type Result struct {
+	RuleID    string     `json:"ruleId"`
+	Level     string     `json:"level"`
+	Message   Message    `json:"message"`
+	Locations []Location `json:"locations,omitempty"`
+}
+
+type Message struct {
+	Text string `json:"text"`
+}
+
+type Location struct {
+	PhysicalLocation PhysicalLocation `json:"physicalLocation"`
+}
+
+type PhysicalLocation struct {
+	ArtifactLocation ArtifactLocation `json:"artifactLocation"`
+	Region           *Region          `json:"region,omitempty"`
+}
+
+type ArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type Region struct {
+	StartLine int `json:"startLine"`
+}
+
+// NewLog returns a log with a single run of diff-processor and no results.
+func NewLog() *Log {
+	return &Log{
+		Schema:  schemaURI,
+		Version: version,
+		Runs: []Run{
+			{
+				Tool: Tool{
+					Driver: Driver{
+						Name:           toolName,
+						InformationURI: toolURI,
+						Rules:          []Rule{},
+					},
+				},
+				Results: []Result{},
+			},
+		},
+	}
+}
+
+// AddResult adds a result to the run, and its rule if it isn't known yet.
+// The file and line are optional; a line is only reported alongside a file.
+func (l *Log) AddResult(ruleID, helpURI, level, message, file string, line int) {
+	run := &l.Runs[0]
+	known := false
+	for _, r := range run.Tool.Driver.Rules {
+		if r.ID == ruleID {
+			known = true
+			break
+		}
+	}
+	if !known {
+		run.Tool.Driver.Rules = append(run.Tool.Driver.Rules, Rule{ID: ruleID, HelpURI: helpURI})
+	}
+
+	result := Result{
+		RuleID:  ruleID,
+		Level:   level,
+		Message: Message{Text: message},
+	}
+	if file != "" {
+		location := Location{
+			PhysicalLocation: PhysicalLocation{
+				ArtifactLocation: ArtifactLocation{URI: file},
+			},
+		}
+		if line > 0 {
+			location.PhysicalLocation.Region = &Region{StartLine: line}
+		}
+		result.Locations = []Location{location}
+	}
+	run.Results = append(run.Results, result)
+}
+
+// FromBreakingChanges returns a log with a result for each breaking change,
+// using the rule identifier of the breaking change as its rule.
+func FromBreakingChanges(breakingChanges []breaking_changes.BreakingChange) *Log {
+	l := NewLog()
+	for _, bc := range breakingChanges {
+		var file string
+		var line int
+		if bc.Location != nil {
+			file, line = bc.Location.File, bc.Location.Line
+		}
+		l.AddResult(bc.RuleName, bc.DocumentationReference, "error", bc.Message, file, line)
+	}
+	return l
+}
@@ -0,0 +1,91 @@
+package sarif
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/GoogleCloudPlatform/magic-modules/tools/diff-processor/breaking_changes"
+)
+
+func TestFromBreakingChanges(t *testing.T) {
+	breakingChanges := []breaking_changes.BreakingChange{
+		{
+			Resource:               "google-x",
+			Field:                  "field-a",
+			Message:                "Field `field-a` changed from optional to required on `google-x`",
+			DocumentationReference: "https://example.com#field-optional-to-required",
+			RuleName:               "field-optional-to-required",
+			Location:               &breaking_changes.SourceLocation{File: "mmv1/products/x/X.yaml", Line: 12},
+		},
+		{
+			Resource:               "google-y",
+			Message:                "Resource `google-y` was either removed or renamed",
+			DocumentationReference: "https://example.com#resource-map-resource-removal-or-rename",
+			RuleName:               "resource-map-resource-removal-or-rename",
+		},
+		{
+			Resource:               "google-x",
+			Field:                  "field-b",
+			Message:                "Field `field-b` changed from optional to required on `google-x`",
+			DocumentationReference: "https://example.com#field-optional-to-required",
+			RuleName:               "field-optional-to-required",
+			Location:               &breaking_changes.SourceLocation{File: "mmv1/products/x/X.yaml"},
+		},
+	}
+
+	want := []Run{
+		{
+			Tool: Tool{
+				Driver: Driver{
+					Name:           toolName,
+					InformationURI: toolURI,
+					Rules: []Rule{
+						{ID: "field-optional-to-required", HelpURI: "https://example.com#field-optional-to-required"},
+						{ID: "resource-map-resource-removal-or-rename", HelpURI: "https://example.com#resource-map-resource-removal-or-rename"},
+					},
+				},
+			},
+			Results: []Result{
+				{
+					RuleID:  "field-optional-to-required",
+					Level:   "error",
+					Message: Message{Text: "Field `field-a` changed from optional to required on `google-x`"},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "mmv1/products/x/X.yaml"},
+								Region:           &Region{StartLine: 12},
+							},
+						},
+					},
+				},
+				{
+					RuleID:  "resource-map-resource-removal-or-rename",
+					Level:   "error",
+					Message: Message{Text: "Resource `google-y` was either removed or renamed"},
+				},
+				{
+					RuleID:  "field-optional-to-required",
+					Level:   "error",
+					Message: Message{Text: "Field `field-b` changed from optional to required on `google-x`"},
+					Locations: []Location{
+						{
+							PhysicalLocation: PhysicalLocation{
+								ArtifactLocation: ArtifactLocation{URI: "mmv1/products/x/X.yaml"},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	got := FromBreakingChanges(breakingChanges)
+	if got.Version != "2.1.0" {
+		t.Errorf("expected version 2.1.0, got %s", got.Version)
+	}
+	if diff := cmp.Diff(want, got.Runs); diff != "" {
+		t.Errorf("run diff(-want, +got) = %s", diff)
+	}
+}